@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -49,6 +50,8 @@ type Addenda02 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda02 returns a new Addenda02 with default values for none exported fields
@@ -106,6 +109,30 @@ func (addenda02 *Addenda02) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda02 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda02 *Addenda02) AppendTo(b []byte) []byte {
+	b = append(b, addenda02.recordType...)
+	b = append(b, addenda02.typeCode...)
+	b = addenda02.appendAlphaField(b, addenda02.ReferenceInformationOne, 7)
+	b = addenda02.appendAlphaField(b, addenda02.ReferenceInformationOne, 3)
+	b = addenda02.appendAlphaField(b, addenda02.TerminalIdentificationCode, 6)
+	b = addenda02.appendAlphaField(b, addenda02.TransactionSerialNumber, 6)
+	b = append(b, addenda02.TransactionDate...)
+	b = addenda02.appendAlphaField(b, addenda02.AuthorizationCodeOrExpireDate, 6)
+	b = addenda02.appendAlphaField(b, addenda02.TerminalLocation, 27)
+	b = addenda02.appendAlphaField(b, addenda02.TerminalCity, 15)
+	b = addenda02.appendAlphaField(b, addenda02.TerminalState, 2)
+	b = addenda02.appendNumericField(b, addenda02.TraceNumber, 15)
+	return b
+}
+
+// WriteTo writes the Addenda02 record to w as the 94 characters of String.
+func (addenda02 *Addenda02) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda02.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda02 *Addenda02) Validate() error {
@@ -114,47 +141,47 @@ func (addenda02 *Addenda02) Validate() error {
 	}
 	if addenda02.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda02.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda02.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda02.isTypeCode(addenda02.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda02.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda02.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 02
 	if addenda02.typeCode != "02" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda02.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda02.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.ReferenceInformationOne); err != nil {
-		return &FieldError{FieldName: "ReferenceInformationOne", Value: addenda02.ReferenceInformationOne, Msg: err.Error()}
+		return &FieldError{FieldName: "ReferenceInformationOne", Value: addenda02.ReferenceInformationOne, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.ReferenceInformationTwo); err != nil {
-		return &FieldError{FieldName: "ReferenceInformationTwo", Value: addenda02.ReferenceInformationTwo, Msg: err.Error()}
+		return &FieldError{FieldName: "ReferenceInformationTwo", Value: addenda02.ReferenceInformationTwo, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.TerminalIdentificationCode); err != nil {
-		return &FieldError{FieldName: "TerminalIdentificationCode", Value: addenda02.TerminalIdentificationCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TerminalIdentificationCode", Value: addenda02.TerminalIdentificationCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.TransactionSerialNumber); err != nil {
-		return &FieldError{FieldName: "TransactionSerialNumber", Value: addenda02.TransactionSerialNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "TransactionSerialNumber", Value: addenda02.TransactionSerialNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// TransactionDate Addenda02 ACH File format is MMDD.  Validate MM is 01-12.
 	if err := addenda02.isMonth(addenda02.parseStringField(addenda02.TransactionDate[0:2])); err != nil {
-		return &FieldError{FieldName: "TransactionDate", Value: addenda02.parseStringField(addenda02.TransactionDate[0:2]), Msg: msgValidMonth}
+		return &FieldError{FieldName: "TransactionDate", Value: addenda02.parseStringField(addenda02.TransactionDate[0:2]), Msg: msgValidMonth, Code: ErrInvalidDate.Code}
 	}
 	// TransactionDate Addenda02 ACH File format is MMDD.  If the month is valid, validate the day for the
 	// month 01-31 depending on month.
 	if err := addenda02.isDay(addenda02.parseStringField(addenda02.TransactionDate[0:2]), addenda02.parseStringField(addenda02.TransactionDate[2:4])); err != nil {
-		return &FieldError{FieldName: "TransactionDate", Value: addenda02.parseStringField(addenda02.TransactionDate[0:2]), Msg: msgValidDay}
+		return &FieldError{FieldName: "TransactionDate", Value: addenda02.parseStringField(addenda02.TransactionDate[0:2]), Msg: msgValidDay, Code: ErrInvalidDate.Code}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.AuthorizationCodeOrExpireDate); err != nil {
-		return &FieldError{FieldName: "AuthorizationCodeOrExpireDate", Value: addenda02.AuthorizationCodeOrExpireDate, Msg: err.Error()}
+		return &FieldError{FieldName: "AuthorizationCodeOrExpireDate", Value: addenda02.AuthorizationCodeOrExpireDate, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.TerminalLocation); err != nil {
-		return &FieldError{FieldName: "TerminalLocation", Value: addenda02.TerminalLocation, Msg: err.Error()}
+		return &FieldError{FieldName: "TerminalLocation", Value: addenda02.TerminalLocation, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.TerminalCity); err != nil {
-		return &FieldError{FieldName: "TerminalCity", Value: addenda02.TerminalCity, Msg: err.Error()}
+		return &FieldError{FieldName: "TerminalCity", Value: addenda02.TerminalCity, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda02.isAlphanumeric(addenda02.TerminalState); err != nil {
-		return &FieldError{FieldName: "TerminalState", Value: addenda02.TerminalState, Msg: err.Error()}
+		return &FieldError{FieldName: "TerminalState", Value: addenda02.TerminalState, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -164,29 +191,29 @@ func (addenda02 *Addenda02) Validate() error {
 
 func (addenda02 *Addenda02) fieldInclusion() error {
 	if addenda02.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda02.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda02.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda02.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda02.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda02.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	// Required Fields
 	if addenda02.TerminalIdentificationCode == "" {
-		return &FieldError{FieldName: "TerminalIdentificationCode", Value: addenda02.TerminalIdentificationCode, Msg: msgFieldRequired}
+		return &FieldError{FieldName: "TerminalIdentificationCode", Value: addenda02.TerminalIdentificationCode, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	if addenda02.TransactionSerialNumber == "" {
-		return &FieldError{FieldName: "TransactionSerialNumber", Value: addenda02.TransactionSerialNumber, Msg: msgFieldRequired}
+		return &FieldError{FieldName: "TransactionSerialNumber", Value: addenda02.TransactionSerialNumber, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	if addenda02.TransactionDate == "" {
-		return &FieldError{FieldName: "TransactionDate", Value: addenda02.TransactionDate, Msg: msgFieldRequired}
+		return &FieldError{FieldName: "TransactionDate", Value: addenda02.TransactionDate, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	if addenda02.TerminalLocation == "" {
-		return &FieldError{FieldName: "TerminalLocation", Value: addenda02.TerminalLocation, Msg: msgFieldRequired}
+		return &FieldError{FieldName: "TerminalLocation", Value: addenda02.TerminalLocation, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	if addenda02.TerminalCity == "" {
-		return &FieldError{FieldName: "TerminalCity", Value: addenda02.TerminalCity, Msg: msgFieldRequired}
+		return &FieldError{FieldName: "TerminalCity", Value: addenda02.TerminalCity, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	if addenda02.TerminalState == "" {
-		return &FieldError{FieldName: "TerminalState", Value: addenda02.TerminalState, Msg: msgFieldRequired}
+		return &FieldError{FieldName: "TerminalState", Value: addenda02.TerminalState, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	return nil
 }
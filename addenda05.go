@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -33,6 +34,8 @@ type Addenda05 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda05 returns a new Addenda05 with default values for none exported fields
@@ -70,6 +73,23 @@ func (addenda05 *Addenda05) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda05 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda05 *Addenda05) AppendTo(b []byte) []byte {
+	b = append(b, addenda05.recordType...)
+	b = append(b, addenda05.typeCode...)
+	b = addenda05.appendAlphaField(b, addenda05.PaymentRelatedInformation, 80)
+	b = addenda05.appendNumericField(b, addenda05.SequenceNumber, 4)
+	b = addenda05.appendNumericField(b, addenda05.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda05 record to w as the 94 characters of String.
+func (addenda05 *Addenda05) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda05.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda05 *Addenda05) Validate() error {
@@ -78,17 +98,17 @@ func (addenda05 *Addenda05) Validate() error {
 	}
 	if addenda05.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda05.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda05.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda05.isTypeCode(addenda05.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda05.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda05.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 05
 	if addenda05.typeCode != "05" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda05.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda05.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda05.isAlphanumeric(addenda05.PaymentRelatedInformation); err != nil {
-		return &FieldError{FieldName: "PaymentRelatedInformation", Value: addenda05.PaymentRelatedInformation, Msg: err.Error()}
+		return &FieldError{FieldName: "PaymentRelatedInformation", Value: addenda05.PaymentRelatedInformation, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	return nil
@@ -98,16 +118,16 @@ func (addenda05 *Addenda05) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda05 *Addenda05) fieldInclusion() error {
 	if addenda05.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda05.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda05.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda05.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda05.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda05.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda05.SequenceNumber == 0 {
-		return &FieldError{FieldName: "SequenceNumber", Value: addenda05.SequenceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "SequenceNumber", Value: addenda05.SequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda05.EntryDetailSequenceNumber == 0 {
-		return &FieldError{FieldName: "EntryDetailSequenceNumber", Value: addenda05.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "EntryDetailSequenceNumber", Value: addenda05.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
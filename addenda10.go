@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -47,6 +48,8 @@ type Addenda10 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda10 returns a new Addenda10 with default values for none exported fields
@@ -93,6 +96,27 @@ func (addenda10 *Addenda10) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda10 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda10 *Addenda10) AppendTo(b []byte) []byte {
+	b = append(b, addenda10.recordType...)
+	b = append(b, addenda10.typeCode...)
+	// TransactionTypeCode Validator
+	b = append(b, addenda10.TransactionTypeCode...)
+	b = addenda10.appendNumericField(b, addenda10.ForeignPaymentAmount, 18)
+	b = addenda10.appendAlphaField(b, addenda10.ForeignTraceNumber, 22)
+	b = addenda10.appendAlphaField(b, addenda10.Name, 35)
+	b = addenda10.appendAlphaField(b, addenda10.reserved, 6)
+	b = addenda10.appendNumericField(b, addenda10.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda10 record to w as the 94 characters of String.
+func (addenda10 *Addenda10) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda10.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda10 *Addenda10) Validate() error {
@@ -101,24 +125,24 @@ func (addenda10 *Addenda10) Validate() error {
 	}
 	if addenda10.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda10.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda10.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda10.isTypeCode(addenda10.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda10.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda10.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 10
 	if addenda10.typeCode != "10" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda10.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda10.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda10.isTransactionTypeCode(addenda10.TransactionTypeCode); err != nil {
-		return &FieldError{FieldName: "TransactionTypeCode", Value: addenda10.TransactionTypeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TransactionTypeCode", Value: addenda10.TransactionTypeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// ToDo: Foreign Payment Amount blank ?
 	if err := addenda10.isAlphanumeric(addenda10.ForeignTraceNumber); err != nil {
-		return &FieldError{FieldName: "ForeignTraceNumber", Value: addenda10.ForeignTraceNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "ForeignTraceNumber", Value: addenda10.ForeignTraceNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda10.isAlphanumeric(addenda10.Name); err != nil {
-		return &FieldError{FieldName: "Name", Value: addenda10.Name, Msg: err.Error()}
+		return &FieldError{FieldName: "Name", Value: addenda10.Name, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -127,26 +151,26 @@ func (addenda10 *Addenda10) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda10 *Addenda10) fieldInclusion() error {
 	if addenda10.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda10.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda10.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda10.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda10.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda10.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda10.TransactionTypeCode == "" {
 		return &FieldError{FieldName: "TransactionTypeCode",
-			Value: addenda10.TransactionTypeCode, Msg: msgFieldRequired}
+			Value: addenda10.TransactionTypeCode, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	// ToDo:  Commented because it appears this value can be all 000 (maybe blank?)
 	/*	if addenda10.ForeignPaymentAmount == 0 {
 		return &FieldError{FieldName: "ForeignPaymentAmount",
-			Value: strconv.Itoa(addenda10.ForeignPaymentAmount), Msg: msgFieldRequired}
+			Value: strconv.Itoa(addenda10.ForeignPaymentAmount), Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}*/
 	if addenda10.Name == "" {
-		return &FieldError{FieldName: "Name", Value: addenda10.Name, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "Name", Value: addenda10.Name, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda10.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda10.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda10.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -38,6 +39,8 @@ type Addenda11 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda11 returns a new Addenda11 with default values for none exported fields
@@ -77,6 +80,24 @@ func (addenda11 *Addenda11) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda11 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda11 *Addenda11) AppendTo(b []byte) []byte {
+	b = append(b, addenda11.recordType...)
+	b = append(b, addenda11.typeCode...)
+	b = addenda11.appendAlphaField(b, addenda11.OriginatorName, 35)
+	b = addenda11.appendAlphaField(b, addenda11.OriginatorStreetAddress, 35)
+	b = addenda11.appendAlphaField(b, addenda11.reserved, 14)
+	b = addenda11.appendNumericField(b, addenda11.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda11 record to w as the 94 characters of String.
+func (addenda11 *Addenda11) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda11.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda11 *Addenda11) Validate() error {
@@ -85,20 +106,20 @@ func (addenda11 *Addenda11) Validate() error {
 	}
 	if addenda11.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda11.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda11.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda11.isTypeCode(addenda11.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda11.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda11.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 11
 	if addenda11.typeCode != "11" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda11.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda11.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda11.isAlphanumeric(addenda11.OriginatorName); err != nil {
-		return &FieldError{FieldName: "OriginatorName", Value: addenda11.OriginatorName, Msg: err.Error()}
+		return &FieldError{FieldName: "OriginatorName", Value: addenda11.OriginatorName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda11.isAlphanumeric(addenda11.OriginatorStreetAddress); err != nil {
-		return &FieldError{FieldName: "OriginatorStreetAddress", Value: addenda11.OriginatorStreetAddress, Msg: err.Error()}
+		return &FieldError{FieldName: "OriginatorStreetAddress", Value: addenda11.OriginatorStreetAddress, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -107,22 +128,22 @@ func (addenda11 *Addenda11) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda11 *Addenda11) fieldInclusion() error {
 	if addenda11.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda11.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda11.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda11.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda11.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda11.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda11.OriginatorName == "" {
 		return &FieldError{FieldName: "OriginatorName",
-			Value: addenda11.OriginatorName, Msg: msgFieldInclusion}
+			Value: addenda11.OriginatorName, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda11.OriginatorStreetAddress == "" {
 		return &FieldError{FieldName: "OriginatorStreetAddress",
-			Value: addenda11.OriginatorStreetAddress, Msg: msgFieldInclusion}
+			Value: addenda11.OriginatorStreetAddress, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda11.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda11.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda11.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -43,6 +44,8 @@ type Addenda12 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda12 returns a new Addenda12 with default values for none exported fields
@@ -83,6 +86,25 @@ func (addenda12 *Addenda12) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda12 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda12 *Addenda12) AppendTo(b []byte) []byte {
+	b = append(b, addenda12.recordType...)
+	b = append(b, addenda12.typeCode...)
+	b = addenda12.appendAlphaField(b, addenda12.OriginatorCityStateProvince, 35)
+	// ToDo Validator for backslash
+	b = addenda12.appendAlphaField(b, addenda12.OriginatorCountryPostalCode, 35)
+	b = addenda12.appendAlphaField(b, addenda12.reserved, 14)
+	b = addenda12.appendNumericField(b, addenda12.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda12 record to w as the 94 characters of String.
+func (addenda12 *Addenda12) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda12.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda12 *Addenda12) Validate() error {
@@ -91,22 +113,30 @@ func (addenda12 *Addenda12) Validate() error {
 	}
 	if addenda12.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda12.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda12.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda12.isTypeCode(addenda12.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda12.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda12.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 12
 	if addenda12.typeCode != "12" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda12.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda12.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda12.isAlphanumeric(addenda12.OriginatorCityStateProvince); err != nil {
 		return &FieldError{FieldName: "OriginatorCityStateProvince",
-			Value: addenda12.OriginatorCityStateProvince, Msg: err.Error()}
+			Value: addenda12.OriginatorCityStateProvince, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda12.isCityStateProvince(addenda12.OriginatorCityStateProvince); err != nil {
+		return &FieldError{FieldName: "OriginatorCityStateProvince",
+			Value: addenda12.OriginatorCityStateProvince, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda12.isAlphanumeric(addenda12.OriginatorCountryPostalCode); err != nil {
 		return &FieldError{FieldName: "OriginatorCountryPostalCode",
-			Value: addenda12.OriginatorCountryPostalCode, Msg: err.Error()}
+			Value: addenda12.OriginatorCountryPostalCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda12.isCountryPostalCode(addenda12.OriginatorCountryPostalCode); err != nil {
+		return &FieldError{FieldName: "OriginatorCountryPostalCode",
+			Value: addenda12.OriginatorCountryPostalCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -115,22 +145,22 @@ func (addenda12 *Addenda12) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda12 *Addenda12) fieldInclusion() error {
 	if addenda12.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda12.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda12.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda12.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda12.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda12.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda12.OriginatorCityStateProvince == "" {
 		return &FieldError{FieldName: "OriginatorCityStateProvince",
-			Value: addenda12.OriginatorCityStateProvince, Msg: msgFieldInclusion}
+			Value: addenda12.OriginatorCityStateProvince, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda12.OriginatorCountryPostalCode == "" {
 		return &FieldError{FieldName: "OriginatorCountryPostalCode",
-			Value: addenda12.OriginatorCountryPostalCode, Msg: msgFieldInclusion}
+			Value: addenda12.OriginatorCountryPostalCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda12.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda12.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda12.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
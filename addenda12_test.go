@@ -364,3 +364,32 @@ func BenchmarkAddenda12String(b *testing.B) {
 		testAddenda12String(b)
 	}
 }
+
+// testOriginatorAddressDelimiters validates the * and \ delimiters of the Originator address
+func testOriginatorAddressDelimiters(t testing.TB) {
+	addenda12 := mockAddenda12()
+	addenda12.OriginatorCityStateProvince = "JacobsTown PA"
+	err := addenda12.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "OriginatorCityStateProvince" || e.Msg != msgCityStateProvince {
+		t.Errorf("%T: %s", err, err)
+	}
+	addenda12 = mockAddenda12()
+	addenda12.OriginatorCountryPostalCode = "XX*19305\\"
+	err = addenda12.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "OriginatorCountryPostalCode" || e.Msg != msgValidCountryCode {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestOriginatorAddressDelimiters tests the * and \ delimiters of the Originator address
+func TestOriginatorAddressDelimiters(t *testing.T) {
+	testOriginatorAddressDelimiters(t)
+}
+
+// BenchmarkOriginatorAddressDelimiters benchmarks the * and \ delimiters of the Originator address
+func BenchmarkOriginatorAddressDelimiters(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testOriginatorAddressDelimiters(b)
+	}
+}
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -59,6 +60,8 @@ type Addenda13 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda13 returns a new Addenda13 with default values for none exported fields
@@ -104,6 +107,26 @@ func (addenda13 *Addenda13) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda13 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda13 *Addenda13) AppendTo(b []byte) []byte {
+	b = append(b, addenda13.recordType...)
+	b = append(b, addenda13.typeCode...)
+	b = addenda13.appendAlphaField(b, addenda13.ODFIName, 35)
+	b = addenda13.appendAlphaField(b, addenda13.ODFIIDNumberQualifier, 2)
+	b = addenda13.appendAlphaField(b, addenda13.ODFIIdentification, 34)
+	b = addenda13.appendAlphaField(b, addenda13.ODFIBranchCountryCode, 3)
+	b = addenda13.appendAlphaField(b, addenda13.reserved, 10)
+	b = addenda13.appendNumericField(b, addenda13.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda13 record to w as the 94 characters of String.
+func (addenda13 *Addenda13) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda13.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda13 *Addenda13) Validate() error {
@@ -112,31 +135,35 @@ func (addenda13 *Addenda13) Validate() error {
 	}
 	if addenda13.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda13.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda13.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda13.isTypeCode(addenda13.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda13.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda13.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 13
 	if addenda13.typeCode != "13" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda13.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda13.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda13.isAlphanumeric(addenda13.ODFIName); err != nil {
 		return &FieldError{FieldName: "ODFIName",
-			Value: addenda13.ODFIName, Msg: err.Error()}
+			Value: addenda13.ODFIName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Valid ODFI Identification Number Qualifier
 	if err := addenda13.isIDNumberQualifier(addenda13.ODFIIDNumberQualifier); err != nil {
 		return &FieldError{FieldName: "ODFIIDNumberQualifier",
-			Value: addenda13.ODFIIDNumberQualifier, Msg: err.Error()}
+			Value: addenda13.ODFIIDNumberQualifier, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda13.isAlphanumeric(addenda13.ODFIIdentification); err != nil {
 		return &FieldError{FieldName: "ODFIIdentification",
-			Value: addenda13.ODFIIdentification, Msg: err.Error()}
+			Value: addenda13.ODFIIdentification, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda13.isAlphanumeric(addenda13.ODFIBranchCountryCode); err != nil {
 		return &FieldError{FieldName: "ODFIBranchCountryCode",
-			Value: addenda13.ODFIBranchCountryCode, Msg: err.Error()}
+			Value: addenda13.ODFIBranchCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda13.isCountryCode(addenda13.ODFIBranchCountryCode); err != nil {
+		return &FieldError{FieldName: "ODFIBranchCountryCode",
+			Value: addenda13.ODFIBranchCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -145,30 +172,30 @@ func (addenda13 *Addenda13) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda13 *Addenda13) fieldInclusion() error {
 	if addenda13.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda13.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda13.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda13.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda13.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda13.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda13.ODFIName == "" {
 		return &FieldError{FieldName: "ODFIName",
-			Value: addenda13.ODFIName, Msg: msgFieldInclusion}
+			Value: addenda13.ODFIName, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda13.ODFIIDNumberQualifier == "" {
 		return &FieldError{FieldName: "ODFIIDNumberQualifier",
-			Value: addenda13.ODFIIDNumberQualifier, Msg: msgFieldInclusion}
+			Value: addenda13.ODFIIDNumberQualifier, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda13.ODFIIdentification == "" {
 		return &FieldError{FieldName: "ODFIIdentification",
-			Value: addenda13.ODFIIdentification, Msg: msgFieldInclusion}
+			Value: addenda13.ODFIIdentification, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda13.ODFIBranchCountryCode == "" {
 		return &FieldError{FieldName: "ODFIBranchCountryCode",
-			Value: addenda13.ODFIBranchCountryCode, Msg: msgFieldInclusion}
+			Value: addenda13.ODFIBranchCountryCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda13.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda13.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda13.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -55,6 +56,8 @@ type Addenda14 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda14 returns a new Addenda14 with default values for none exported fields
@@ -100,6 +103,26 @@ func (addenda14 *Addenda14) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda14 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda14 *Addenda14) AppendTo(b []byte) []byte {
+	b = append(b, addenda14.recordType...)
+	b = append(b, addenda14.typeCode...)
+	b = addenda14.appendAlphaField(b, addenda14.RDFIName, 35)
+	b = addenda14.appendAlphaField(b, addenda14.RDFIIDNumberQualifier, 2)
+	b = addenda14.appendAlphaField(b, addenda14.RDFIIdentification, 34)
+	b = addenda14.appendAlphaField(b, addenda14.RDFIBranchCountryCode, 3)
+	b = addenda14.appendAlphaField(b, addenda14.reserved, 10)
+	b = addenda14.appendNumericField(b, addenda14.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda14 record to w as the 94 characters of String.
+func (addenda14 *Addenda14) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda14.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda14 *Addenda14) Validate() error {
@@ -108,31 +131,35 @@ func (addenda14 *Addenda14) Validate() error {
 	}
 	if addenda14.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda14.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda14.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda14.isTypeCode(addenda14.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda14.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda14.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 14
 	if addenda14.typeCode != "14" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda14.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda14.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda14.isAlphanumeric(addenda14.RDFIName); err != nil {
 		return &FieldError{FieldName: "RDFIName",
-			Value: addenda14.RDFIName, Msg: err.Error()}
+			Value: addenda14.RDFIName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Valid RDFI Identification Number Qualifier
 	if err := addenda14.isIDNumberQualifier(addenda14.RDFIIDNumberQualifier); err != nil {
 		return &FieldError{FieldName: "RDFIIDNumberQualifier",
-			Value: addenda14.RDFIIDNumberQualifier, Msg: msgIDNumberQualifier}
+			Value: addenda14.RDFIIDNumberQualifier, Msg: msgIDNumberQualifier, Code: ErrInvalidIDNumberQualifier.Code}
 	}
 	if err := addenda14.isAlphanumeric(addenda14.RDFIIdentification); err != nil {
 		return &FieldError{FieldName: "RDFIIdentification",
-			Value: addenda14.RDFIIdentification, Msg: err.Error()}
+			Value: addenda14.RDFIIdentification, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda14.isAlphanumeric(addenda14.RDFIBranchCountryCode); err != nil {
 		return &FieldError{FieldName: "RDFIBranchCountryCode",
-			Value: addenda14.RDFIBranchCountryCode, Msg: err.Error()}
+			Value: addenda14.RDFIBranchCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda14.isCountryCode(addenda14.RDFIBranchCountryCode); err != nil {
+		return &FieldError{FieldName: "RDFIBranchCountryCode",
+			Value: addenda14.RDFIBranchCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -141,30 +168,30 @@ func (addenda14 *Addenda14) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda14 *Addenda14) fieldInclusion() error {
 	if addenda14.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda14.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda14.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda14.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda14.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda14.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda14.RDFIName == "" {
 		return &FieldError{FieldName: "RDFIName",
-			Value: addenda14.RDFIName, Msg: msgFieldInclusion}
+			Value: addenda14.RDFIName, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda14.RDFIIDNumberQualifier == "" {
 		return &FieldError{FieldName: "RDFIIDNumberQualifier",
-			Value: addenda14.RDFIIDNumberQualifier, Msg: msgFieldInclusion}
+			Value: addenda14.RDFIIDNumberQualifier, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda14.RDFIIdentification == "" {
 		return &FieldError{FieldName: "RDFIIdentification",
-			Value: addenda14.RDFIIdentification, Msg: msgFieldInclusion}
+			Value: addenda14.RDFIIdentification, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda14.RDFIBranchCountryCode == "" {
 		return &FieldError{FieldName: "RDFIBranchCountryCode",
-			Value: addenda14.RDFIBranchCountryCode, Msg: msgFieldInclusion}
+			Value: addenda14.RDFIBranchCountryCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda14.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda14.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda14.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
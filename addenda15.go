@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -39,6 +40,8 @@ type Addenda15 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda15 returns a new Addenda15 with default values for none exported fields
@@ -78,6 +81,24 @@ func (addenda15 *Addenda15) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda15 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda15 *Addenda15) AppendTo(b []byte) []byte {
+	b = append(b, addenda15.recordType...)
+	b = append(b, addenda15.typeCode...)
+	b = addenda15.appendAlphaField(b, addenda15.ReceiverIDNumber, 15)
+	b = addenda15.appendAlphaField(b, addenda15.ReceiverStreetAddress, 35)
+	b = addenda15.appendAlphaField(b, addenda15.reserved, 34)
+	b = addenda15.appendNumericField(b, addenda15.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda15 record to w as the 94 characters of String.
+func (addenda15 *Addenda15) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda15.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda15 *Addenda15) Validate() error {
@@ -86,20 +107,20 @@ func (addenda15 *Addenda15) Validate() error {
 	}
 	if addenda15.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda15.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda15.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda15.isTypeCode(addenda15.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda15.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda15.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 15
 	if addenda15.typeCode != "15" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda15.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda15.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda15.isAlphanumeric(addenda15.ReceiverIDNumber); err != nil {
-		return &FieldError{FieldName: "ReceiverIDNumber", Value: addenda15.ReceiverIDNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "ReceiverIDNumber", Value: addenda15.ReceiverIDNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda15.isAlphanumeric(addenda15.ReceiverStreetAddress); err != nil {
-		return &FieldError{FieldName: "ReceiverStreetAddress", Value: addenda15.ReceiverStreetAddress, Msg: err.Error()}
+		return &FieldError{FieldName: "ReceiverStreetAddress", Value: addenda15.ReceiverStreetAddress, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -108,18 +129,18 @@ func (addenda15 *Addenda15) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda15 *Addenda15) fieldInclusion() error {
 	if addenda15.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda15.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda15.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda15.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda15.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda15.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda15.ReceiverStreetAddress == "" {
 		return &FieldError{FieldName: "ReceiverStreetAddress",
-			Value: addenda15.ReceiverStreetAddress, Msg: msgFieldInclusion}
+			Value: addenda15.ReceiverStreetAddress, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda15.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda15.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda15.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
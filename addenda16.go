@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -42,6 +43,8 @@ type Addenda16 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda16 returns a new Addenda16 with default values for none exported fields
@@ -81,6 +84,24 @@ func (addenda16 *Addenda16) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda16 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda16 *Addenda16) AppendTo(b []byte) []byte {
+	b = append(b, addenda16.recordType...)
+	b = append(b, addenda16.typeCode...)
+	b = addenda16.appendAlphaField(b, addenda16.ReceiverCityStateProvince, 35)
+	b = addenda16.appendAlphaField(b, addenda16.ReceiverCountryPostalCode, 35)
+	b = addenda16.appendAlphaField(b, addenda16.reserved, 14)
+	b = addenda16.appendNumericField(b, addenda16.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda16 record to w as the 94 characters of String.
+func (addenda16 *Addenda16) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda16.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda16 *Addenda16) Validate() error {
@@ -89,22 +110,30 @@ func (addenda16 *Addenda16) Validate() error {
 	}
 	if addenda16.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda16.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda16.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda16.isTypeCode(addenda16.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda16.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda16.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 16
 	if addenda16.typeCode != "16" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda16.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda16.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda16.isAlphanumeric(addenda16.ReceiverCityStateProvince); err != nil {
 		return &FieldError{FieldName: "ReceiverCityStateProvince",
-			Value: addenda16.ReceiverCityStateProvince, Msg: err.Error()}
+			Value: addenda16.ReceiverCityStateProvince, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda16.isCityStateProvince(addenda16.ReceiverCityStateProvince); err != nil {
+		return &FieldError{FieldName: "ReceiverCityStateProvince",
+			Value: addenda16.ReceiverCityStateProvince, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda16.isAlphanumeric(addenda16.ReceiverCountryPostalCode); err != nil {
 		return &FieldError{FieldName: "ReceiverCountryPostalCode",
-			Value: addenda16.ReceiverCountryPostalCode, Msg: err.Error()}
+			Value: addenda16.ReceiverCountryPostalCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda16.isCountryPostalCode(addenda16.ReceiverCountryPostalCode); err != nil {
+		return &FieldError{FieldName: "ReceiverCountryPostalCode",
+			Value: addenda16.ReceiverCountryPostalCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -113,22 +142,22 @@ func (addenda16 *Addenda16) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda16 *Addenda16) fieldInclusion() error {
 	if addenda16.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda16.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda16.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda16.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda16.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda16.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda16.ReceiverCityStateProvince == "" {
 		return &FieldError{FieldName: "ReceiverCityStateProvince",
-			Value: addenda16.ReceiverCityStateProvince, Msg: msgFieldInclusion}
+			Value: addenda16.ReceiverCityStateProvince, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda16.ReceiverCountryPostalCode == "" {
 		return &FieldError{FieldName: "ReceiverCountryPostalCode",
-			Value: addenda16.ReceiverCountryPostalCode, Msg: msgFieldInclusion}
+			Value: addenda16.ReceiverCountryPostalCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda16.EntryDetailSequenceNumber == 0 {
 		return &FieldError{FieldName: "EntryDetailSequenceNumber",
-			Value: addenda16.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+			Value: addenda16.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
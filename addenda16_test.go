@@ -364,3 +364,32 @@ func BenchmarkAddenda16String(b *testing.B) {
 		testAddenda16String(b)
 	}
 }
+
+// testReceiverAddressDelimiters validates the * and \ delimiters of the Receiver address
+func testReceiverAddressDelimiters(t testing.TB) {
+	addenda16 := mockAddenda16()
+	addenda16.ReceiverCityStateProvince = "LetterTown*AB"
+	err := addenda16.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ReceiverCityStateProvince" || e.Msg != msgCityStateProvince {
+		t.Errorf("%T: %s", err, err)
+	}
+	addenda16 = mockAddenda16()
+	addenda16.ReceiverCountryPostalCode = "CA80014\\"
+	err = addenda16.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ReceiverCountryPostalCode" || e.Msg != msgCountryPostalCode {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestReceiverAddressDelimiters tests the * and \ delimiters of the Receiver address
+func TestReceiverAddressDelimiters(t *testing.T) {
+	testReceiverAddressDelimiters(t)
+}
+
+// BenchmarkReceiverAddressDelimiters benchmarks the * and \ delimiters of the Receiver address
+func BenchmarkReceiverAddressDelimiters(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReceiverAddressDelimiters(b)
+	}
+}
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -38,6 +39,8 @@ type Addenda17 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda17 returns a new Addenda17 with default values for none exported fields
@@ -75,6 +78,23 @@ func (addenda17 *Addenda17) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda17 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda17 *Addenda17) AppendTo(b []byte) []byte {
+	b = append(b, addenda17.recordType...)
+	b = append(b, addenda17.typeCode...)
+	b = addenda17.appendAlphaField(b, addenda17.PaymentRelatedInformation, 80)
+	b = addenda17.appendNumericField(b, addenda17.SequenceNumber, 4)
+	b = addenda17.appendNumericField(b, addenda17.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda17 record to w as the 94 characters of String.
+func (addenda17 *Addenda17) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda17.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda17 *Addenda17) Validate() error {
@@ -83,17 +103,17 @@ func (addenda17 *Addenda17) Validate() error {
 	}
 	if addenda17.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda17.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda17.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda17.isTypeCode(addenda17.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda17.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda17.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 17
 	if addenda17.typeCode != "17" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda17.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda17.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda17.isAlphanumeric(addenda17.PaymentRelatedInformation); err != nil {
-		return &FieldError{FieldName: "PaymentRelatedInformation", Value: addenda17.PaymentRelatedInformation, Msg: err.Error()}
+		return &FieldError{FieldName: "PaymentRelatedInformation", Value: addenda17.PaymentRelatedInformation, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	return nil
@@ -103,16 +123,16 @@ func (addenda17 *Addenda17) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda17 *Addenda17) fieldInclusion() error {
 	if addenda17.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda17.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda17.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda17.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda17.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda17.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda17.SequenceNumber == 0 {
-		return &FieldError{FieldName: "SequenceNumber", Value: addenda17.SequenceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "SequenceNumber", Value: addenda17.SequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda17.EntryDetailSequenceNumber == 0 {
-		return &FieldError{FieldName: "EntryDetailSequenceNumber", Value: addenda17.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "EntryDetailSequenceNumber", Value: addenda17.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -56,6 +57,8 @@ type Addenda18 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewAddenda18 returns a new Addenda18 with default values for none exported fields
@@ -108,6 +111,27 @@ func (addenda18 *Addenda18) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda18 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda18 *Addenda18) AppendTo(b []byte) []byte {
+	b = append(b, addenda18.recordType...)
+	b = append(b, addenda18.typeCode...)
+	b = addenda18.appendAlphaField(b, addenda18.ForeignCorrespondentBankName, 35)
+	b = addenda18.appendAlphaField(b, addenda18.ForeignCorrespondentBankIDNumberQualifier, 2)
+	b = addenda18.appendAlphaField(b, addenda18.ForeignCorrespondentBankIDNumber, 34)
+	b = addenda18.appendAlphaField(b, addenda18.ForeignCorrespondentBankBranchCountryCode, 3)
+	b = addenda18.appendAlphaField(b, addenda18.reserved, 6)
+	b = addenda18.appendNumericField(b, addenda18.SequenceNumber, 4)
+	b = addenda18.appendNumericField(b, addenda18.EntryDetailSequenceNumber, 7)
+	return b
+}
+
+// WriteTo writes the Addenda18 record to w as the 94 characters of String.
+func (addenda18 *Addenda18) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda18.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (addenda18 *Addenda18) Validate() error {
@@ -116,26 +140,30 @@ func (addenda18 *Addenda18) Validate() error {
 	}
 	if addenda18.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda18.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda18.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := addenda18.isTypeCode(addenda18.typeCode); err != nil {
-		return &FieldError{FieldName: "TypeCode", Value: addenda18.typeCode, Msg: err.Error()}
+		return &FieldError{FieldName: "TypeCode", Value: addenda18.typeCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// Type Code must be 18
 	if addenda18.typeCode != "18" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda18.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda18.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankName); err != nil {
-		return &FieldError{FieldName: "ForeignCorrespondentBankName", Value: addenda18.ForeignCorrespondentBankName, Msg: err.Error()}
+		return &FieldError{FieldName: "ForeignCorrespondentBankName", Value: addenda18.ForeignCorrespondentBankName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankIDNumberQualifier); err != nil {
-		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumberQualifier", Value: addenda18.ForeignCorrespondentBankIDNumberQualifier, Msg: err.Error()}
+		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumberQualifier", Value: addenda18.ForeignCorrespondentBankIDNumberQualifier, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankIDNumber); err != nil {
-		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumber", Value: addenda18.ForeignCorrespondentBankIDNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumber", Value: addenda18.ForeignCorrespondentBankIDNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := addenda18.isAlphanumeric(addenda18.ForeignCorrespondentBankBranchCountryCode); err != nil {
-		return &FieldError{FieldName: "ForeignCorrespondentBankBranchCountryCode", Value: addenda18.ForeignCorrespondentBankBranchCountryCode, Msg: err.Error()}
+		return &FieldError{FieldName: "ForeignCorrespondentBankBranchCountryCode", Value: addenda18.ForeignCorrespondentBankBranchCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := addenda18.isCountryCode(addenda18.ForeignCorrespondentBankBranchCountryCode); err != nil {
+		return &FieldError{FieldName: "ForeignCorrespondentBankBranchCountryCode",
+			Value: addenda18.ForeignCorrespondentBankBranchCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
@@ -144,28 +172,28 @@ func (addenda18 *Addenda18) Validate() error {
 // invalid the ACH transfer will be returned.
 func (addenda18 *Addenda18) fieldInclusion() error {
 	if addenda18.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: addenda18.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: addenda18.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda18.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda18.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.ForeignCorrespondentBankName == "" {
-		return &FieldError{FieldName: "ForeignCorrespondentBankName", Value: addenda18.ForeignCorrespondentBankName, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ForeignCorrespondentBankName", Value: addenda18.ForeignCorrespondentBankName, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.ForeignCorrespondentBankIDNumberQualifier == "" {
-		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumberQualifier", Value: addenda18.ForeignCorrespondentBankIDNumberQualifier, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumberQualifier", Value: addenda18.ForeignCorrespondentBankIDNumberQualifier, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.ForeignCorrespondentBankIDNumber == "" {
-		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumber", Value: addenda18.ForeignCorrespondentBankIDNumber, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ForeignCorrespondentBankIDNumber", Value: addenda18.ForeignCorrespondentBankIDNumber, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.ForeignCorrespondentBankBranchCountryCode == "" {
-		return &FieldError{FieldName: "ForeignCorrespondentBankBranchCountryCode", Value: addenda18.ForeignCorrespondentBankBranchCountryCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ForeignCorrespondentBankBranchCountryCode", Value: addenda18.ForeignCorrespondentBankBranchCountryCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.SequenceNumber == 0 {
-		return &FieldError{FieldName: "SequenceNumber", Value: addenda18.SequenceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "SequenceNumber", Value: addenda18.SequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if addenda18.EntryDetailSequenceNumber == 0 {
-		return &FieldError{FieldName: "EntryDetailSequenceNumber", Value: addenda18.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "EntryDetailSequenceNumber", Value: addenda18.EntryDetailSequenceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -375,3 +375,26 @@ func BenchmarkAddenda18TypeCode18(b *testing.B) {
 		testAddenda18TypeCode18(b)
 	}
 }
+
+// testAddenda18CountryCode validates ForeignCorrespondentBankBranchCountryCode is an ISO 3166-1 country code
+func testAddenda18CountryCode(t testing.TB) {
+	addenda18 := mockAddenda18()
+	addenda18.ForeignCorrespondentBankBranchCountryCode = "EU"
+	err := addenda18.Validate()
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "ForeignCorrespondentBankBranchCountryCode" || e.Msg != msgValidCountryCode {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestAddenda18CountryCode tests ForeignCorrespondentBankBranchCountryCode is an ISO 3166-1 country code
+func TestAddenda18CountryCode(t *testing.T) {
+	testAddenda18CountryCode(t)
+}
+
+// BenchmarkAddenda18CountryCode benchmarks ForeignCorrespondentBankBranchCountryCode is an ISO 3166-1 country code
+func BenchmarkAddenda18CountryCode(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testAddenda18CountryCode(b)
+	}
+}
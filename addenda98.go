@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -36,6 +37,8 @@ type Addenda98 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 var (
@@ -100,29 +103,50 @@ func (addenda98 *Addenda98) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda98 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (addenda98 *Addenda98) AppendTo(b []byte) []byte {
+	b = append(b, addenda98.recordType...)
+	b = append(b, addenda98.TypeCode()...)
+	b = append(b, addenda98.ChangeCode...)
+	b = addenda98.appendNumericField(b, addenda98.OriginalTrace, 15)
+	b = append(b, "      "...) // 6 char reserved field
+	b = addenda98.appendStringField(b, addenda98.OriginalDFI, 8)
+	b = addenda98.appendAlphaField(b, addenda98.CorrectedData, 29)
+	b = append(b, "               "...) // 15 char reserved field
+	b = addenda98.appendNumericField(b, addenda98.TraceNumber, 15)
+	return b
+}
+
+// WriteTo writes the Addenda98 record to w as the 94 characters of String.
+func (addenda98 *Addenda98) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(addenda98.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate verifies NACHA rules for Addenda98
 func (addenda98 *Addenda98) Validate() error {
 	if addenda98.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: addenda98.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: addenda98.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if addenda98.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda98.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: addenda98.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	// Type Code must be 98
 	if addenda98.typeCode != "98" {
-		return &FieldError{FieldName: "TypeCode", Value: addenda98.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: addenda98.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 
 	// Addenda98 requires a valid ChangeCode
 	_, ok := changeCodeDict[addenda98.ChangeCode]
 	if !ok {
-		return &FieldError{FieldName: "ChangeCode", Value: addenda98.ChangeCode, Msg: msgAddenda98ChangeCode}
+		return &FieldError{FieldName: "ChangeCode", Value: addenda98.ChangeCode, Msg: msgAddenda98ChangeCode, Code: ErrInvalidChangeCode.Code}
 	}
 
 	// Addenda98 Record must contain the corrected information corresponding to the Change Code used
 	if addenda98.CorrectedData == "" {
-		return &FieldError{FieldName: "CorrectedData", Value: addenda98.CorrectedData, Msg: msgAddenda98CorrectedData}
+		return &FieldError{FieldName: "CorrectedData", Value: addenda98.CorrectedData, Msg: msgAddenda98CorrectedData, Code: ErrInvalidCorrectedData.Code}
 	}
 
 	return nil
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 )
@@ -23,6 +24,29 @@ var (
 
 	// Error messages specific to Return Addenda
 	msgAddenda99ReturnCode = "found is not a valid return code"
+	msgAddenda99Late       = "was returned after the deadline of %s"
+)
+
+// Return time frames in Part 4.2 of the NACHA corporate rules and guidelines. Returns not
+// listed must be received by the ODFI within two banking days of the settlement date of the
+// original entry.
+var (
+	// returnCalendarDays are returns of unauthorized consumer debits, which the Receiver has
+	// sixty calendar days from the settlement date of the original entry to report. R29 is a
+	// corporate return and remains at two banking days.
+	returnCalendarDays = map[string]int{
+		"R05": 60, "R07": 60, "R10": 60, "R11": 60, "R33": 60, "R37": 60,
+		"R38": 60, "R51": 60, "R52": 60, "R53": 60,
+	}
+	// returnBankingDays are returns with a time frame other than two banking days. Dishonored
+	// returns are measured from the settlement date of the return.
+	returnBankingDays = map[string]int{
+		"R61": 5, "R67": 5, "R68": 5, "R69": 5, "R70": 5,
+	}
+	// returnNoDeadline are returns without a time frame, which are agreed between the ODFI and RDFI
+	returnNoDeadline = map[string]bool{
+		"R06": true, "R31": true,
+	}
 )
 
 func init() {
@@ -58,6 +82,8 @@ type Addenda99 struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // returnCode holds a return Code, Reason/Title, and Description
@@ -111,23 +137,47 @@ func (Addenda99 *Addenda99) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the Addenda99 record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (Addenda99 *Addenda99) AppendTo(b []byte) []byte {
+	b = append(b, Addenda99.recordType...)
+	b = append(b, Addenda99.TypeCode()...)
+	b = append(b, Addenda99.ReturnCode...)
+	b = Addenda99.appendNumericField(b, Addenda99.OriginalTrace, 15)
+	if Addenda99.DateOfDeath.IsZero() {
+		b = Addenda99.appendAlphaField(b, "", 6)
+	} else {
+		b = Addenda99.appendSimpleDate(b, Addenda99.DateOfDeath)
+	}
+	b = Addenda99.appendStringField(b, Addenda99.OriginalDFI, 8)
+	b = Addenda99.appendAlphaField(b, Addenda99.AddendaInformation, 44)
+	b = Addenda99.appendNumericField(b, Addenda99.TraceNumber, 15)
+	return b
+}
+
+// WriteTo writes the Addenda99 record to w as the 94 characters of String.
+func (Addenda99 *Addenda99) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(Addenda99.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate verifies NACHA rules for Addenda99
 func (Addenda99 *Addenda99) Validate() error {
 	if Addenda99.recordType != "7" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: Addenda99.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: Addenda99.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if Addenda99.typeCode == "" {
-		return &FieldError{FieldName: "TypeCode", Value: Addenda99.typeCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TypeCode", Value: Addenda99.typeCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if Addenda99.typeCode != "99" {
-		return &FieldError{FieldName: "TypeCode", Value: Addenda99.typeCode, Msg: msgAddendaTypeCode}
+		return &FieldError{FieldName: "TypeCode", Value: Addenda99.typeCode, Msg: msgAddendaTypeCode, Code: ErrInvalidAddendaTypeCode.Code}
 	}
 
 	_, ok := returnCodeDict[Addenda99.ReturnCode]
 	if !ok {
 		// Return Addenda requires a valid ReturnCode
-		return &FieldError{FieldName: "ReturnCode", Value: Addenda99.ReturnCode, Msg: msgAddenda99ReturnCode}
+		return &FieldError{FieldName: "ReturnCode", Value: Addenda99.ReturnCode, Msg: msgAddenda99ReturnCode, Code: ErrInvalidReturnCode.Code}
 	}
 	return nil
 }
@@ -190,6 +240,35 @@ func (Addenda99 *Addenda99) TraceNumberField() string {
 	return Addenda99.numericField(Addenda99.TraceNumber, 15)
 }
 
+// ReturnDeadline returns the last date the return may settle given the settlement date of the
+// original entry, skipping the weekends and holidays of c. A nil c uses the Federal Reserve
+// holidays. It returns false when the return code has no deadline.
+func (Addenda99 *Addenda99) ReturnDeadline(settlement time.Time, c *Calendar) (time.Time, bool) {
+	if returnNoDeadline[Addenda99.ReturnCode] {
+		return time.Time{}, false
+	}
+	if days, ok := returnCalendarDays[Addenda99.ReturnCode]; ok {
+		return settlement.AddDate(0, 0, days), true
+	}
+	if c == nil {
+		c = NewCalendar()
+	}
+	if days, ok := returnBankingDays[Addenda99.ReturnCode]; ok {
+		return c.AddBankingDays(settlement, days), true
+	}
+	return c.AddBankingDays(settlement, 2), true
+}
+
+// IsTimely returns true if a return settling on returned is within the time frame of its
+// return code, given the settlement date of the original entry. Only dates are compared.
+func (Addenda99 *Addenda99) IsTimely(settlement, returned time.Time, c *Calendar) bool {
+	deadline, ok := Addenda99.ReturnDeadline(settlement, c)
+	if !ok {
+		return true
+	}
+	return !returned.After(deadline) || isSameDate(returned, deadline)
+}
+
 func makeReturnCodeDict() map[string]*returnCode {
 	dict := make(map[string]*returnCode)
 
@@ -337,3 +337,57 @@ func BenchmarkAddenda99TypeCodeNil(b *testing.B) {
 		testAddenda99TypeCodeNil(b)
 	}
 }
+
+// testAddenda99ReturnDeadline validates the deadline and timeliness of return codes
+func testAddenda99ReturnDeadline(t testing.TB) {
+	// Friday before Columbus Day
+	settlement := mockDate(t, "2018-10-05")
+	tests := map[string]string{
+		"R01": "2018-10-10",
+		"R07": "2018-12-04",
+		"R29": "2018-10-10",
+		"R61": "2018-10-15",
+	}
+	addenda99 := mockAddenda99()
+	for code, want := range tests {
+		addenda99.ReturnCode = code
+		deadline, ok := addenda99.ReturnDeadline(settlement, nil)
+		if !ok || deadline.Format("2006-01-02") != want {
+			t.Errorf("%s: got %s %v want %s", code, deadline.Format("2006-01-02"), ok, want)
+		}
+		if !addenda99.IsTimely(settlement, mockDate(t, want).Add(15*time.Hour), nil) {
+			t.Errorf("%s: return on the deadline should be timely", code)
+		}
+		if addenda99.IsTimely(settlement, mockDate(t, want).AddDate(0, 0, 1), nil) {
+			t.Errorf("%s: return after the deadline should be late", code)
+		}
+	}
+
+	addenda99.ReturnCode = "R06"
+	if _, ok := addenda99.ReturnDeadline(settlement, nil); ok {
+		t.Error("R06 should not have a deadline")
+	}
+	if !addenda99.IsTimely(settlement, settlement.AddDate(1, 0, 0), nil) {
+		t.Error("R06 should always be timely")
+	}
+
+	c := NewCalendar()
+	c.AddClosure(mockDate(t, "2018-10-09"))
+	addenda99.ReturnCode = "R01"
+	if deadline, _ := addenda99.ReturnDeadline(settlement, c); deadline.Format("2006-01-02") != "2018-10-11" {
+		t.Errorf("closure was not skipped %s", deadline.Format("2006-01-02"))
+	}
+}
+
+// TestAddenda99ReturnDeadline tests the deadline and timeliness of return codes
+func TestAddenda99ReturnDeadline(t *testing.T) {
+	testAddenda99ReturnDeadline(t)
+}
+
+// BenchmarkAddenda99ReturnDeadline benchmarks the deadline and timeliness of return codes
+func BenchmarkAddenda99ReturnDeadline(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testAddenda99ReturnDeadline(b)
+	}
+}
@@ -41,7 +41,7 @@ func NewBatch(bh *BatchHeader) (Batcher, error) {
 		return NewBatchCTX(bh), nil
 	case "IAT":
 		msg := fmt.Sprintf(msgFileIATSEC, bh.StandardEntryClassCode)
-		return nil, &FileError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: msg}
+		return nil, &FileError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: msg, Code: ErrFileSECNotImplemented.Code}
 	case "POP":
 		return NewBatchPOP(bh), nil
 	case "POS":
@@ -59,7 +59,7 @@ func NewBatch(bh *BatchHeader) (Batcher, error) {
 	default:
 	}
 	msg := fmt.Sprintf(msgFileNoneSEC, bh.StandardEntryClassCode)
-	return nil, &FileError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: msg}
+	return nil, &FileError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: msg, Code: ErrFileSECNotImplemented.Code}
 }
 
 // verify checks basic valid NACHA batch rules. Assumes properly parsed records. This does not mean it is a valid batch as validity is tied to each batch type
@@ -68,35 +68,35 @@ func (batch *batch) verify() error {
 
 	// No entries in batch
 	if len(batch.Entries) <= 0 {
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries, Code: ErrBatchEntries.Code}
 	}
 	// verify field inclusion in all the records of the batch.
 	if err := batch.isFieldInclusion(); err != nil {
 		// convert the field error in to a batch error for a consistent api
 		if e, ok := err.(*FieldError); ok {
-			return &BatchError{BatchNumber: batchNumber, FieldName: e.FieldName, Msg: e.Msg}
+			return &BatchError{BatchNumber: batchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
 		}
-		return &BatchError{BatchNumber: batchNumber, FieldName: "FieldError", Msg: err.Error()}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "FieldError", Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// validate batch header and control codes are the same
 	if batch.Header.ServiceClassCode != batch.Control.ServiceClassCode {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.ServiceClassCode, batch.Control.ServiceClassCode)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "ServiceClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "ServiceClassCode", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	// Company Identification must match the Company ID from the batch header record
 	if batch.Header.CompanyIdentification != batch.Control.CompanyIdentification {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.CompanyIdentification, batch.Control.CompanyIdentification)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "CompanyIdentification", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "CompanyIdentification", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	// Control ODFIIdentification must be the same as batch header
 	if batch.Header.ODFIIdentification != batch.Control.ODFIIdentification {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.ODFIIdentification, batch.Control.ODFIIdentification)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "ODFIIdentification", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "ODFIIdentification", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	// batch number header and control must match
 	if batch.Header.BatchNumber != batch.Control.BatchNumber {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.BatchNumber, batch.Control.BatchNumber)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "BatchNumber", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "BatchNumber", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	if err := batch.isBatchEntryCount(); err != nil {
 		return err
@@ -134,7 +134,7 @@ func (batch *batch) build() error {
 		return err
 	}
 	if len(batch.Entries) <= 0 {
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries, Code: ErrBatchEntries.Code}
 	}
 	// Create record sequence numbers
 	entryCount := 0
@@ -256,7 +256,7 @@ func (batch *batch) isBatchEntryCount() error {
 	}
 	if entryCount != batch.Control.EntryAddendaCount {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, entryCount, batch.Control.EntryAddendaCount)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryAddendaCount", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryAddendaCount", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 	return nil
 }
@@ -268,12 +268,12 @@ func (batch *batch) isBatchAmount() error {
 	credit, debit := batch.calculateBatchAmounts()
 	if debit != batch.Control.TotalDebitEntryDollarAmount {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, debit, batch.Control.TotalDebitEntryDollarAmount)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalDebitEntryDollarAmount", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalDebitEntryDollarAmount", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 
 	if credit != batch.Control.TotalCreditEntryDollarAmount {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, credit, batch.Control.TotalCreditEntryDollarAmount)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalCreditEntryDollarAmount", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalCreditEntryDollarAmount", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 	return nil
 }
@@ -297,7 +297,7 @@ func (batch *batch) isSequenceAscending() error {
 	for _, entry := range batch.Entries {
 		if entry.TraceNumber <= lastSeq {
 			msg := fmt.Sprintf(msgBatchAscending, entry.TraceNumber, lastSeq)
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAscending.Code}
 		}
 		lastSeq = entry.TraceNumber
 	}
@@ -309,7 +309,7 @@ func (batch *batch) isEntryHash() error {
 	hashField := batch.calculateEntryHash()
 	if hashField != batch.Control.EntryHashField() {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, hashField, batch.Control.EntryHashField())
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryHash", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryHash", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 	return nil
 }
@@ -333,7 +333,7 @@ func (batch *batch) isOriginatorDNE() error {
 		for _, entry := range batch.Entries {
 			if entry.TransactionCode == 23 || entry.TransactionCode == 33 {
 				msg := fmt.Sprintf(msgBatchOriginatorDNE, batch.Header.OriginatorStatusCode)
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "OriginatorStatusCode", Msg: msg}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "OriginatorStatusCode", Msg: msg, Code: ErrBatchOriginatorDNE.Code}
 			}
 		}
 	}
@@ -343,10 +343,16 @@ func (batch *batch) isOriginatorDNE() error {
 // isTraceNumberODFI checks if the first 8 positions of the entry detail trace number
 // match the batch header ODFI
 func (batch *batch) isTraceNumberODFI() error {
+	odfi := batch.Header.ODFIIdentificationField()
+	n, err := strconv.Atoi(odfi)
 	for _, entry := range batch.Entries {
-		if batch.Header.ODFIIdentificationField() != entry.TraceNumberField()[:8] {
+		// the first 8 of the 15 digits of the trace number are compared without formatting it
+		if err == nil && isDigits(odfi) && entry.TraceNumber >= 0 && entry.TraceNumber%1e15/1e7 == n {
+			continue
+		}
+		if odfi != entry.TraceNumberField()[:8] {
 			msg := fmt.Sprintf(msgBatchTraceNumberNotODFI, batch.Header.ODFIIdentificationField(), entry.TraceNumberField()[:8])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ODFIIdentificationField", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ODFIIdentificationField", Msg: msg, Code: ErrBatchTraceNumberNotODFI.Code}
 		}
 	}
 	return nil
@@ -358,7 +364,7 @@ func (batch *batch) isAddendaSequence() error {
 		if len(entry.Addendum) > 0 {
 			// addenda without indicator flag of 1
 			if entry.AddendaRecordIndicator != 1 {
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "AddendaRecordIndicator", Msg: msgBatchAddendaIndicator}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "AddendaRecordIndicator", Msg: msgBatchAddendaIndicator, Code: ErrBatchAddendaIndicator.Code}
 			}
 			lastSeq := -1
 			// check if sequence is ascending
@@ -368,13 +374,13 @@ func (batch *batch) isAddendaSequence() error {
 
 					if a.SequenceNumber < lastSeq {
 						msg := fmt.Sprintf(msgBatchAscending, a.SequenceNumber, lastSeq)
-						return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "SequenceNumber", Msg: msg}
+						return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "SequenceNumber", Msg: msg, Code: ErrBatchAscending.Code}
 					}
 					lastSeq = a.SequenceNumber
 					// check that we are in the correct Entry Detail
 					if !(a.EntryDetailSequenceNumberField() == entry.TraceNumberField()[8:]) {
 						msg := fmt.Sprintf(msgBatchAddendaTraceNumber, a.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-						return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+						return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 					}
 				}
 			}
@@ -390,7 +396,7 @@ func (batch *batch) isAddendaCount(count int) error {
 	for _, entry := range batch.Entries {
 		if len(entry.Addendum) > count {
 			msg := fmt.Sprintf(msgBatchAddendaCount, len(entry.Addendum), count, batch.Header.StandardEntryClassCode)
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "AddendaCount", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "AddendaCount", Msg: msg, Code: ErrBatchAddendaCount.Code}
 		}
 
 	}
@@ -403,7 +409,7 @@ func (batch *batch) isTypeCode(typeCode string) error {
 		for _, addenda := range entry.Addendum {
 			if addenda.TypeCode() != typeCode {
 				msg := fmt.Sprintf(msgBatchTypeCode, addenda.TypeCode(), typeCode, batch.Header.StandardEntryClassCode)
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TypeCode", Msg: msg}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TypeCode", Msg: msg, Code: ErrBatchAddendaType.Code}
 			}
 		}
 	}
@@ -419,7 +425,7 @@ func (batch *batch) isCategory() error {
 				continue
 			}
 			if batch.Entries[i].Category != category {
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Category", Msg: msgBatchForwardReturn}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Category", Msg: msgBatchForwardReturn, Code: ErrBatchForwardReturn.Code}
 			}
 		}
 	}
@@ -434,7 +440,7 @@ func (batch *batch) isPaymentTypeCode() error {
 		if !strings.Contains(strings.ToUpper(entry.PaymentTypeField()), "S") && !strings.Contains(strings.ToUpper(entry.PaymentTypeField()), "R") {
 			// TODO dead code because PaymentTypeField always returns S regardless of Discretionary Data value
 			msg := fmt.Sprintf(msgBatchWebPaymentType, entry.PaymentTypeField())
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "PaymentType", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "PaymentType", Msg: msg, Code: ErrBatchPaymentType.Code}
 		}
 	}
 	return nil
@@ -47,33 +47,33 @@ func (batch *BatchARC) Validate() error {
 	// Add type specific validation.
 	if batch.Header.StandardEntryClassCode != "ARC" {
 		msg := fmt.Sprintf(msgBatchSECType, batch.Header.StandardEntryClassCode, "ARC")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg, Code: ErrBatchSECType.Code}
 	}
 
 	// ARC detail entries can only be a debit, ServiceClassCode must allow debits
 	switch batch.Header.ServiceClassCode {
 	case 200, 220, 280:
 		msg := fmt.Sprintf(msgBatchServiceClassCode, batch.Header.ServiceClassCode, "ARC")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ServiceClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ServiceClassCode", Msg: msg, Code: ErrBatchServiceClassCode.Code}
 	}
 
 	for _, entry := range batch.Entries {
 		// ARC detail entries must be a debit
 		if entry.CreditOrDebit() != "D" {
 			msg := fmt.Sprintf(msgBatchTransactionCodeCredit, entry.TransactionCode)
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TransactionCode", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TransactionCode", Msg: msg, Code: ErrBatchTransactionCode.Code}
 		}
 
 		// Amount must be 25,000 or less
 		if entry.Amount > 2500000 {
 			msg := fmt.Sprintf(msgBatchAmount, "25,000", "ARC")
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Amount", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Amount", Msg: msg, Code: ErrBatchAmount.Code}
 		}
 
 		// CheckSerialNumber underlying IdentificationNumber, must be defined
 		if entry.IdentificationNumber == "" {
 			msg := fmt.Sprintf(msgBatchCheckSerialNumber, "ARC")
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "CheckSerialNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "CheckSerialNumber", Msg: msg, Code: ErrBatchCheckSerialNumber.Code}
 		}
 	}
 	return nil
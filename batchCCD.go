@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"strings"
 )
 
 // BatchCCD is a batch file that handles SEC payment type CCD amd CCD+.
@@ -15,6 +16,16 @@ type BatchCCD struct {
 	batch
 }
 
+// HealthcareEFTDescription is the CompanyEntryDescription of CCD+ health care claim payments
+const HealthcareEFTDescription = "HCCLAIMPMT"
+
+var (
+	msgBatchCCDHealthcareDescription = "must be " + HealthcareEFTDescription + " for health care claim payments"
+	msgBatchCCDHealthcareAddenda     = "health care claim payments require an Addenda05 with a TRN reassociation segment"
+	msgBatchCCDHealthcareTRN         = "is not a valid TRN reassociation segment"
+	msgBatchCCDHealthcarePayer       = "must be 1 followed by the 9 digit payer TIN"
+)
+
 // NewBatchCCD returns a *BatchCCD
 func NewBatchCCD(bh *BatchHeader) *BatchCCD {
 	batch := new(BatchCCD)
@@ -41,9 +52,94 @@ func (batch *BatchCCD) Validate() error {
 	// Add type specific validation.
 	if batch.Header.StandardEntryClassCode != "CCD" {
 		msg := fmt.Sprintf(msgBatchSECType, batch.Header.StandardEntryClassCode, "CCD")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg, Code: ErrBatchSECType.Code}
 	}
 
+	if batch.IsHealthcareEFT() {
+		for _, entry := range batch.Entries {
+			switch entry.TransactionCode {
+			// Prenote credit  23, 33, 43, 53
+			// Prenote debit 28, 38, 48
+			case 23, 28, 33, 38, 43, 48, 53:
+				if len(entry.Addendum) == 0 {
+					continue
+				}
+			}
+			if _, err := HealthcareTRN(entry); err != nil {
+				if e, ok := err.(*FieldError); ok {
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
+				}
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsHealthcareEFT returns true if the batch holds CCD+ health care claim payments, which
+// Validate requires to carry a TRN reassociation segment in their Addenda05
+func (batch *BatchCCD) IsHealthcareEFT() bool {
+	return strings.TrimSpace(batch.Header.CompanyEntryDescription) == HealthcareEFTDescription
+}
+
+// AddHealthcareClaimPayment adds a health care claim payment to a batch with the
+// CompanyEntryDescription HCCLAIMPMT. The addenda of entry are replaced by an Addenda05 of the
+// TRN reassociation segment TRN*1*trace number*payer ID, where the payer ID is 1 followed by
+// the payer TIN.
+func (batch *BatchCCD) AddHealthcareClaimPayment(entry *EntryDetail, trn X12Trace) error {
+	if !batch.IsHealthcareEFT() {
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "CompanyEntryDescription", Msg: msgBatchCCDHealthcareDescription, Code: ErrBatchHealthcareEFT.Code}
+	}
+	if trn.TraceType == "" {
+		trn.TraceType = "1"
+	}
+	if err := validateHealthcareTRN(trn); err != nil {
+		if e, ok := err.(*FieldError); ok {
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
+		}
+		return err
+	}
+	segment := X12Segment{ID: "TRN", Elements: []string{trn.TraceType, trn.ReferenceID, trn.OriginatingCompanyID}}
+	addenda05 := NewAddenda05()
+	addenda05.PaymentRelatedInformation = segment.String() + X12SegmentTerminator
+	entry.Addendum = nil
+	entry.AddendaRecordIndicator = 1
+	entry.AddAddenda(addenda05)
+	batch.AddEntry(entry)
+	return nil
+}
+
+// HealthcareTRN returns the TRN reassociation segment of a CCD+ health care claim payment,
+// which matches the payment to the TRN of its 835 remittance advice
+func HealthcareTRN(entry *EntryDetail) (X12Trace, error) {
+	for _, addenda := range entry.Addendum {
+		addenda05, ok := addenda.(*Addenda05)
+		if !ok {
+			continue
+		}
+		segments, err := ParseX12(addenda05.PaymentRelatedInformation)
+		if err != nil || len(segments) == 0 || segments[0].ID != "TRN" {
+			return X12Trace{}, &FieldError{FieldName: "PaymentRelatedInformation", Value: addenda05.PaymentRelatedInformation, Msg: msgBatchCCDHealthcareTRN, Code: ErrBatchHealthcareEFT.Code}
+		}
+		s := segments[0]
+		trn := X12Trace{TraceType: s.Element(1), ReferenceID: s.Element(2), OriginatingCompanyID: s.Element(3)}
+		return trn, validateHealthcareTRN(trn)
+	}
+	return X12Trace{}, &FieldError{FieldName: "Addendum", Value: entry.TraceNumberField(), Msg: msgBatchCCDHealthcareAddenda, Code: ErrBatchHealthcareEFT.Code}
+}
+
+// validateHealthcareTRN checks the elements of a TRN reassociation segment
+func validateHealthcareTRN(trn X12Trace) error {
+	if trn.TraceType != "1" {
+		return &FieldError{FieldName: "TRN01", Value: trn.TraceType, Msg: msgBatchCCDHealthcareTRN, Code: ErrBatchHealthcareEFT.Code}
+	}
+	if trn.ReferenceID == "" || len(trn.ReferenceID) > 50 {
+		return &FieldError{FieldName: "TRN02", Value: trn.ReferenceID, Msg: msgBatchCCDHealthcareTRN, Code: ErrBatchHealthcareEFT.Code}
+	}
+	if len(trn.OriginatingCompanyID) != 10 || trn.OriginatingCompanyID[0] != '1' || !isDigits(trn.OriginatingCompanyID) {
+		return &FieldError{FieldName: "TRN03", Value: trn.OriginatingCompanyID, Msg: msgBatchCCDHealthcarePayer, Code: ErrBatchHealthcareEFT.Code}
+	}
 	return nil
 }
 
@@ -264,3 +264,79 @@ func BenchmarkBatchCCDReceivingCompanyField(b *testing.B) {
 		testBatchCCDReceivingCompanyField(b)
 	}
 }
+
+// mockHealthcareTRN creates the TRN reassociation segment of a health care claim payment
+func mockHealthcareTRN() X12Trace {
+	return X12Trace{TraceType: "1", ReferenceID: "12345678901", OriginatingCompanyID: "1512345678"}
+}
+
+// testBatchCCDHealthcareEFT validates building and reading CCD+ health care claim payments
+func testBatchCCDHealthcareEFT(t testing.TB) {
+	bh := mockBatchCCDHeader()
+	mockBatch := NewBatchCCD(bh)
+	entry := mockCCDEntryDetail()
+	entry.TransactionCode = 22
+	if err := mockBatch.AddHealthcareClaimPayment(entry, mockHealthcareTRN()); err == nil {
+		t.Error("expected an error for the CompanyEntryDescription")
+	}
+
+	bh.CompanyEntryDescription = HealthcareEFTDescription
+	if err := mockBatch.AddHealthcareClaimPayment(entry, X12Trace{ReferenceID: "12345678901", OriginatingCompanyID: "512345678"}); err == nil {
+		t.Error("expected an error for the payer ID")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "TRN03" || e.Msg != msgBatchCCDHealthcarePayer {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := mockBatch.AddHealthcareClaimPayment(entry, mockHealthcareTRN()); err != nil {
+		t.Fatal(err)
+	}
+	if err := mockBatch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	addenda05 := mockBatch.GetEntries()[0].Addendum[0].(*Addenda05)
+	if addenda05.PaymentRelatedInformation != "TRN*1*12345678901*1512345678\\" {
+		t.Errorf("PaymentRelatedInformation %q", addenda05.PaymentRelatedInformation)
+	}
+
+	// read the entry back from its records
+	read := new(EntryDetail)
+	read.Parse(entry.String())
+	received := NewAddenda05()
+	received.Parse(addenda05.String())
+	read.AddAddenda(received)
+	if trn, err := HealthcareTRN(read); err != nil || trn != mockHealthcareTRN() {
+		t.Errorf("TRN %#v %v", trn, err)
+	}
+
+	// health care claim payments require the TRN
+	addenda05.PaymentRelatedInformation = "Claim payment"
+	if err := mockBatch.Validate(); err == nil {
+		t.Error("expected an error for a missing TRN")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "PaymentRelatedInformation" {
+		t.Errorf("%T: %s", err, err)
+	}
+	mockBatch.GetEntries()[0].Addendum = nil
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 0
+	if err := mockBatch.Validate(); err == nil {
+		t.Error("expected an error for a missing Addenda05")
+	}
+	// prenotes do not need the TRN
+	bh.ServiceClassCode = 200
+	mockBatch.GetEntries()[0].TransactionCode = 28
+	mockBatch.GetEntries()[0].Amount = 0
+	if err := mockBatch.Create(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestBatchCCDHealthcareEFT tests building and reading CCD+ health care claim payments
+func TestBatchCCDHealthcareEFT(t *testing.T) {
+	testBatchCCDHealthcareEFT(t)
+}
+
+// BenchmarkBatchCCDHealthcareEFT benchmarks building and reading CCD+ health care claim payments
+func BenchmarkBatchCCDHealthcareEFT(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testBatchCCDHealthcareEFT(b)
+	}
+}
@@ -44,28 +44,28 @@ func (batch *BatchCIE) Validate() error {
 
 	if batch.Header.StandardEntryClassCode != "CIE" {
 		msg := fmt.Sprintf(msgBatchSECType, batch.Header.StandardEntryClassCode, "CIE")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg, Code: ErrBatchSECType.Code}
 	}
 
 	// CIE detail entries can only be a debit, ServiceClassCode must allow debits
 	switch batch.Header.ServiceClassCode {
 	case 200, 225, 280:
 		msg := fmt.Sprintf(msgBatchServiceClassCode, batch.Header.ServiceClassCode, "CIE")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ServiceClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ServiceClassCode", Msg: msg, Code: ErrBatchServiceClassCode.Code}
 	}
 
 	for _, entry := range batch.Entries {
 		// CIE detail entries must be a debit
 		if entry.CreditOrDebit() != "C" {
 			msg := fmt.Sprintf(msgBatchTransactionCodeCredit, entry.TransactionCode)
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TransactionCode", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TransactionCode", Msg: msg, Code: ErrBatchTransactionCode.Code}
 		}
 
 		// Addenda validations - CIE Addenda must be Addenda05
 
 		// Addendum must be equal to 1
 		if len(entry.Addendum) > 1 {
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchCIEAddenda}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchCIEAddenda, Code: ErrBatchAddendaCount.Code}
 		}
 
 		if len(entry.Addendum) > 0 {
@@ -73,14 +73,14 @@ func (batch *BatchCIE) Validate() error {
 			addenda05, ok := entry.Addendum[0].(*Addenda05)
 			if !ok {
 				msg := fmt.Sprintf(msgBatchCIEAddendaType, entry.Addendum[0])
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaType.Code}
 			}
 
 			// Addenda05 must be Validated
 			if err := addenda05.Validate(); err != nil {
 				// convert the field error in to a batch error for a consistent api
 				if e, ok := err.(*FieldError); ok {
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
 				}
 			}
 		}
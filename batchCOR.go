@@ -42,7 +42,7 @@ func (batch *BatchCOR) Validate() error {
 	// Add type specific validation.
 	if batch.Header.StandardEntryClassCode != "COR" {
 		msg := fmt.Sprintf(msgBatchSECType, batch.Header.StandardEntryClassCode, "COR")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg, Code: ErrBatchSECType.Code}
 	}
 
 	// The Amount field must be zero
@@ -75,7 +75,7 @@ func (batch *BatchCOR) Validate() error {
 			23, 28, 33, 38, 43, 48, 53,
 			24, 29, 34, 39, 44, 49, 54:
 			msg := fmt.Sprintf(msgBatchTransactionCode, entry.TransactionCode, "COR")
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TransactionCode", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TransactionCode", Msg: msg, Code: ErrBatchTransactionCode.Code}
 		}
 	}
 
@@ -97,19 +97,19 @@ func (batch *BatchCOR) isAddenda98() error {
 	for _, entry := range batch.Entries {
 		// Addenda type must be equal to 1
 		if len(entry.Addendum) != 1 {
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchCORAddenda}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchCORAddenda, Code: ErrBatchAddendaCount.Code}
 		}
 		// Addenda type assertion must be Addenda98
 		addenda98, ok := entry.Addendum[0].(*Addenda98)
 		if !ok {
 			msg := fmt.Sprintf(msgBatchCORAddendaType, entry.Addendum[0])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaType.Code}
 		}
 		// Addenda98 must be Validated
 		if err := addenda98.Validate(); err != nil {
 			// convert the field error in to a batch error for a consistent api
 			if e, ok := err.(*FieldError); ok {
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
 			}
 		}
 	}
@@ -45,7 +45,7 @@ func (batch *BatchCTX) Validate() error {
 
 	if batch.Header.StandardEntryClassCode != "CTX" {
 		msg := fmt.Sprintf(msgBatchSECType, batch.Header.StandardEntryClassCode, "CTX")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg, Code: ErrBatchSECType.Code}
 	}
 
 	for _, entry := range batch.Entries {
@@ -54,7 +54,7 @@ func (batch *BatchCTX) Validate() error {
 
 		// A maximum of 9999 addenda records for CTX entry details
 		if len(entry.Addendum) > 9999 {
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchCTXAddenda}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchCTXAddenda, Code: ErrBatchAddendaCount.Code}
 		}
 
 		// validate CTXAddendaRecord Field is equal to the actual number of Addenda records
@@ -62,7 +62,7 @@ func (batch *BatchCTX) Validate() error {
 		addendaRecords, _ := strconv.Atoi(entry.CTXAddendaRecordsField())
 		if len(entry.Addendum) != addendaRecords {
 			msg := fmt.Sprintf(msgBatchCTXAddendaCount, addendaRecords, len(entry.Addendum))
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaCount.Code}
 		}
 
 		if len(entry.Addendum) > 0 {
@@ -72,7 +72,7 @@ func (batch *BatchCTX) Validate() error {
 			// Prenote debit 28, 38, 48
 			case 23, 28, 33, 38, 43, 48, 53:
 				msg := fmt.Sprintf(msgBatchTransactionCodeAddenda, entry.TransactionCode, "CTX")
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchTransactionCodeAddenda.Code}
 			default:
 			}
 
@@ -80,12 +80,12 @@ func (batch *BatchCTX) Validate() error {
 				addenda05, ok := entry.Addendum[i].(*Addenda05)
 				if !ok {
 					msg := fmt.Sprintf(msgBatchCTXAddendaType, entry.Addendum[i])
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaType.Code}
 				}
 				if err := addenda05.Validate(); err != nil {
 					// convert the field error in to a batch error for a consistent api
 					if e, ok := err.(*FieldError); ok {
-						return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg}
+						return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
 					}
 				}
 			}
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -68,6 +69,8 @@ type BatchControl struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // Parse takes the input record string and parses the EntryDetail values
@@ -125,6 +128,29 @@ func (bc *BatchControl) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the BatchControl record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (bc *BatchControl) AppendTo(b []byte) []byte {
+	b = append(b, bc.recordType...)
+	b = strconv.AppendInt(b, int64(bc.ServiceClassCode), 10)
+	b = bc.appendNumericField(b, bc.EntryAddendaCount, 6)
+	b = bc.appendNumericField(b, bc.EntryHash, 10)
+	b = bc.appendNumericField(b, bc.TotalDebitEntryDollarAmount, 12)
+	b = bc.appendNumericField(b, bc.TotalCreditEntryDollarAmount, 12)
+	b = bc.appendAlphaField(b, bc.CompanyIdentification, 10)
+	b = bc.appendAlphaField(b, bc.MessageAuthenticationCode, 19)
+	b = append(b, "      "...)
+	b = bc.appendStringField(b, bc.ODFIIdentification, 8)
+	b = bc.appendNumericField(b, bc.BatchNumber, 7)
+	return b
+}
+
+// WriteTo writes the BatchControl record to w as the 94 characters of String.
+func (bc *BatchControl) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(bc.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (bc *BatchControl) Validate() error {
@@ -133,18 +159,18 @@ func (bc *BatchControl) Validate() error {
 	}
 	if bc.recordType != "8" {
 		msg := fmt.Sprintf(msgRecordType, 7)
-		return &FieldError{FieldName: "recordType", Value: bc.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: bc.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := bc.isServiceClass(bc.ServiceClassCode); err != nil {
-		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bc.ServiceClassCode), Msg: err.Error()}
+		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bc.ServiceClassCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	if err := bc.isAlphanumeric(bc.CompanyIdentification); err != nil {
-		return &FieldError{FieldName: "CompanyIdentification", Value: bc.CompanyIdentification, Msg: err.Error()}
+		return &FieldError{FieldName: "CompanyIdentification", Value: bc.CompanyIdentification, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	if err := bc.isAlphanumeric(bc.MessageAuthenticationCode); err != nil {
-		return &FieldError{FieldName: "MessageAuthenticationCode", Value: bc.MessageAuthenticationCode, Msg: err.Error()}
+		return &FieldError{FieldName: "MessageAuthenticationCode", Value: bc.MessageAuthenticationCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	return nil
@@ -154,13 +180,13 @@ func (bc *BatchControl) Validate() error {
 // invalid the ACH transfer will be returned.
 func (bc *BatchControl) fieldInclusion() error {
 	if bc.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: bc.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: bc.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bc.ServiceClassCode == 0 {
-		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bc.ServiceClassCode), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bc.ServiceClassCode), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bc.ODFIIdentification == "000000000" {
-		return &FieldError{FieldName: "ODFIIdentification", Value: bc.ODFIIdentificationField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ODFIIdentification", Value: bc.ODFIIdentificationField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
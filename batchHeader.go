@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -80,7 +81,10 @@ type BatchHeader struct {
 	// EffectiveEntryDate the date on which the entries are to settle
 	EffectiveEntryDate time.Time `json:"effectiveEntryDate,omitempty"`
 
-	// SettlementDate Leave blank, this field is inserted by the ACH operator
+	// SettlementDate is the date the ACH Operator settles the entries of the batch. It is
+	// inserted by the ACH Operator as a Julian day and left blank by Originators.
+	SettlementDate time.Time `json:"settlementDate,omitempty"`
+	// settlementDate is the Julian day of the SettlementDate as read
 	settlementDate string
 
 	// OriginatorStatusCode refers to the ODFI initiating the Entry.
@@ -104,6 +108,8 @@ type BatchHeader struct {
 
 	// converters is composed for ACH to golang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewBatchHeader returns a new BatchHeader with default values for non exported fields
@@ -142,8 +148,11 @@ func (bh *BatchHeader) Parse(record string) {
 	// 70-75 Date transactions are to be posted to the receivers’ account.
 	// You almost always want the transaction to post as soon as possible, so put tomorrow's date in YYMMDD format
 	bh.EffectiveEntryDate = bh.parseSimpleDate(record[69:75])
-	// 76-79 Always blank (just fill with spaces)
+	// 76-78 Julian day of the settlement date inserted by the ACH Operator, blank for Originators
 	bh.settlementDate = "   "
+	if isDigits(record[75:78]) {
+		bh.settlementDate = record[75:78]
+	}
 	// 79-79 Always 1
 	bh.OriginatorStatusCode = bh.parseNumField(record[78:79])
 	// 80-87 Your ODFI's routing number without the last digit. The last digit is simply a
@@ -167,13 +176,42 @@ func (bh *BatchHeader) String() string {
 	buf.WriteString(bh.CompanyEntryDescriptionField())
 	buf.WriteString(bh.CompanyDescriptiveDateField())
 	buf.WriteString(bh.EffectiveEntryDateField())
-	buf.WriteString(bh.settlementDateField())
+	buf.WriteString(bh.SettlementDateField())
 	buf.WriteString(fmt.Sprintf("%v", bh.OriginatorStatusCode))
 	buf.WriteString(bh.ODFIIdentificationField())
 	buf.WriteString(bh.BatchNumberField())
 	return buf.String()
 }
 
+// AppendTo appends the BatchHeader record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (bh *BatchHeader) AppendTo(b []byte) []byte {
+	b = append(b, bh.recordType...)
+	b = strconv.AppendInt(b, int64(bh.ServiceClassCode), 10)
+	b = bh.appendAlphaField(b, bh.CompanyName, 16)
+	b = bh.appendAlphaField(b, bh.CompanyDiscretionaryData, 20)
+	b = bh.appendAlphaField(b, bh.CompanyIdentification, 10)
+	b = append(b, bh.StandardEntryClassCode...)
+	b = bh.appendAlphaField(b, bh.CompanyEntryDescription, 10)
+	b = bh.appendAlphaField(b, bh.CompanyDescriptiveDate, 6)
+	b = bh.appendSimpleDate(b, bh.EffectiveEntryDate)
+	if !bh.SettlementDate.IsZero() {
+		b = bh.appendNumericField(b, bh.SettlementDate.YearDay(), 3)
+	} else {
+		b = bh.appendAlphaField(b, bh.settlementDate, 3)
+	}
+	b = strconv.AppendInt(b, int64(bh.OriginatorStatusCode), 10)
+	b = bh.appendStringField(b, bh.ODFIIdentification, 8)
+	b = bh.appendNumericField(b, bh.BatchNumber, 7)
+	return b
+}
+
+// WriteTo writes the BatchHeader record to w as the 94 characters of String.
+func (bh *BatchHeader) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(bh.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (bh *BatchHeader) Validate() error {
@@ -182,55 +220,75 @@ func (bh *BatchHeader) Validate() error {
 	}
 	if bh.recordType != "5" {
 		msg := fmt.Sprintf(msgRecordType, 5)
-		return &FieldError{FieldName: "recordType", Value: bh.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: bh.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := bh.isServiceClass(bh.ServiceClassCode); err != nil {
-		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bh.ServiceClassCode), Msg: err.Error()}
+		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bh.ServiceClassCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := bh.isSECCode(bh.StandardEntryClassCode); err != nil {
-		return &FieldError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: err.Error()}
+		return &FieldError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := bh.isOriginatorStatusCode(bh.OriginatorStatusCode); err != nil {
-		return &FieldError{FieldName: "OriginatorStatusCode", Value: strconv.Itoa(bh.OriginatorStatusCode), Msg: err.Error()}
+		return &FieldError{FieldName: "OriginatorStatusCode", Value: strconv.Itoa(bh.OriginatorStatusCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := bh.isAlphanumeric(bh.CompanyName); err != nil {
-		return &FieldError{FieldName: "CompanyName", Value: bh.CompanyName, Msg: err.Error()}
+		return &FieldError{FieldName: "CompanyName", Value: bh.CompanyName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := bh.isAlphanumeric(bh.CompanyDiscretionaryData); err != nil {
-		return &FieldError{FieldName: "CompanyDiscretionaryData", Value: bh.CompanyDiscretionaryData, Msg: err.Error()}
+		return &FieldError{FieldName: "CompanyDiscretionaryData", Value: bh.CompanyDiscretionaryData, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := bh.isAlphanumeric(bh.CompanyIdentification); err != nil {
-		return &FieldError{FieldName: "CompanyIdentification", Value: bh.CompanyIdentification, Msg: err.Error()}
+		return &FieldError{FieldName: "CompanyIdentification", Value: bh.CompanyIdentification, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := bh.isAlphanumeric(bh.CompanyEntryDescription); err != nil {
-		return &FieldError{FieldName: "CompanyEntryDescription", Value: bh.CompanyEntryDescription, Msg: err.Error()}
+		return &FieldError{FieldName: "CompanyEntryDescription", Value: bh.CompanyEntryDescription, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
 
+// ValidateWith performs Validate and the optional checks of opts. An EffectiveEntryDate
+// that is not a banking day is moved forward when opts.RollForwardEffectiveDate is set.
+func (bh *BatchHeader) ValidateWith(opts *ValidateOpts) error {
+	if err := bh.Validate(); err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	if opts.SameDay && bh.IsSameDay() && !isSameDaySettlement(bh.CompanyDescriptiveDate[2:]) {
+		return &FieldError{FieldName: "CompanyDescriptiveDate", Value: bh.CompanyDescriptiveDate, Msg: msgSameDayDescriptiveDate, Code: ErrSameDayDescriptiveDate.Code}
+	}
+	date, err := opts.validateEffectiveEntryDate(bh.EffectiveEntryDate)
+	if err != nil {
+		return err
+	}
+	bh.EffectiveEntryDate = date
+	return nil
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the ACH transfer will be returned.
 func (bh *BatchHeader) fieldInclusion() error {
 	if bh.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: bh.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: bh.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bh.ServiceClassCode == 0 {
-		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bh.ServiceClassCode), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ServiceClassCode", Value: strconv.Itoa(bh.ServiceClassCode), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bh.CompanyName == "" {
-		return &FieldError{FieldName: "CompanyName", Value: bh.CompanyName, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "CompanyName", Value: bh.CompanyName, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bh.CompanyIdentification == "" {
-		return &FieldError{FieldName: "CompanyIdentification", Value: bh.CompanyIdentification, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "CompanyIdentification", Value: bh.CompanyIdentification, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bh.StandardEntryClassCode == "" {
-		return &FieldError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "StandardEntryClassCode", Value: bh.StandardEntryClassCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bh.CompanyEntryDescription == "" {
-		return &FieldError{FieldName: "CompanyEntryDescription", Value: bh.CompanyEntryDescription, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "CompanyEntryDescription", Value: bh.CompanyEntryDescription, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if bh.ODFIIdentification == "" {
-		return &FieldError{FieldName: "ODFIIdentification", Value: bh.ODFIIdentificationField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ODFIIdentification", Value: bh.ODFIIdentificationField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -275,6 +333,18 @@ func (bh *BatchHeader) BatchNumberField() string {
 	return bh.numericField(bh.BatchNumber, 7)
 }
 
-func (bh *BatchHeader) settlementDateField() string {
+// SettlementDateField gets the Julian day of the SettlementDate, or blanks if it is not set
+func (bh *BatchHeader) SettlementDateField() string {
+	if !bh.SettlementDate.IsZero() {
+		return bh.formatJulianDate(bh.SettlementDate)
+	}
 	return bh.alphaField(bh.settlementDate, 3)
 }
+
+// resolveSettlementDate sets the SettlementDate of the Julian day read to the date of that day
+// nearest to the FileCreationDate of the file
+func (bh *BatchHeader) resolveSettlementDate(fileCreationDate time.Time) {
+	if bh.SettlementDate.IsZero() {
+		bh.SettlementDate = bh.parseJulianDate(bh.settlementDate, fileCreationDate)
+	}
+}
@@ -7,6 +7,7 @@ package ach
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockBatchheader creates a batch header
@@ -567,3 +568,43 @@ func BenchmarkBHFieldInclusionODFIIdentification(b *testing.B) {
 		testBHFieldInclusionODFIIdentification(b)
 	}
 }
+
+// testBatchHeaderSettlementDate validates the Julian settlement date inserted by the ACH Operator
+func testBatchHeaderSettlementDate(t testing.TB) {
+	var line = "5225companyname                         origid    PPDCHECKPAYMT0000020807302121076401250000001"
+	r := NewReader(strings.NewReader(line))
+	r.File.Header.FileCreationDate = time.Date(2008, time.July, 29, 0, 0, 0, 0, time.UTC)
+	r.line = line
+	if err := r.parseBatchHeader(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	record := r.currentBatch.GetHeader()
+	if !record.SettlementDate.Equal(time.Date(2008, time.July, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("SettlementDate %v", record.SettlementDate)
+	}
+	if record.String() != line {
+		t.Errorf("got %q", record.String())
+	}
+
+	bh := mockBatchHeader()
+	if bh.SettlementDateField() != "   " {
+		t.Errorf("Originator SettlementDate %q", bh.SettlementDateField())
+	}
+	bh.SettlementDate = time.Date(2018, time.July, 3, 0, 0, 0, 0, time.UTC)
+	if bh.SettlementDateField() != "184" || bh.String()[75:78] != "184" {
+		t.Errorf("ACH Operator SettlementDate %q", bh.SettlementDateField())
+	}
+}
+
+// TestBatchHeaderSettlementDate tests the Julian settlement date inserted by the ACH Operator
+func TestBatchHeaderSettlementDate(t *testing.T) {
+	testBatchHeaderSettlementDate(t)
+}
+
+// BenchmarkBatchHeaderSettlementDate benchmarks the Julian settlement date inserted by the ACH Operator
+func BenchmarkBatchHeaderSettlementDate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testBatchHeaderSettlementDate(b)
+	}
+}
@@ -41,7 +41,7 @@ func (batch *BatchWEB) Validate() error {
 	// Add type specific validation.
 	if batch.Header.StandardEntryClassCode != "WEB" {
 		msg := fmt.Sprintf(msgBatchSECType, batch.Header.StandardEntryClassCode, "WEB")
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "StandardEntryClassCode", Msg: msg, Code: ErrBatchSECType.Code}
 	}
 
 	return batch.isPaymentTypeCode()
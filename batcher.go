@@ -36,6 +36,12 @@ type BatchError struct {
 	BatchNumber int
 	FieldName   string
 	Msg         string
+	// Code is the code of the error, ErrInvalidBatch when empty
+	Code ErrorCode
+	// Line is the line of the record read by a Reader, 0 otherwise
+	Line int
+	// Start and End are the positions of the field in the record, 0 when unknown
+	Start, End int
 }
 
 func (e *BatchError) Error() string {
@@ -0,0 +1,131 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"time"
+)
+
+// Errors specific to a banking day Calendar
+var (
+	msgBankingDay = "is not a banking day"
+)
+
+// Calendar determines the banking days on which ACH entries settle. Saturdays, Sundays and
+// the Federal Reserve holidays are closed, along with any closures added to the Calendar.
+// Only the date of a time.Time in its own location is considered.
+type Calendar struct {
+	closures map[string]bool
+}
+
+// NewCalendar returns a Calendar of the Federal Reserve holidays
+func NewCalendar() *Calendar {
+	return &Calendar{
+		closures: make(map[string]bool),
+	}
+}
+
+// AddClosure closes the date of t, such as a local holiday or an emergency closure
+func (c *Calendar) AddClosure(t time.Time) {
+	c.closures[t.Format("2006-01-02")] = true
+}
+
+// IsHoliday returns true if the date of t is a Federal Reserve holiday or a closure of the Calendar
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	if c.closures[t.Format("2006-01-02")] {
+		return true
+	}
+	return isFedHoliday(t)
+}
+
+// IsBankingDay returns true if the date of t is neither a weekend nor a holiday
+func (c *Calendar) IsBankingDay(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !c.IsHoliday(t)
+}
+
+// NextBankingDay returns the first banking day after the date of t
+func (c *Calendar) NextBankingDay(t time.Time) time.Time {
+	return c.AddBankingDays(t, 1)
+}
+
+// AddBankingDays returns the date n banking days after t, or before t when n is negative.
+// The time of day of t is kept.
+func (c *Calendar) AddBankingDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsBankingDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// rollForward returns t when it is a banking day, otherwise the next banking day
+func (c *Calendar) rollForward(t time.Time) time.Time {
+	if c.IsBankingDay(t) {
+		return t
+	}
+	return c.NextBankingDay(t)
+}
+
+// isFedHoliday returns true if the date of t is a Federal Reserve holiday. A holiday falling on a
+// Sunday is observed the following Monday. A holiday falling on a Saturday is not observed by the
+// Federal Reserve Banks, which stay open the preceding Friday.
+func isFedHoliday(t time.Time) bool {
+	year, month, day := t.Date()
+	switch month {
+	case time.January:
+		// New Year's Day and Birthday of Martin Luther King, Jr. (third Monday)
+		return isObserved(t, year, time.January, 1) || isNthWeekday(t, time.Monday, 3)
+	case time.February:
+		// Washington's Birthday (third Monday)
+		return isNthWeekday(t, time.Monday, 3)
+	case time.May:
+		// Memorial Day (last Monday)
+		return t.Weekday() == time.Monday && day+7 > 31
+	case time.June:
+		// Juneteenth National Independence Day, observed by the Federal Reserve Banks since 2022
+		return year >= 2022 && isObserved(t, year, time.June, 19)
+	case time.July:
+		// Independence Day
+		return isObserved(t, year, time.July, 4)
+	case time.September:
+		// Labor Day (first Monday)
+		return isNthWeekday(t, time.Monday, 1)
+	case time.October:
+		// Columbus Day (second Monday)
+		return isNthWeekday(t, time.Monday, 2)
+	case time.November:
+		// Veterans Day and Thanksgiving Day (fourth Thursday)
+		return isObserved(t, year, time.November, 11) || isNthWeekday(t, time.Thursday, 4)
+	case time.December:
+		// Christmas Day
+		return isObserved(t, year, time.December, 25)
+	}
+	return false
+}
+
+// isObserved returns true if t is the date of a fixed holiday, or the Monday after one falling on a Sunday
+func isObserved(t time.Time, year int, month time.Month, day int) bool {
+	holiday := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	if holiday.Weekday() == time.Sunday {
+		holiday = holiday.AddDate(0, 0, 1)
+	}
+	y, m, d := t.Date()
+	return y == year && m == month && d == holiday.Day()
+}
+
+// isNthWeekday returns true if t is the nth weekday of its month
+func isNthWeekday(t time.Time, weekday time.Weekday, n int) bool {
+	return t.Weekday() == weekday && (t.Day()-1)/7 == n-1
+}
@@ -0,0 +1,158 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"testing"
+	"time"
+)
+
+// mockDate returns the time.Time of a YYYY-MM-DD date
+func mockDate(t testing.TB, s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// testCalendarHolidays validates the Federal Reserve holidays
+func testCalendarHolidays(t testing.TB) {
+	c := NewCalendar()
+	holidays := []string{
+		"2018-01-01", "2018-01-15", "2018-02-19", "2018-05-28", "2018-07-04", "2018-09-03",
+		"2018-10-08", "2018-11-12", "2018-11-22", "2018-12-25",
+		// Juneteenth on a Sunday is observed on Monday
+		"2022-06-20",
+		// New Year's Day on a Sunday is observed on Monday
+		"2017-01-02",
+	}
+	for _, s := range holidays {
+		if c.IsBankingDay(mockDate(t, s)) {
+			t.Errorf("%s should be a holiday", s)
+		}
+	}
+	bankingDays := []string{
+		// Veterans Day 2018 is a Sunday observed on Monday
+		"2018-11-13",
+		// Juneteenth was first observed in 2022
+		"2021-06-18",
+		// Christmas Day 2021 on a Saturday is not observed on Friday
+		"2021-12-24",
+		"2018-05-21", "2018-10-09",
+	}
+	for _, s := range bankingDays {
+		if !c.IsBankingDay(mockDate(t, s)) {
+			t.Errorf("%s should be a banking day", s)
+		}
+	}
+	if c.IsBankingDay(mockDate(t, "2018-10-13")) || c.IsBankingDay(mockDate(t, "2018-10-14")) {
+		t.Error("weekends should not be banking days")
+	}
+}
+
+// TestCalendarHolidays tests the Federal Reserve holidays
+func TestCalendarHolidays(t *testing.T) {
+	testCalendarHolidays(t)
+}
+
+// BenchmarkCalendarHolidays benchmarks the Federal Reserve holidays
+func BenchmarkCalendarHolidays(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCalendarHolidays(b)
+	}
+}
+
+// testCalendarAddBankingDays validates moving forward and backward by banking days
+func testCalendarAddBankingDays(t testing.TB) {
+	c := NewCalendar()
+	tests := []struct {
+		date string
+		n    int
+		want string
+	}{
+		// Friday before Columbus Day
+		{"2018-10-05", 1, "2018-10-09"},
+		{"2018-10-05", 3, "2018-10-11"},
+		{"2018-10-09", -1, "2018-10-05"},
+		// Wednesday before Thanksgiving
+		{"2018-11-21", 1, "2018-11-23"},
+		{"2018-12-21", 2, "2018-12-26"},
+		{"2018-12-21", 0, "2018-12-21"},
+	}
+	for _, test := range tests {
+		got := c.AddBankingDays(mockDate(t, test.date), test.n)
+		if got.Format("2006-01-02") != test.want {
+			t.Errorf("%s %+d: got %s want %s", test.date, test.n, got.Format("2006-01-02"), test.want)
+		}
+	}
+	if got := c.NextBankingDay(mockDate(t, "2018-12-29")); got.Format("2006-01-02") != "2018-12-31" {
+		t.Errorf("NextBankingDay %s", got.Format("2006-01-02"))
+	}
+
+	c.AddClosure(mockDate(t, "2018-12-31"))
+	if got := c.NextBankingDay(mockDate(t, "2018-12-29")); got.Format("2006-01-02") != "2019-01-02" {
+		t.Errorf("NextBankingDay with closure %s", got.Format("2006-01-02"))
+	}
+}
+
+// TestCalendarAddBankingDays tests moving forward and backward by banking days
+func TestCalendarAddBankingDays(t *testing.T) {
+	testCalendarAddBankingDays(t)
+}
+
+// BenchmarkCalendarAddBankingDays benchmarks moving forward and backward by banking days
+func BenchmarkCalendarAddBankingDays(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCalendarAddBankingDays(b)
+	}
+}
+
+// testBatchHeaderValidateCalendar validates rejecting and rolling forward effective entry dates
+func testBatchHeaderValidateCalendar(t testing.TB) {
+	bh := mockBatchHeader()
+	// Christmas Day
+	bh.EffectiveEntryDate = mockDate(t, "2018-12-25")
+	opts := &ValidateOpts{Calendar: NewCalendar()}
+	err := bh.ValidateWith(opts)
+	if e, ok := err.(*FieldError); !ok || e.FieldName != "EffectiveEntryDate" || e.Msg != msgBankingDay {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	opts.RollForwardEffectiveDate = true
+	if err := bh.ValidateWith(opts); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if bh.EffectiveEntryDateField() != "181226" {
+		t.Errorf("EffectiveEntryDate was rolled forward to %s", bh.EffectiveEntryDateField())
+	}
+
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchPPD())
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	file.Batches[0].GetHeader().EffectiveEntryDate = mockDate(t, "2018-12-22")
+	if err := file.ValidateWith(&ValidateOpts{Calendar: NewCalendar()}); err == nil {
+		t.Error("expected a BatchError for a Saturday")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "EffectiveEntryDate" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestBatchHeaderValidateCalendar tests rejecting and rolling forward effective entry dates
+func TestBatchHeaderValidateCalendar(t *testing.T) {
+	testBatchHeaderValidateCalendar(t)
+}
+
+// BenchmarkBatchHeaderValidateCalendar benchmarks rejecting and rolling forward effective entry dates
+func BenchmarkBatchHeaderValidateCalendar(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testBatchHeaderValidateCalendar(b)
+	}
+}
@@ -14,6 +14,9 @@ func main() {
 
 	var fPath = flag.String("fPath", "201805101354.ach", "File Path")
 	var cpuprofile = flag.String("cpuprofile", "", "write cpu profile to file")
+	var report = flag.String("report", "", "print a report of the file as text, markdown or html")
+	var lenient = flag.Bool("lenient", false, "fix line endings, padding and blank lines of malformed files")
+	var ebcdic = flag.Bool("ebcdic", false, "read a file encoded in EBCDIC (code page 037)")
 
 	flag.Parse()
 	if *cpuprofile != "" {
@@ -34,10 +37,17 @@ func main() {
 		log.Panicf("Can not open file: %s: \n", err)
 	}
 	r := ach.NewReader(f)
+	r.SetLenient(*lenient)
+	if *ebcdic {
+		r.SetEncoding(ach.EBCDIC)
+	}
 	achFile, err := r.Read()
 	if err != nil {
 		fmt.Printf("Issue reading file: %+v \n", err)
 	}
+	for _, w := range r.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
 	// ensure we have a validated file structure
 	if achFile.Validate(); err != nil {
 		fmt.Printf("Could not validate entire read file: %v", err)
@@ -47,6 +57,17 @@ func main() {
 		fmt.Printf("Could not build file with read properties: %v", err)
 	}
 
+	if *report != "" {
+		format, err := ach.ParseReportFormat(*report)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ach.WriteReport(os.Stdout, &achFile, format); err != nil {
+			fmt.Printf("Could not write report: %v \n", err)
+		}
+		return
+	}
+
 	fmt.Printf("total amount debit: %v \n", achFile.Control.TotalDebitEntryDollarAmountInFile)
 	fmt.Printf("total amount credit: %v \n", achFile.Control.TotalCreditEntryDollarAmountInFile)
 }
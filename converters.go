@@ -14,7 +14,20 @@ import (
 type converters struct{}
 
 func (c *converters) parseNumField(r string) (s int) {
-	s, _ = strconv.Atoi(strings.TrimSpace(r))
+	r = strings.TrimSpace(r)
+	// fast path for unsigned digits, which are most numeric fields
+	if len(r) > 0 && len(r) < 19 {
+		for i := 0; i < len(r); i++ {
+			d := r[i] - '0'
+			if d > 9 {
+				s, _ = strconv.Atoi(r)
+				return s
+			}
+			s = s*10 + int(d)
+		}
+		return s
+	}
+	s, _ = strconv.Atoi(r)
 	return s
 }
 
@@ -45,25 +58,61 @@ func (c *converters) parseSimpleTime(s string) time.Time {
 	return t
 }
 
+// formatJulianDate returns the 3 digit day of the year of t
+func (c *converters) formatJulianDate(t time.Time) string {
+	return c.numericField(t.YearDay(), 3)
+}
+
+// parseJulianDate returns the date of the 3 digit day of the year s nearest to reference, or
+// a zero time.Time if s is not a day of the year or reference is zero
+func (c *converters) parseJulianDate(s string, reference time.Time) time.Time {
+	day, err := strconv.Atoi(s)
+	if err != nil || day < 1 || day > 366 || reference.IsZero() {
+		return time.Time{}
+	}
+	var nearest, distance time.Duration
+	var date time.Time
+	for year := reference.Year() - 1; year <= reference.Year()+1; year++ {
+		t := time.Date(year, time.January, day, 0, 0, 0, 0, reference.Location())
+		if t.Year() != year {
+			// day 366 of a year that is not a leap year
+			continue
+		}
+		distance = t.Sub(reference)
+		if distance < 0 {
+			distance = -distance
+		}
+		if date.IsZero() || distance < nearest {
+			date, nearest = t, distance
+		}
+	}
+	return date
+}
+
 // alphaField Alphanumeric and Alphabetic fields are left-justified and space filled.
 func (c *converters) alphaField(s string, max uint) string {
 	ln := uint(len(s))
 	if ln > max {
 		return s[:max]
 	}
-	s += strings.Repeat(" ", int(max-ln))
-	return s
+	return padField(s, ' ', max, false)
 }
 
 // numericField right-justified, unsigned, and zero filled
 func (c *converters) numericField(n int, max uint) string {
-	s := strconv.Itoa(n)
-	ln := uint(len(s))
+	var digits [20]byte
+	b := strconv.AppendInt(digits[:0], int64(n), 10)
+	ln := uint(len(b))
 	if ln > max {
-		return s[ln-max:]
+		return string(b[ln-max:])
 	}
-	s = strings.Repeat("0", int(max-ln)) + s
-	return s
+	var buf strings.Builder
+	buf.Grow(int(max))
+	for ; ln < max; ln++ {
+		buf.WriteByte('0')
+	}
+	buf.Write(b)
+	return buf.String()
 }
 
 // stringField slices to max length and zero filled
@@ -72,6 +121,72 @@ func (c *converters) stringField(s string, max uint) string {
 	if ln > max {
 		return s[:max]
 	}
-	s = strings.Repeat("0", int(max-ln)) + s
-	return s
+	return padField(s, '0', max, true)
+}
+
+// appendAlphaField appends s to b left-justified and space filled like alphaField
+func (c *converters) appendAlphaField(b []byte, s string, max uint) []byte {
+	if uint(len(s)) > max {
+		return append(b, s[:max]...)
+	}
+	b = append(b, s...)
+	for i := uint(len(s)); i < max; i++ {
+		b = append(b, ' ')
+	}
+	return b
+}
+
+// appendNumericField appends n to b right-justified and zero filled like numericField
+func (c *converters) appendNumericField(b []byte, n int, max uint) []byte {
+	var digits [20]byte
+	d := strconv.AppendInt(digits[:0], int64(n), 10)
+	ln := uint(len(d))
+	if ln > max {
+		return append(b, d[ln-max:]...)
+	}
+	for ; ln < max; ln++ {
+		b = append(b, '0')
+	}
+	return append(b, d...)
+}
+
+// appendStringField appends s to b right-justified and zero filled like stringField
+func (c *converters) appendStringField(b []byte, s string, max uint) []byte {
+	if uint(len(s)) > max {
+		return append(b, s[:max]...)
+	}
+	for i := uint(len(s)); i < max; i++ {
+		b = append(b, '0')
+	}
+	return append(b, s...)
+}
+
+// appendSimpleDate appends t to b as YYMMDD like formatSimpleDate
+func (c *converters) appendSimpleDate(b []byte, t time.Time) []byte {
+	return t.AppendFormat(b, "060102")
+}
+
+// appendSimpleTime appends t to b as HHMM like formatSimpleTime
+func (c *converters) appendSimpleTime(b []byte, t time.Time) []byte {
+	return t.AppendFormat(b, "1504")
+}
+
+// padField returns s padded with c to max length, to the left when right justified, in a
+// single allocation
+func padField(s string, c byte, max uint, right bool) string {
+	if uint(len(s)) == max {
+		return s
+	}
+	var buf strings.Builder
+	buf.Grow(int(max))
+	if !right {
+		buf.WriteString(s)
+	}
+	for i := uint(len(s)); i < max; i++ {
+		buf.WriteByte(c)
+	}
+	if right {
+		buf.WriteString(s)
+	}
+	return buf.String()
 }
@@ -4,7 +4,10 @@
 
 package ach
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 //testAlphaField ensures that padding and two long of strings get properly made
 func testAlphaFieldShort(t testing.TB) {
@@ -101,6 +104,13 @@ func testParseNumField(t testing.TB) {
 	if result != 12345 {
 		t.Errorf("Right justified zero got: '%v'", result)
 	}
+	// fields that are not only digits are converted by strconv
+	tests := map[string]int{"-12": -12, "+12": 12, "1A": 0, "  ": 0}
+	for s, want := range tests {
+		if got := c.parseNumField(s); got != want {
+			t.Errorf("parseNumField(%q) %d want %d", s, got, want)
+		}
+	}
 }
 
 // TestParseNumField test handles zero and spaces in number conversion
@@ -203,3 +213,46 @@ func BenchmarkRTNFieldExact(b *testing.B) {
 		testRTNFieldExact(b)
 	}
 }
+
+// testParseJulianDate validates the date of a Julian day nearest to a reference date
+func testParseJulianDate(t testing.TB) {
+	c := converters{}
+	reference := time.Date(2018, time.December, 30, 0, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"364": time.Date(2018, time.December, 30, 0, 0, 0, 0, time.UTC),
+		"002": time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC),
+		"366": {},
+		"001": time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"   ": {},
+		"000": {},
+		"367": {},
+	}
+	for s, want := range tests {
+		if got := c.parseJulianDate(s, reference); !got.Equal(want) {
+			t.Errorf("%q: got %v want %v", s, got, want)
+		}
+	}
+	leap := time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)
+	if got := c.parseJulianDate("366", leap); !got.Equal(time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("366: got %v", got)
+	}
+	if got := c.parseJulianDate("211", time.Time{}); !got.IsZero() {
+		t.Errorf("got %v without a reference", got)
+	}
+	if got := c.formatJulianDate(time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC)); got != "002" {
+		t.Errorf("formatJulianDate %q", got)
+	}
+}
+
+// TestParseJulianDate tests the date of a Julian day nearest to a reference date
+func TestParseJulianDate(t *testing.T) {
+	testParseJulianDate(t)
+}
+
+// BenchmarkParseJulianDate benchmarks the date of a Julian day nearest to a reference date
+func BenchmarkParseJulianDate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testParseJulianDate(b)
+	}
+}
@@ -0,0 +1,667 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	msgCSVHeader        = "CSV header row is missing"
+	msgCSVColumn        = "required CSV column %v is missing"
+	msgCSVAmount        = "is not a valid amount"
+	msgCSVDate          = "is not a valid date, use YYYY-MM-DD or YYMMDD"
+	msgCSVNumber        = "is not a valid number"
+	msgCSVIAT           = "IAT entries can not be imported from CSV"
+	msgCSVRoutingNumber = "must be a 9 digit routing number"
+)
+
+// CSV column keys name the BatchHeader, EntryDetail and addenda fields that can be
+// read from or written to a CSV document. A CSVReader maps the header row of the
+// document onto these keys through its Columns.
+const (
+	CSVBatchNumber                   = "BatchNumber"
+	CSVServiceClassCode              = "ServiceClassCode"
+	CSVCompanyName                   = "CompanyName"
+	CSVCompanyDiscretionaryData      = "CompanyDiscretionaryData"
+	CSVCompanyIdentification         = "CompanyIdentification"
+	CSVStandardEntryClassCode        = "StandardEntryClassCode"
+	CSVCompanyEntryDescription       = "CompanyEntryDescription"
+	CSVCompanyDescriptiveDate        = "CompanyDescriptiveDate"
+	CSVEffectiveEntryDate            = "EffectiveEntryDate"
+	CSVODFIIdentification            = "ODFIIdentification"
+	CSVTransactionCode               = "TransactionCode"
+	CSVRDFIRoutingNumber             = "RDFIRoutingNumber"
+	CSVDFIAccountNumber              = "DFIAccountNumber"
+	CSVAmount                        = "Amount"
+	CSVIdentificationNumber          = "IdentificationNumber"
+	CSVIndividualName                = "IndividualName"
+	CSVDiscretionaryData             = "DiscretionaryData"
+	CSVTraceNumber                   = "TraceNumber"
+	CSVPaymentRelatedInformation     = "PaymentRelatedInformation"
+	CSVReferenceInformationOne       = "ReferenceInformationOne"
+	CSVReferenceInformationTwo       = "ReferenceInformationTwo"
+	CSVTerminalIdentificationCode    = "TerminalIdentificationCode"
+	CSVTransactionSerialNumber       = "TransactionSerialNumber"
+	CSVTransactionDate               = "TransactionDate"
+	CSVAuthorizationCodeOrExpireDate = "AuthorizationCodeOrExpireDate"
+	CSVTerminalLocation              = "TerminalLocation"
+	CSVTerminalCity                  = "TerminalCity"
+	CSVTerminalState                 = "TerminalState"
+	CSVReturnCode                    = "ReturnCode"
+	CSVChangeCode                    = "ChangeCode"
+	CSVCorrectedData                 = "CorrectedData"
+	CSVOriginalTrace                 = "OriginalTrace"
+)
+
+// CSVColumns is the default column order written by CSVWriter. A CSVReader accepts
+// the same header row, so a file exported with CSVWriter can be edited and imported again.
+// Return and notification of change columns are informational and ignored on import.
+var CSVColumns = []string{
+	CSVBatchNumber,
+	CSVServiceClassCode,
+	CSVCompanyName,
+	CSVCompanyDiscretionaryData,
+	CSVCompanyIdentification,
+	CSVStandardEntryClassCode,
+	CSVCompanyEntryDescription,
+	CSVCompanyDescriptiveDate,
+	CSVEffectiveEntryDate,
+	CSVODFIIdentification,
+	CSVTransactionCode,
+	CSVRDFIRoutingNumber,
+	CSVDFIAccountNumber,
+	CSVAmount,
+	CSVIdentificationNumber,
+	CSVIndividualName,
+	CSVDiscretionaryData,
+	CSVTraceNumber,
+	CSVPaymentRelatedInformation,
+	CSVReferenceInformationOne,
+	CSVReferenceInformationTwo,
+	CSVTerminalIdentificationCode,
+	CSVTransactionSerialNumber,
+	CSVTransactionDate,
+	CSVAuthorizationCodeOrExpireDate,
+	CSVTerminalLocation,
+	CSVTerminalCity,
+	CSVTerminalState,
+	CSVReturnCode,
+	CSVChangeCode,
+	CSVCorrectedData,
+	CSVOriginalTrace,
+}
+
+// csvRequiredColumns must be present in the header row of an imported CSV document
+var csvRequiredColumns = []string{
+	CSVCompanyName,
+	CSVCompanyIdentification,
+	CSVStandardEntryClassCode,
+	CSVCompanyEntryDescription,
+	CSVODFIIdentification,
+	CSVTransactionCode,
+	CSVRDFIRoutingNumber,
+	CSVDFIAccountNumber,
+	CSVAmount,
+	CSVIndividualName,
+}
+
+// csvBatchColumns are the columns whose values identify the batch a row belongs to
+var csvBatchColumns = []string{
+	CSVBatchNumber,
+	CSVServiceClassCode,
+	CSVCompanyName,
+	CSVCompanyDiscretionaryData,
+	CSVCompanyIdentification,
+	CSVStandardEntryClassCode,
+	CSVCompanyEntryDescription,
+	CSVCompanyDescriptiveDate,
+	CSVEffectiveEntryDate,
+	CSVODFIIdentification,
+}
+
+// csvAddenda02Columns are the columns used to build an Addenda02 for POS and SHR entries
+var csvAddenda02Columns = []string{
+	CSVReferenceInformationOne,
+	CSVReferenceInformationTwo,
+	CSVTerminalIdentificationCode,
+	CSVTransactionSerialNumber,
+	CSVTransactionDate,
+	CSVAuthorizationCodeOrExpireDate,
+	CSVTerminalLocation,
+	CSVTerminalCity,
+	CSVTerminalState,
+}
+
+// CSVReader reads payment rows from a CSV document and builds an ach.File.
+//
+// The first row of the document is a header row. Each following row is one entry
+// along with the batch it belongs to; consecutive or scattered rows sharing the same
+// batch column values are grouped into a single batch in order of first appearance.
+type CSVReader struct {
+	// r is the underlying CSV reader
+	r *csv.Reader
+	// Header is the FileHeader of the File being built
+	Header FileHeader
+	// Columns maps header names used in the document onto CSV column keys
+	// (i.e. "Account" -> CSVDFIAccountNumber). Header names which are not
+	// mapped are matched against the column keys directly.
+	Columns map[string]string
+	// AmountsInCents reads the Amount column as a whole number of cents,
+	// otherwise amounts are read as dollars (i.e. "1,234.56")
+	AmountsInCents bool
+	// lineNum is the current line being parsed
+	lineNum int
+	// index holds the position of each column key in a row
+	index map[string]int
+}
+
+// csvBatch holds the rows of a batch while a CSV document is being read
+type csvBatch struct {
+	header  *BatchHeader
+	entries []*EntryDetail
+	line    int
+}
+
+// NewCSVReader returns a new CSVReader that reads from r and uses fh as the File Header.
+func NewCSVReader(r io.Reader, fh FileHeader) *CSVReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return &CSVReader{
+		r:       reader,
+		Header:  fh,
+		Columns: make(map[string]string),
+	}
+}
+
+// error creates a new ParseError for the current line based on err.
+func (r *CSVReader) error(err error) error {
+	return &ParseError{
+		Line: r.lineNum,
+		Err:  err,
+	}
+}
+
+// Read reads every row of the CSV document and returns a File with a batch per
+// distinct batch header. Batches and the File are created so controls and trace
+// numbers are populated.
+func (r *CSVReader) Read() (File, error) {
+	file := NewFile().SetHeader(r.Header)
+	r.lineNum = 0
+
+	if err := r.readHeaderRow(); err != nil {
+		return *file, err
+	}
+
+	var batches []*csvBatch
+	byKey := make(map[string]*csvBatch)
+	for {
+		row, err := r.r.Read()
+		if err == io.EOF {
+			break
+		}
+		r.lineNum++
+		if err != nil {
+			return *file, r.error(err)
+		}
+		if isBlankCSVRow(row) {
+			continue
+		}
+		key := r.batchKey(row)
+		b, ok := byKey[key]
+		if !ok {
+			bh, err := r.parseBatchHeader(row)
+			if err != nil {
+				return *file, r.error(err)
+			}
+			b = &csvBatch{header: bh, line: r.lineNum}
+			byKey[key] = b
+			batches = append(batches, b)
+		}
+		entry, err := r.parseEntryDetail(row, b.header.StandardEntryClassCode)
+		if err != nil {
+			return *file, r.error(err)
+		}
+		b.entries = append(b.entries, entry)
+	}
+
+	for i, b := range batches {
+		if b.header.BatchNumber == 0 {
+			b.header.BatchNumber = i + 1
+		}
+		if b.header.ServiceClassCode == 0 {
+			b.header.ServiceClassCode = csvServiceClassCode(b.entries)
+		}
+		batch, err := NewBatch(b.header)
+		if err != nil {
+			return *file, &ParseError{Line: b.line, Err: err}
+		}
+		for j, entry := range b.entries {
+			if entry.TraceNumber == 0 {
+				entry.SetTraceNumber(b.header.ODFIIdentification, j+1)
+			}
+			for _, addenda := range entry.Addendum {
+				if addenda02, ok := addenda.(*Addenda02); ok {
+					addenda02.TraceNumber = entry.TraceNumber
+				}
+			}
+			batch.AddEntry(entry)
+		}
+		if err := batch.Create(); err != nil {
+			return *file, &ParseError{Line: b.line, Err: err}
+		}
+		file.AddBatch(batch)
+	}
+	if err := file.Create(); err != nil {
+		return *file, err
+	}
+	return *file, nil
+}
+
+// readHeaderRow reads the first row of the document and indexes the column keys
+func (r *CSVReader) readHeaderRow() error {
+	row, err := r.r.Read()
+	r.lineNum++
+	if err == io.EOF {
+		return r.error(&FileError{FieldName: "Header", Msg: msgCSVHeader, Code: ErrInvalidCSV.Code})
+	}
+	if err != nil {
+		return r.error(err)
+	}
+	r.index = make(map[string]int)
+	for i, name := range row {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if key, ok := r.Columns[name]; ok {
+			name = key
+		}
+		r.index[name] = i
+	}
+	for _, key := range csvRequiredColumns {
+		if _, ok := r.index[key]; !ok {
+			msg := fmt.Sprintf(msgCSVColumn, key)
+			return r.error(&FileError{FieldName: key, Msg: msg, Code: ErrInvalidCSV.Code})
+		}
+	}
+	return nil
+}
+
+// value returns the trimmed value of the column key in row, or "" if the column is not present
+func (r *CSVReader) value(row []string, key string) string {
+	i, ok := r.index[key]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// batchKey joins the batch column values of row
+func (r *CSVReader) batchKey(row []string) string {
+	values := make([]string, len(csvBatchColumns))
+	for i, key := range csvBatchColumns {
+		values[i] = r.value(row, key)
+	}
+	return strings.Join(values, "\x00")
+}
+
+// parseBatchHeader builds the BatchHeader of row
+func (r *CSVReader) parseBatchHeader(row []string) (*BatchHeader, error) {
+	bh := NewBatchHeader()
+	// BatchNumber is assigned in order of appearance unless the document sets it
+	bh.BatchNumber = 0
+	bh.CompanyName = r.value(row, CSVCompanyName)
+	bh.CompanyDiscretionaryData = r.value(row, CSVCompanyDiscretionaryData)
+	bh.CompanyIdentification = r.value(row, CSVCompanyIdentification)
+	bh.StandardEntryClassCode = strings.ToUpper(r.value(row, CSVStandardEntryClassCode))
+	bh.CompanyEntryDescription = r.value(row, CSVCompanyEntryDescription)
+	bh.CompanyDescriptiveDate = r.value(row, CSVCompanyDescriptiveDate)
+	bh.ODFIIdentification = r.value(row, CSVODFIIdentification)
+	if bh.StandardEntryClassCode == "IAT" {
+		return nil, &FieldError{FieldName: CSVStandardEntryClassCode, Value: bh.StandardEntryClassCode, Msg: msgCSVIAT, Code: ErrInvalidCSV.Code}
+	}
+	if v := r.value(row, CSVServiceClassCode); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &FieldError{FieldName: CSVServiceClassCode, Value: v, Msg: msgCSVNumber, Code: ErrInvalidCSV.Code}
+		}
+		bh.ServiceClassCode = n
+	}
+	if v := r.value(row, CSVBatchNumber); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &FieldError{FieldName: CSVBatchNumber, Value: v, Msg: msgCSVNumber, Code: ErrInvalidCSV.Code}
+		}
+		bh.BatchNumber = n
+	}
+	if v := r.value(row, CSVEffectiveEntryDate); v != "" {
+		t, err := parseCSVDate(v)
+		if err != nil {
+			return nil, &FieldError{FieldName: CSVEffectiveEntryDate, Value: v, Msg: msgCSVDate, Code: ErrInvalidCSV.Code}
+		}
+		bh.EffectiveEntryDate = t
+	}
+	return bh, nil
+}
+
+// parseEntryDetail builds the EntryDetail of row along with the addenda its SEC code allows
+func (r *CSVReader) parseEntryDetail(row []string, sec string) (*EntryDetail, error) {
+	ed := NewEntryDetail()
+
+	v := r.value(row, CSVTransactionCode)
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, &FieldError{FieldName: CSVTransactionCode, Value: v, Msg: msgCSVNumber, Code: ErrInvalidCSV.Code}
+	}
+	ed.TransactionCode = n
+
+	v = r.value(row, CSVRDFIRoutingNumber)
+	if len(v) != 9 {
+		return nil, &FieldError{FieldName: CSVRDFIRoutingNumber, Value: v, Msg: msgCSVRoutingNumber, Code: ErrInvalidRoutingNumber.Code}
+	}
+	ed.SetRDFI(v)
+
+	ed.DFIAccountNumber = r.value(row, CSVDFIAccountNumber)
+
+	v = r.value(row, CSVAmount)
+	if r.AmountsInCents {
+		ed.Amount, err = strconv.Atoi(v)
+	} else {
+		ed.Amount, err = parseDollars(v)
+	}
+	if err != nil {
+		return nil, &FieldError{FieldName: CSVAmount, Value: v, Msg: msgCSVAmount, Code: ErrInvalidCSV.Code}
+	}
+
+	ed.IdentificationNumber = r.value(row, CSVIdentificationNumber)
+	ed.IndividualName = r.value(row, CSVIndividualName)
+	ed.DiscretionaryData = r.value(row, CSVDiscretionaryData)
+	if v := r.value(row, CSVTraceNumber); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &FieldError{FieldName: CSVTraceNumber, Value: v, Msg: msgCSVNumber, Code: ErrInvalidCSV.Code}
+		}
+		ed.TraceNumber = n
+	}
+
+	switch sec {
+	case "POS", "SHR":
+		if addenda02 := r.parseAddenda02(row); addenda02 != nil {
+			ed.AddAddenda(addenda02)
+		}
+	case "ARC", "BOC", "COR", "POP", "RCK", "TEL":
+		// no addenda is allowed for these SEC codes
+	default:
+		r.parseAddenda05(row, ed, sec)
+	}
+	return ed, nil
+}
+
+// parseAddenda02 returns the Addenda02 of row or nil when none of its columns are set
+func (r *CSVReader) parseAddenda02(row []string) *Addenda02 {
+	empty := true
+	for _, key := range csvAddenda02Columns {
+		if r.value(row, key) != "" {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return nil
+	}
+	addenda02 := NewAddenda02()
+	addenda02.ReferenceInformationOne = r.value(row, CSVReferenceInformationOne)
+	addenda02.ReferenceInformationTwo = r.value(row, CSVReferenceInformationTwo)
+	addenda02.TerminalIdentificationCode = r.value(row, CSVTerminalIdentificationCode)
+	addenda02.TransactionSerialNumber = r.value(row, CSVTransactionSerialNumber)
+	addenda02.TransactionDate = r.value(row, CSVTransactionDate)
+	addenda02.AuthorizationCodeOrExpireDate = r.value(row, CSVAuthorizationCodeOrExpireDate)
+	addenda02.TerminalLocation = r.value(row, CSVTerminalLocation)
+	addenda02.TerminalCity = r.value(row, CSVTerminalCity)
+	addenda02.TerminalState = r.value(row, CSVTerminalState)
+	return addenda02
+}
+
+// parseAddenda05 adds an Addenda05 for each line of the PaymentRelatedInformation column.
+// CTX entries have their addenda count and receiving company set in IndividualName.
+func (r *CSVReader) parseAddenda05(row []string, ed *EntryDetail, sec string) {
+	var count int
+	info := r.value(row, CSVPaymentRelatedInformation)
+	if info != "" {
+		for _, line := range strings.Split(strings.Replace(info, "\r\n", "\n", -1), "\n") {
+			addenda05 := NewAddenda05()
+			addenda05.PaymentRelatedInformation = strings.TrimSpace(line)
+			ed.AddAddenda(addenda05)
+			count++
+		}
+	}
+	if sec == "CTX" {
+		name := ed.IndividualName
+		ed.SetCTXAddendaRecords(count)
+		ed.SetCTXReceivingCompany(name)
+	}
+}
+
+// isBlankCSVRow returns true when every value of row is empty
+func isBlankCSVRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// csvServiceClassCode returns the ServiceClassCode matching the debits and credits of entries
+func csvServiceClassCode(entries []*EntryDetail) int {
+	var credits, debits bool
+	for _, entry := range entries {
+		switch entry.CreditOrDebit() {
+		case "C":
+			credits = true
+		case "D":
+			debits = true
+		}
+	}
+	switch {
+	case credits && !debits:
+		return 220
+	case debits && !credits:
+		return 225
+	}
+	return 200
+}
+
+// parseCSVDate parses a date formatted as YYYY-MM-DD or YYMMDD
+func parseCSVDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse("060102", s)
+}
+
+// parseDollars converts a dollar amount such as "$1,234.5" into cents without floating point rounding
+func parseDollars(s string) (int, error) {
+	s = strings.Replace(strings.TrimPrefix(s, "$"), ",", "", -1)
+	if s == "" || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return 0, errorWithCode(ErrInvalidCSV, msgCSVAmount)
+	}
+	dollars, cents := s, ""
+	if i := strings.Index(s, "."); i >= 0 {
+		dollars, cents = s[:i], s[i+1:]
+	}
+	if len(cents) > 2 {
+		return 0, errorWithCode(ErrInvalidCSV, msgCSVAmount)
+	}
+	cents = cents + strings.Repeat("0", 2-len(cents))
+	if dollars == "" {
+		dollars = "0"
+	}
+	d, err := strconv.Atoi(dollars)
+	if err != nil {
+		return 0, err
+	}
+	c, err := strconv.Atoi(cents)
+	if err != nil {
+		return 0, err
+	}
+	return d*100 + c, nil
+}
+
+// formatDollars converts cents into a dollar amount such as "1234.56"
+func formatDollars(cents int) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// CSVWriter flattens an ach.File into a CSV document with one row per entry.
+type CSVWriter struct {
+	w *csv.Writer
+	// Columns are the column keys written, in order. Defaults to CSVColumns.
+	Columns []string
+	// AmountsInCents writes the Amount column as a whole number of cents,
+	// otherwise amounts are written as dollars (i.e. "1234.56")
+	AmountsInCents bool
+}
+
+// NewCSVWriter returns a new CSVWriter that writes to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		w:       csv.NewWriter(w),
+		Columns: CSVColumns,
+	}
+}
+
+// Write writes a header row followed by a row for every entry of file, including IAT
+// entries, with the values of its batch header.
+func (w *CSVWriter) Write(file *File) error {
+	if err := w.w.Write(w.Columns); err != nil {
+		return err
+	}
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		for _, entry := range batch.GetEntries() {
+			values := w.batchValues(bh.BatchNumber, bh.ServiceClassCode, bh.StandardEntryClassCode,
+				bh.CompanyEntryDescription, bh.EffectiveEntryDate, bh.ODFIIdentification)
+			values[CSVCompanyName] = bh.CompanyName
+			values[CSVCompanyDiscretionaryData] = bh.CompanyDiscretionaryData
+			values[CSVCompanyIdentification] = bh.CompanyIdentification
+			values[CSVCompanyDescriptiveDate] = bh.CompanyDescriptiveDate
+			w.entryValues(values, entry, bh.StandardEntryClassCode)
+			if err := w.w.Write(w.row(values)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, iatBatch := range file.IATBatches {
+		bh := iatBatch.GetHeader()
+		for _, entry := range iatBatch.GetEntries() {
+			values := w.batchValues(bh.BatchNumber, bh.ServiceClassCode, bh.StandardEntryClassCode,
+				bh.CompanyEntryDescription, bh.EffectiveEntryDate, bh.ODFIIdentification)
+			values[CSVCompanyIdentification] = bh.OriginatorIdentification
+			values[CSVTransactionCode] = strconv.Itoa(entry.TransactionCode)
+			values[CSVRDFIRoutingNumber] = entry.RDFIIdentification + entry.CheckDigit
+			values[CSVDFIAccountNumber] = strings.TrimSpace(entry.DFIAccountNumber)
+			values[CSVAmount] = w.amount(entry.Amount)
+			values[CSVTraceNumber] = entry.TraceNumberField()
+			if entry.Addenda10 != nil {
+				values[CSVIndividualName] = entry.Addenda10.Name
+			}
+			for _, addenda := range entry.Addendum {
+				if addenda99, ok := addenda.(*Addenda99); ok {
+					values[CSVReturnCode] = addenda99.ReturnCode
+					values[CSVOriginalTrace] = addenda99.OriginalTraceField()
+				}
+			}
+			if err := w.w.Write(w.row(values)); err != nil {
+				return err
+			}
+		}
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *CSVWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// batchValues returns the column values shared by domestic and IAT batch headers
+func (w *CSVWriter) batchValues(batchNumber, serviceClassCode int, sec, description string, effective time.Time, odfi string) map[string]string {
+	values := map[string]string{
+		CSVBatchNumber:             strconv.Itoa(batchNumber),
+		CSVServiceClassCode:        strconv.Itoa(serviceClassCode),
+		CSVStandardEntryClassCode:  sec,
+		CSVCompanyEntryDescription: description,
+		CSVODFIIdentification:      odfi,
+	}
+	if !effective.IsZero() {
+		values[CSVEffectiveEntryDate] = effective.Format("2006-01-02")
+	}
+	return values
+}
+
+// entryValues sets the column values of entry and its addenda
+func (w *CSVWriter) entryValues(values map[string]string, entry *EntryDetail, sec string) {
+	values[CSVTransactionCode] = strconv.Itoa(entry.TransactionCode)
+	values[CSVRDFIRoutingNumber] = entry.RDFIIdentification + entry.CheckDigit
+	values[CSVDFIAccountNumber] = strings.TrimSpace(entry.DFIAccountNumber)
+	values[CSVAmount] = w.amount(entry.Amount)
+	values[CSVIdentificationNumber] = strings.TrimSpace(entry.IdentificationNumber)
+	values[CSVIndividualName] = strings.TrimSpace(entry.IndividualName)
+	if sec == "CTX" && len(entry.IndividualName) >= 20 {
+		values[CSVIndividualName] = entry.CTXReceivingCompanyField()
+	}
+	values[CSVDiscretionaryData] = strings.TrimSpace(entry.DiscretionaryData)
+	values[CSVTraceNumber] = entry.TraceNumberField()
+
+	var info []string
+	for _, addenda := range entry.Addendum {
+		switch a := addenda.(type) {
+		case *Addenda02:
+			values[CSVReferenceInformationOne] = a.ReferenceInformationOne
+			values[CSVReferenceInformationTwo] = a.ReferenceInformationTwo
+			values[CSVTerminalIdentificationCode] = a.TerminalIdentificationCode
+			values[CSVTransactionSerialNumber] = a.TransactionSerialNumber
+			values[CSVTransactionDate] = a.TransactionDate
+			values[CSVAuthorizationCodeOrExpireDate] = a.AuthorizationCodeOrExpireDate
+			values[CSVTerminalLocation] = a.TerminalLocation
+			values[CSVTerminalCity] = a.TerminalCity
+			values[CSVTerminalState] = a.TerminalState
+		case *Addenda05:
+			info = append(info, a.PaymentRelatedInformation)
+		case *Addenda98:
+			values[CSVChangeCode] = a.ChangeCode
+			values[CSVCorrectedData] = strings.TrimSpace(a.CorrectedData)
+			values[CSVOriginalTrace] = a.OriginalTraceField()
+		case *Addenda99:
+			values[CSVReturnCode] = a.ReturnCode
+			values[CSVOriginalTrace] = a.OriginalTraceField()
+		}
+	}
+	values[CSVPaymentRelatedInformation] = strings.Join(info, "\n")
+}
+
+// amount formats cents as configured on the CSVWriter
+func (w *CSVWriter) amount(cents int) string {
+	if w.AmountsInCents {
+		return strconv.Itoa(cents)
+	}
+	return formatDollars(cents)
+}
+
+// row orders values by the CSVWriter Columns
+func (w *CSVWriter) row(values map[string]string) []string {
+	row := make([]string, len(w.Columns))
+	for i, key := range w.Columns {
+		row[i] = values[key]
+	}
+	return row
+}
@@ -0,0 +1,292 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// mockCSVPayments is a spreadsheet export of two PPD payroll credits and a CCD vendor payment
+const mockCSVPayments = `CompanyName,CompanyIdentification,StandardEntryClassCode,CompanyEntryDescription,EffectiveEntryDate,ODFIIdentification,TransactionCode,RDFIRoutingNumber,DFIAccountNumber,Amount,IndividualName,PaymentRelatedInformation
+ACME Corporation,121042882,PPD,PAYROLL,2018-10-22,12104288,22,231380104,123456789,"1,250.00",Wade Arnold,
+ACME Corporation,121042882,PPD,PAYROLL,2018-10-22,12104288,32,231380104,987654321,99.5,Jane Doe,Bonus payment
+ACME Corporation,121042882,CCD,VNDR PAY,181022,12104288,22,231380104,744-5678-99,$10.01,Best Co,
+`
+
+// testCSVRead validates reading a CSV document into a File
+func testCSVRead(t testing.TB) {
+	r := NewCSVReader(strings.NewReader(mockCSVPayments), mockFileHeader())
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(file.Batches) != 2 {
+		t.Fatalf("expected 2 batches got %d", len(file.Batches))
+	}
+	ppd := file.Batches[0]
+	if ppd.GetHeader().BatchNumber != 1 || file.Batches[1].GetHeader().BatchNumber != 2 {
+		t.Error("BatchNumber was not assigned in order of appearance")
+	}
+	if ppd.GetHeader().ServiceClassCode != 220 {
+		t.Errorf("ServiceClassCode %d expected 220", ppd.GetHeader().ServiceClassCode)
+	}
+	entries := ppd.GetEntries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 PPD entries got %d", len(entries))
+	}
+	if entries[0].Amount != 125000 || entries[1].Amount != 9950 {
+		t.Errorf("unexpected amounts %d %d", entries[0].Amount, entries[1].Amount)
+	}
+	if entries[1].TraceNumberField() != "121042880000002" {
+		t.Errorf("TraceNumber %s was not sequenced", entries[1].TraceNumberField())
+	}
+	if len(entries[1].Addendum) != 1 || entries[1].AddendaRecordIndicator != 1 {
+		t.Error("PaymentRelatedInformation did not create an Addenda05")
+	}
+	if file.Control.TotalCreditEntryDollarAmountInFile != 135951 {
+		t.Errorf("TotalCreditEntryDollarAmountInFile %d", file.Control.TotalCreditEntryDollarAmountInFile)
+	}
+}
+
+// TestCSVRead tests reading a CSV document into a File
+func TestCSVRead(t *testing.T) {
+	testCSVRead(t)
+}
+
+// BenchmarkCSVRead benchmarks reading a CSV document into a File
+func BenchmarkCSVRead(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCSVRead(b)
+	}
+}
+
+// testCSVReadColumns validates mapping custom header names and amounts in cents
+func testCSVReadColumns(t testing.TB) {
+	doc := "Company,Company ID,SEC,Description,ODFI,Code,Routing,Account,Cents,Name\n" +
+		"ACME Corporation,121042882,PPD,PAYROLL,12104288,27,231380104,123456789,1999,Wade Arnold\n"
+	r := NewCSVReader(strings.NewReader(doc), mockFileHeader())
+	r.AmountsInCents = true
+	r.Columns = map[string]string{
+		"Company":     CSVCompanyName,
+		"Company ID":  CSVCompanyIdentification,
+		"SEC":         CSVStandardEntryClassCode,
+		"Description": CSVCompanyEntryDescription,
+		"ODFI":        CSVODFIIdentification,
+		"Code":        CSVTransactionCode,
+		"Routing":     CSVRDFIRoutingNumber,
+		"Account":     CSVDFIAccountNumber,
+		"Cents":       CSVAmount,
+		"Name":        CSVIndividualName,
+	}
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	bh := file.Batches[0].GetHeader()
+	if bh.ServiceClassCode != 225 {
+		t.Errorf("ServiceClassCode %d expected 225", bh.ServiceClassCode)
+	}
+	if file.Batches[0].GetEntries()[0].Amount != 1999 {
+		t.Errorf("Amount %d expected 1999", file.Batches[0].GetEntries()[0].Amount)
+	}
+}
+
+// TestCSVReadColumns tests mapping custom header names and amounts in cents
+func TestCSVReadColumns(t *testing.T) {
+	testCSVReadColumns(t)
+}
+
+// BenchmarkCSVReadColumns benchmarks mapping custom header names and amounts in cents
+func BenchmarkCSVReadColumns(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCSVReadColumns(b)
+	}
+}
+
+// testCSVReadErr validates errors for invalid CSV documents
+func testCSVReadErr(t testing.TB) {
+	header := "CompanyName,CompanyIdentification,StandardEntryClassCode,CompanyEntryDescription,ODFIIdentification,TransactionCode,RDFIRoutingNumber,DFIAccountNumber,Amount,IndividualName\n"
+	tests := map[string]string{
+		"Amount":                 header + "ACME,121042882,PPD,PAYROLL,12104288,22,231380104,123,12.345,Wade\n",
+		"RDFIRoutingNumber":      header + "ACME,121042882,PPD,PAYROLL,12104288,22,2313801,123,12.34,Wade\n",
+		"TransactionCode":        header + "ACME,121042882,PPD,PAYROLL,12104288,XX,231380104,123,12.34,Wade\n",
+		"StandardEntryClassCode": header + "ACME,121042882,IAT,PAYROLL,12104288,22,231380104,123,12.34,Wade\n",
+		"CompanyIdentification":  "CompanyName\nACME\n",
+	}
+	for field, doc := range tests {
+		_, err := NewCSVReader(strings.NewReader(doc), mockFileHeader()).Read()
+		p, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%s: expected ParseError got %T: %v", field, err, err)
+			continue
+		}
+		switch e := p.Err.(type) {
+		case *FieldError:
+			if e.FieldName != field {
+				t.Errorf("%s: unexpected FieldName %s", field, e.FieldName)
+			}
+		case *FileError:
+			if e.FieldName != field {
+				t.Errorf("%s: unexpected FieldName %s", field, e.FieldName)
+			}
+		default:
+			t.Errorf("%s: %T: %s", field, p.Err, p.Err)
+		}
+	}
+}
+
+// TestCSVReadErr tests errors for invalid CSV documents
+func TestCSVReadErr(t *testing.T) {
+	testCSVReadErr(t)
+}
+
+// BenchmarkCSVReadErr benchmarks errors for invalid CSV documents
+func BenchmarkCSVReadErr(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCSVReadErr(b)
+	}
+}
+
+// testCSVRoundTrip validates exporting a File and importing the result
+func testCSVRoundTrip(t testing.TB) {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchPPD())
+	file.AddBatch(mockBatchCTX())
+	pos := mockBatchPOS()
+	pos.GetHeader().BatchNumber = 3
+	// Addenda02 carries the trace number of its entry
+	pos.GetEntries()[0].Addendum[0].(*Addenda02).TraceNumber = pos.GetEntries()[0].TraceNumber
+	file.AddBatch(pos)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var buf bytes.Buffer
+	if err := NewCSVWriter(&buf).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 4 {
+		t.Errorf("expected a header and 3 rows got %d lines", lines)
+	}
+
+	read, err := NewCSVReader(&buf, mockFileHeader()).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(read.Batches) != len(file.Batches) {
+		t.Fatalf("expected %d batches got %d", len(file.Batches), len(read.Batches))
+	}
+	for i := range file.Batches {
+		want, got := file.Batches[i].GetEntries()[0], read.Batches[i].GetEntries()[0]
+		if want.String() != got.String() {
+			t.Errorf("batch %d entry\nwant %s\n got %s", i, want.String(), got.String())
+		}
+		if len(want.Addendum) != len(got.Addendum) {
+			t.Errorf("batch %d expected %d addenda got %d", i, len(want.Addendum), len(got.Addendum))
+			continue
+		}
+		for j := range want.Addendum {
+			if want.Addendum[j].String() != got.Addendum[j].String() {
+				t.Errorf("batch %d addenda\nwant %s\n got %s", i, want.Addendum[j].String(), got.Addendum[j].String())
+			}
+		}
+	}
+	if read.Control.String() != file.Control.String() {
+		t.Errorf("FileControl\nwant %s\n got %s", file.Control.String(), read.Control.String())
+	}
+}
+
+// TestCSVRoundTrip tests exporting a File and importing the result
+func TestCSVRoundTrip(t *testing.T) {
+	testCSVRoundTrip(t)
+}
+
+// BenchmarkCSVRoundTrip benchmarks exporting a File and importing the result
+func BenchmarkCSVRoundTrip(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCSVRoundTrip(b)
+	}
+}
+
+// testCSVWriteReturns validates return codes are written with their entry
+func testCSVWriteReturns(t testing.TB) {
+	batch := mockBatchPPD()
+	batch.GetEntries()[0].AddAddenda(mockAddenda99())
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	w.Columns = []string{CSVTraceNumber, CSVReturnCode, CSVOriginalTrace}
+	if err := w.Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if !strings.Contains(buf.String(), "121042880000001,R07,099912340000015\n") {
+		t.Errorf("return code was not written:\n%s", buf.String())
+	}
+}
+
+// TestCSVWriteReturns tests return codes are written with their entry
+func TestCSVWriteReturns(t *testing.T) {
+	testCSVWriteReturns(t)
+}
+
+// BenchmarkCSVWriteReturns benchmarks return codes are written with their entry
+func BenchmarkCSVWriteReturns(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCSVWriteReturns(b)
+	}
+}
+
+// testParseDollars validates converting dollar amounts into cents
+func testParseDollars(t testing.TB) {
+	tests := map[string]int{
+		"0.01":       1,
+		"1":          100,
+		".5":         50,
+		"$1,234.56":  123456,
+		"1000000.00": 100000000,
+	}
+	for s, want := range tests {
+		got, err := parseDollars(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %d want %d", s, got, want)
+		}
+		if s == "1000000.00" && formatDollars(got) != s {
+			t.Errorf("formatDollars %s", formatDollars(got))
+		}
+	}
+	for _, s := range []string{"", "-1.00", "1.234", "abc", "1.x"} {
+		if _, err := parseDollars(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+// TestParseDollars tests converting dollar amounts into cents
+func TestParseDollars(t *testing.T) {
+	testParseDollars(t)
+}
+
+// BenchmarkParseDollars benchmarks converting dollar amounts into cents
+func BenchmarkParseDollars(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testParseDollars(b)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fileIDModifiers are the FileIDModifier values in the order they are assigned to files
+// created on the same date
+const fileIDModifiers = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Errors specific to duplicate file detection
+var (
+	msgFileIDModifierExhausted = "all FileIDModifier values have been used on this date"
+)
+
+// DuplicateReason is why a file is reported as a duplicate of a recorded file
+type DuplicateReason string
+
+const (
+	// DuplicateExact is a file with the same identifying header fields and entries
+	DuplicateExact DuplicateReason = "exact"
+	// DuplicateIdentity is a file with the same identifying header fields and different entries,
+	// which the ACH Operator rejects as a duplicate file
+	DuplicateIdentity DuplicateReason = "identity"
+	// DuplicateSuspected is a file with the same entries and different identifying header fields,
+	// such as a file resubmitted with a new FileIDModifier or creation time
+	DuplicateSuspected DuplicateReason = "suspected"
+)
+
+// FileFingerprint identifies a file by the NACHA unique file identification fields of its
+// header and a hash of its entries.
+type FileFingerprint struct {
+	// ImmediateOrigin of the file header
+	ImmediateOrigin string `json:"immediateOrigin"`
+	// ImmediateDestination of the file header
+	ImmediateDestination string `json:"immediateDestination"`
+	// FileCreationDate of the file header in YYMMDD format
+	FileCreationDate string `json:"fileCreationDate"`
+	// FileCreationTime of the file header in HHMM format
+	FileCreationTime string `json:"fileCreationTime"`
+	// FileIDModifier of the file header
+	FileIDModifier string `json:"fileIDModifier"`
+	// EntryHash is the hex SHA-256 of the entries of the file without their trace numbers,
+	// independent of the order of entries and batches
+	EntryHash string `json:"entryHash"`
+}
+
+// NewFileFingerprint returns the fingerprint of file
+func NewFileFingerprint(file *File) FileFingerprint {
+	var entries []string
+	for _, batch := range file.Batches {
+		for _, entry := range batch.GetEntries() {
+			entries = append(entries, entry.String()[:79])
+		}
+	}
+	for _, iatBatch := range file.IATBatches {
+		for _, entry := range iatBatch.GetEntries() {
+			entries = append(entries, entry.String()[:79])
+		}
+	}
+	sort.Strings(entries)
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+
+	return FileFingerprint{
+		ImmediateOrigin:      strings.TrimSpace(file.Header.ImmediateOrigin),
+		ImmediateDestination: strings.TrimSpace(file.Header.ImmediateDestination),
+		FileCreationDate:     file.Header.FileCreationDateField(),
+		FileCreationTime:     file.Header.FileCreationTimeField(),
+		FileIDModifier:       file.Header.FileIDModifier,
+		EntryHash:            hex.EncodeToString(sum[:]),
+	}
+}
+
+// sameDate returns true if fp and other have the same origin, destination and creation date
+func (fp FileFingerprint) sameDate(other FileFingerprint) bool {
+	return fp.ImmediateOrigin == other.ImmediateOrigin &&
+		fp.ImmediateDestination == other.ImmediateDestination &&
+		fp.FileCreationDate == other.FileCreationDate
+}
+
+// sameIdentity returns true if fp and other have the same unique file identification fields
+func (fp FileFingerprint) sameIdentity(other FileFingerprint) bool {
+	return fp.sameDate(other) && fp.FileCreationTime == other.FileCreationTime && fp.FileIDModifier == other.FileIDModifier
+}
+
+// DuplicateMatch is a recorded file that a file duplicates
+type DuplicateMatch struct {
+	Reason   DuplicateReason `json:"reason"`
+	Previous FileFingerprint `json:"previous"`
+}
+
+// DuplicateDetector records the fingerprints of transmitted files so later files can be
+// checked for duplicates with FindDuplicates before they are transmitted.
+type DuplicateDetector interface {
+	// Lookup returns the recorded fingerprints with the same origin, destination and creation
+	// date as fp, or with the same EntryHash
+	Lookup(fp FileFingerprint) ([]FileFingerprint, error)
+	// Record records fp as transmitted
+	Record(fp FileFingerprint) error
+}
+
+// FindDuplicates returns the recorded files that file duplicates exactly, by identity or
+// suspiciously by its entries. An empty result means file can be transmitted.
+func FindDuplicates(d DuplicateDetector, file *File) ([]DuplicateMatch, error) {
+	fp := NewFileFingerprint(file)
+	recorded, err := d.Lookup(fp)
+	if err != nil {
+		return nil, err
+	}
+	var matches []DuplicateMatch
+	for _, prev := range recorded {
+		switch {
+		case fp.sameIdentity(prev) && fp.EntryHash == prev.EntryHash:
+			matches = append(matches, DuplicateMatch{Reason: DuplicateExact, Previous: prev})
+		case fp.sameIdentity(prev):
+			matches = append(matches, DuplicateMatch{Reason: DuplicateIdentity, Previous: prev})
+		case fp.EntryHash == prev.EntryHash:
+			matches = append(matches, DuplicateMatch{Reason: DuplicateSuspected, Previous: prev})
+		}
+	}
+	return matches, nil
+}
+
+// SetFileIDModifier sets the FileIDModifier of file to the first value from its current
+// FileIDModifier in A-Z then 0-9 that was not recorded for a file of the same origin,
+// destination and creation date. A FileError is returned once every value has been used.
+func SetFileIDModifier(d DuplicateDetector, file *File) error {
+	fp := NewFileFingerprint(file)
+	recorded, err := d.Lookup(fp)
+	if err != nil {
+		return err
+	}
+	used := make(map[string]bool)
+	for _, prev := range recorded {
+		if fp.sameDate(prev) {
+			used[prev.FileIDModifier] = true
+		}
+	}
+	start := strings.Index(fileIDModifiers, file.Header.FileIDModifier)
+	if start < 0 {
+		start = 0
+	}
+	for _, c := range fileIDModifiers[start:] {
+		if !used[string(c)] {
+			file.Header.FileIDModifier = string(c)
+			return nil
+		}
+	}
+	return &FileError{FieldName: "FileIDModifier", Value: file.Header.FileIDModifier, Msg: msgFileIDModifierExhausted, Code: ErrFileIDModifierExhausted.Code}
+}
+
+// MemoryDuplicateDetector is a DuplicateDetector of the fingerprints recorded in memory.
+// It is safe for concurrent use.
+type MemoryDuplicateDetector struct {
+	mu           sync.RWMutex
+	fingerprints []FileFingerprint
+}
+
+// NewMemoryDuplicateDetector returns an empty MemoryDuplicateDetector
+func NewMemoryDuplicateDetector() *MemoryDuplicateDetector {
+	return &MemoryDuplicateDetector{}
+}
+
+// Lookup returns the recorded fingerprints with the same origin, destination and creation date
+// as fp, or with the same EntryHash
+func (d *MemoryDuplicateDetector) Lookup(fp FileFingerprint) ([]FileFingerprint, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var found []FileFingerprint
+	for _, prev := range d.fingerprints {
+		if fp.sameDate(prev) || fp.EntryHash == prev.EntryHash {
+			found = append(found, prev)
+		}
+	}
+	return found, nil
+}
+
+// Record records fp as transmitted
+func (d *MemoryDuplicateDetector) Record(fp FileFingerprint) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fingerprints = append(d.fingerprints, fp)
+	return nil
+}
+
+// FileDuplicateDetector is a DuplicateDetector that keeps the recorded fingerprints in a file of
+// JSON lines, so duplicates are found across restarts. It is safe for concurrent use within one
+// process.
+type FileDuplicateDetector struct {
+	MemoryDuplicateDetector
+	path string
+}
+
+// NewFileDuplicateDetector returns a FileDuplicateDetector of the fingerprints recorded at path.
+// The file is created on the first Record if it does not exist.
+func NewFileDuplicateDetector(path string) (*FileDuplicateDetector, error) {
+	d := &FileDuplicateDetector{path: path}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		fp := FileFingerprint{}
+		if err := json.Unmarshal(scanner.Bytes(), &fp); err != nil {
+			return nil, err
+		}
+		d.fingerprints = append(d.fingerprints, fp)
+	}
+	return d, scanner.Err()
+}
+
+// Record appends fp to the file and records it in memory
+func (d *FileDuplicateDetector) Record(fp FileFingerprint) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	line, err := json.Marshal(fp)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(d.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	d.fingerprints = append(d.fingerprints, fp)
+	return nil
+}
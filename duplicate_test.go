@@ -0,0 +1,162 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mockDuplicateFile creates a PPD file to check for duplicates
+func mockDuplicateFile(t testing.TB) *File {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchPPD())
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return file
+}
+
+// testFindDuplicates validates exact, identity and suspected duplicate files
+func testFindDuplicates(t testing.TB) {
+	d := NewMemoryDuplicateDetector()
+	file := mockDuplicateFile(t)
+	if matches, err := FindDuplicates(d, file); err != nil || len(matches) != 0 {
+		t.Fatalf("unexpected duplicates %v %v", matches, err)
+	}
+	if err := d.Record(NewFileFingerprint(file)); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, _ := FindDuplicates(d, file)
+	if len(matches) != 1 || matches[0].Reason != DuplicateExact {
+		t.Errorf("expected an exact duplicate got %#v", matches)
+	}
+
+	// the same entries with new trace numbers and a new FileIDModifier
+	resubmitted := mockDuplicateFile(t)
+	resubmitted.Header.FileIDModifier = "B"
+	resubmitted.Batches[0].GetEntries()[0].SetTraceNumber("12104288", 99)
+	matches, _ = FindDuplicates(d, resubmitted)
+	if len(matches) != 1 || matches[0].Reason != DuplicateSuspected {
+		t.Errorf("expected a suspected duplicate got %#v", matches)
+	}
+
+	// the same header with other entries
+	other := mockDuplicateFile(t)
+	other.Batches[0].GetEntries()[0].Amount = 1
+	matches, _ = FindDuplicates(d, other)
+	if len(matches) != 1 || matches[0].Reason != DuplicateIdentity {
+		t.Errorf("expected an identity duplicate got %#v", matches)
+	}
+
+	other.Header.FileIDModifier = "C"
+	if matches, _ := FindDuplicates(d, other); len(matches) != 0 {
+		t.Errorf("unexpected duplicates %#v", matches)
+	}
+}
+
+// TestFindDuplicates tests exact, identity and suspected duplicate files
+func TestFindDuplicates(t *testing.T) {
+	testFindDuplicates(t)
+}
+
+// BenchmarkFindDuplicates benchmarks exact, identity and suspected duplicate files
+func BenchmarkFindDuplicates(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testFindDuplicates(b)
+	}
+}
+
+// testSetFileIDModifier validates incrementing the FileIDModifier of files created on the same date
+func testSetFileIDModifier(t testing.TB) {
+	d := NewMemoryDuplicateDetector()
+	file := mockDuplicateFile(t)
+	for _, modifier := range []string{"A", "B", "D"} {
+		fp := NewFileFingerprint(file)
+		fp.FileIDModifier = modifier
+		d.Record(fp)
+	}
+	if err := SetFileIDModifier(d, file); err != nil {
+		t.Fatal(err)
+	}
+	if file.Header.FileIDModifier != "C" {
+		t.Errorf("FileIDModifier %s expected C", file.Header.FileIDModifier)
+	}
+
+	for _, c := range fileIDModifiers {
+		fp := NewFileFingerprint(file)
+		fp.FileIDModifier = string(c)
+		d.Record(fp)
+	}
+	err := SetFileIDModifier(d, file)
+	if e, ok := err.(*FileError); !ok || e.Msg != msgFileIDModifierExhausted {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestSetFileIDModifier tests incrementing the FileIDModifier of files created on the same date
+func TestSetFileIDModifier(t *testing.T) {
+	testSetFileIDModifier(t)
+}
+
+// BenchmarkSetFileIDModifier benchmarks incrementing the FileIDModifier of files created on the same date
+func BenchmarkSetFileIDModifier(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testSetFileIDModifier(b)
+	}
+}
+
+// testFileDuplicateDetector validates recorded fingerprints are kept across detectors of a file
+func testFileDuplicateDetector(t testing.TB) {
+	dir, err := ioutil.TempDir("", "ach-duplicates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fingerprints.json")
+
+	d, err := NewFileDuplicateDetector(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := mockDuplicateFile(t)
+	if err := d.Record(NewFileFingerprint(file)); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileDuplicateDetector(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches, err := FindDuplicates(reopened, file)
+	if err != nil || len(matches) != 1 || matches[0].Reason != DuplicateExact {
+		t.Errorf("expected an exact duplicate got %#v %v", matches, err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("{not json\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileDuplicateDetector(path); err == nil {
+		t.Error("expected an error for a corrupt file")
+	}
+}
+
+// TestFileDuplicateDetector tests recorded fingerprints are kept across detectors of a file
+func TestFileDuplicateDetector(t *testing.T) {
+	testFileDuplicateDetector(t)
+}
+
+// BenchmarkFileDuplicateDetector benchmarks recorded fingerprints are kept across detectors of a file
+func BenchmarkFileDuplicateDetector(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testFileDuplicateDetector(b)
+	}
+}
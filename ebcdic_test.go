@@ -0,0 +1,112 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testEBCDICTables validates the code page 037 tables
+func testEBCDICTables(t testing.TB) {
+	tests := map[byte]byte{
+		'1':  0xF1,
+		'A':  0xC1,
+		'z':  0xA9,
+		' ':  0x40,
+		'*':  0x5C,
+		'\\': 0xE0,
+		'\n': 0x25,
+		'\r': 0x0D,
+	}
+	for ascii, ebcdic := range tests {
+		if got := encodeEBCDIC(string(ascii))[0]; got != ebcdic {
+			t.Errorf("%q encoded as 0x%02x want 0x%02x", ascii, got, ebcdic)
+		}
+		if got := ebcdicToLatin1[ebcdic]; got != ascii {
+			t.Errorf("0x%02x decoded as %q want %q", ebcdic, got, ascii)
+		}
+	}
+	for c := 0; c < 256; c++ {
+		if ebcdicToLatin1[latin1ToEBCDIC[c]] != byte(c) {
+			t.Errorf("0x%02x does not round trip", c)
+		}
+	}
+}
+
+// TestEBCDICTables tests the code page 037 tables
+func TestEBCDICTables(t *testing.T) {
+	testEBCDICTables(t)
+}
+
+// BenchmarkEBCDICTables benchmarks the code page 037 tables
+func BenchmarkEBCDICTables(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testEBCDICTables(b)
+	}
+}
+
+// testEBCDICReadWrite validates writing and reading a fixed-width EBCDIC file
+func testEBCDICReadWrite(t testing.TB) {
+	// more records than fit in the default bufio.Scanner buffer
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	for i := 1; i <= 800; i++ {
+		entry := mockPPDEntryDetail()
+		entry.SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, i)
+		batch.AddEntry(entry)
+	}
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	b := &bytes.Buffer{}
+	w := NewWriter(b)
+	w.LineEnding = ""
+	w.Encoding = EBCDIC
+	if err := w.Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if b.Bytes()[0] != 0xF1 || bytes.IndexByte(b.Bytes(), '1') >= 0 {
+		t.Error("file was not written in EBCDIC")
+	}
+
+	if _, err := NewReader(bytes.NewReader(b.Bytes())).Read(); err == nil {
+		t.Error("expected an error reading EBCDIC as ASCII")
+	}
+	r := NewReader(bytes.NewReader(b.Bytes()))
+	r.SetEncoding(EBCDIC)
+	read, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := read.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if len(read.Batches[0].GetEntries()) != 800 || r.LineEnding != "" {
+		t.Errorf("read %d entries with LineEnding %q", len(read.Batches[0].GetEntries()), r.LineEnding)
+	}
+	if name := read.Batches[0].GetEntries()[0].IndividualNameField(); name != file.Batches[0].GetEntries()[0].IndividualNameField() {
+		t.Errorf("IndividualName %q", name)
+	}
+}
+
+// TestEBCDICReadWrite tests writing and reading a fixed-width EBCDIC file
+func TestEBCDICReadWrite(t *testing.T) {
+	testEBCDICReadWrite(t)
+}
+
+// BenchmarkEBCDICReadWrite benchmarks writing and reading a fixed-width EBCDIC file
+func BenchmarkEBCDICReadWrite(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testEBCDICReadWrite(b)
+	}
+}
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -74,6 +75,8 @@ type EntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 const (
@@ -142,6 +145,29 @@ func (ed *EntryDetail) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the EntryDetail record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (ed *EntryDetail) AppendTo(b []byte) []byte {
+	b = append(b, ed.recordType...)
+	b = strconv.AppendInt(b, int64(ed.TransactionCode), 10)
+	b = ed.appendStringField(b, ed.RDFIIdentification, 8)
+	b = append(b, ed.CheckDigit...)
+	b = ed.appendAlphaField(b, ed.DFIAccountNumber, 17)
+	b = ed.appendNumericField(b, ed.Amount, 10)
+	b = ed.appendAlphaField(b, ed.IdentificationNumber, 15)
+	b = ed.appendAlphaField(b, ed.IndividualName, 22)
+	b = ed.appendAlphaField(b, ed.DiscretionaryData, 2)
+	b = strconv.AppendInt(b, int64(ed.AddendaRecordIndicator), 10)
+	b = ed.appendNumericField(b, ed.TraceNumber, 15)
+	return b
+}
+
+// WriteTo writes the EntryDetail record to w as the 94 characters of String.
+func (ed *EntryDetail) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(ed.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (ed *EntryDetail) Validate() error {
@@ -150,34 +176,50 @@ func (ed *EntryDetail) Validate() error {
 	}
 	if ed.recordType != "6" {
 		msg := fmt.Sprintf(msgRecordType, 6)
-		return &FieldError{FieldName: "recordType", Value: ed.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ed.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := ed.isTransactionCode(ed.TransactionCode); err != nil {
-		return &FieldError{FieldName: "TransactionCode", Value: strconv.Itoa(ed.TransactionCode), Msg: err.Error()}
+		return &FieldError{FieldName: "TransactionCode", Value: strconv.Itoa(ed.TransactionCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := ed.isAlphanumeric(ed.DFIAccountNumber); err != nil {
-		return &FieldError{FieldName: "DFIAccountNumber", Value: ed.DFIAccountNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "DFIAccountNumber", Value: ed.DFIAccountNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := ed.isAlphanumeric(ed.IdentificationNumber); err != nil {
-		return &FieldError{FieldName: "IdentificationNumber", Value: ed.IdentificationNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "IdentificationNumber", Value: ed.IdentificationNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := ed.isAlphanumeric(ed.IndividualName); err != nil {
-		return &FieldError{FieldName: "IndividualName", Value: ed.IndividualName, Msg: err.Error()}
+		return &FieldError{FieldName: "IndividualName", Value: ed.IndividualName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := ed.isAlphanumeric(ed.DiscretionaryData); err != nil {
-		return &FieldError{FieldName: "DiscretionaryData", Value: ed.DiscretionaryData, Msg: err.Error()}
+		return &FieldError{FieldName: "DiscretionaryData", Value: ed.DiscretionaryData, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	calculated := ed.CalculateCheckDigit(ed.RDFIIdentificationField())
 
 	edCheckDigit, err := strconv.Atoi(ed.CheckDigit)
 	if err != nil {
-		return &FieldError{FieldName: "CheckDigit", Value: ed.CheckDigit, Msg: err.Error()}
+		return &FieldError{FieldName: "CheckDigit", Value: ed.CheckDigit, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	if calculated != edCheckDigit {
 		msg := fmt.Sprintf(msgValidCheckDigit, calculated)
-		return &FieldError{FieldName: "RDFIIdentification", Value: ed.CheckDigit, Msg: msg}
+		return &FieldError{FieldName: "RDFIIdentification", Value: ed.CheckDigit, Msg: msg, Code: ErrInvalidCheckDigit.Code}
+	}
+	return nil
+}
+
+// ValidateWith performs Validate and the optional checks of opts
+func (ed *EntryDetail) ValidateWith(opts *ValidateOpts) error {
+	if err := ed.Validate(); err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	if opts.RoutingDirectory != nil {
+		if err := opts.RoutingDirectory.validateRouting("RDFIIdentification", ed.RDFIIdentification+ed.CheckDigit); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -186,22 +228,22 @@ func (ed *EntryDetail) Validate() error {
 // invalid the ACH transfer will be returned.
 func (ed *EntryDetail) fieldInclusion() error {
 	if ed.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: ed.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: ed.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.TransactionCode == 0 {
-		return &FieldError{FieldName: "TransactionCode", Value: strconv.Itoa(ed.TransactionCode), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TransactionCode", Value: strconv.Itoa(ed.TransactionCode), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.RDFIIdentification == "" {
-		return &FieldError{FieldName: "RDFIIdentification", Value: ed.RDFIIdentificationField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "RDFIIdentification", Value: ed.RDFIIdentificationField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.DFIAccountNumber == "" {
-		return &FieldError{FieldName: "DFIAccountNumber", Value: ed.DFIAccountNumber, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "DFIAccountNumber", Value: ed.DFIAccountNumber, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.IndividualName == "" {
-		return &FieldError{FieldName: "IndividualName", Value: ed.IndividualName, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "IndividualName", Value: ed.IndividualName, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.TraceNumber == 0 {
-		return &FieldError{FieldName: "TraceNumber", Value: ed.TraceNumberField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "TraceNumber", Value: ed.TraceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
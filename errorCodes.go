@@ -0,0 +1,275 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"errors"
+)
+
+// ErrorCode is a stable, machine-readable identifier of the kind of an error. Codes do not
+// change when the wording of an error message changes.
+type ErrorCode string
+
+// CodedError is the sentinel error of an ErrorCode. FieldError, BatchError, FileError and
+// ParseError match the sentinel of their Code with errors.Is:
+//
+//	if errors.Is(err, ach.ErrInvalidCheckDigit) {
+//		// ...
+//	}
+type CodedError struct {
+	Code ErrorCode
+}
+
+func (e *CodedError) Error() string {
+	return string(e.Code)
+}
+
+// Sentinel errors of FieldError
+var (
+	// ErrInvalidField is the code of a FieldError without a more specific code
+	ErrInvalidField = &CodedError{Code: "invalid_field"}
+	// ErrNonAlphanumeric is a field with characters that are not alphanumeric
+	ErrNonAlphanumeric = &CodedError{Code: "non_alphanumeric"}
+	// ErrNonUpperAlphanumeric is a field that is not uppercase A-Z or 0-9
+	ErrNonUpperAlphanumeric = &CodedError{Code: "non_upper_alphanumeric"}
+	// ErrFieldInclusion is a mandatory field with its default value
+	ErrFieldInclusion = &CodedError{Code: "field_inclusion"}
+	// ErrFieldRequired is a required field that is empty
+	ErrFieldRequired = &CodedError{Code: "field_required"}
+	// ErrFieldLength is a field of the wrong length
+	ErrFieldLength = &CodedError{Code: "field_length"}
+	// ErrInvalidServiceClassCode is an unknown Service Class Code
+	ErrInvalidServiceClassCode = &CodedError{Code: "invalid_service_class_code"}
+	// ErrInvalidSECCode is an unknown Standard Entry Class Code
+	ErrInvalidSECCode = &CodedError{Code: "invalid_sec_code"}
+	// ErrInvalidOriginatorStatusCode is an unknown Originator Status Code
+	ErrInvalidOriginatorStatusCode = &CodedError{Code: "invalid_originator_status_code"}
+	// ErrInvalidAddendaTypeCode is an unknown Addenda Type Code
+	ErrInvalidAddendaTypeCode = &CodedError{Code: "invalid_addenda_type_code"}
+	// ErrInvalidTransactionCode is an unknown Transaction Code
+	ErrInvalidTransactionCode = &CodedError{Code: "invalid_transaction_code"}
+	// ErrInvalidCheckDigit is a routing number check digit that does not match its calculated check digit
+	ErrInvalidCheckDigit = &CodedError{Code: "invalid_check_digit"}
+	// ErrInvalidCardTransactionType is an unknown Card Transaction Type
+	ErrInvalidCardTransactionType = &CodedError{Code: "invalid_card_transaction_type"}
+	// ErrInvalidDate is a date with an invalid year, month or day
+	ErrInvalidDate = &CodedError{Code: "invalid_date"}
+	// ErrInvalidForeignExchangeIndicator is an unknown IAT Foreign Exchange Indicator
+	ErrInvalidForeignExchangeIndicator = &CodedError{Code: "invalid_foreign_exchange_indicator"}
+	// ErrInvalidForeignExchangeReferenceIndicator is an unknown IAT Foreign Exchange Reference Indicator
+	ErrInvalidForeignExchangeReferenceIndicator = &CodedError{Code: "invalid_foreign_exchange_reference_indicator"}
+	// ErrInvalidTransactionTypeCode is an unknown Addenda10 Transaction Type Code
+	ErrInvalidTransactionTypeCode = &CodedError{Code: "invalid_transaction_type_code"}
+	// ErrInvalidIDNumberQualifier is an unknown IAT Identification Number Qualifier
+	ErrInvalidIDNumberQualifier = &CodedError{Code: "invalid_id_number_qualifier"}
+	// ErrInvalidCountryCode is not an ISO 3166-1 alpha-2 country code
+	ErrInvalidCountryCode = &CodedError{Code: "invalid_country_code"}
+	// ErrInvalidCurrencyCode is not an ISO 4217 currency code
+	ErrInvalidCurrencyCode = &CodedError{Code: "invalid_currency_code"}
+	// ErrInvalidCityStateProvince is an IAT city and state or province that is not City*State\
+	ErrInvalidCityStateProvince = &CodedError{Code: "invalid_city_state_province"}
+	// ErrInvalidCountryPostalCode is an IAT country and postal code that is not Country*Postal Code\
+	ErrInvalidCountryPostalCode = &CodedError{Code: "invalid_country_postal_code"}
+	// ErrInvalidRoutingNumber is a routing number that is not 9 digits
+	ErrInvalidRoutingNumber = &CodedError{Code: "invalid_routing_number"}
+	// ErrRoutingNotFound is a routing number that is not a FedACH participant
+	ErrRoutingNotFound = &CodedError{Code: "routing_not_found"}
+	// ErrRoutingRedirect is a routing number that has been replaced by another
+	ErrRoutingRedirect = &CodedError{Code: "routing_redirect"}
+	// ErrRoutingIneligible is a routing number that can not receive ACH entries
+	ErrRoutingIneligible = &CodedError{Code: "routing_ineligible"}
+	// ErrBankingDay is an EffectiveEntryDate that is not a banking day
+	ErrBankingDay = &CodedError{Code: "banking_day"}
+	// ErrInvalidChangeCode is an unknown Addenda98 Change Code
+	ErrInvalidChangeCode = &CodedError{Code: "invalid_change_code"}
+	// ErrInvalidCorrectedData is Addenda98 CorrectedData that does not match its Change Code
+	ErrInvalidCorrectedData = &CodedError{Code: "invalid_corrected_data"}
+	// ErrInvalidReturnCode is an unknown Addenda99 Return Code
+	ErrInvalidReturnCode = &CodedError{Code: "invalid_return_code"}
+	// ErrLateReturn is a return received after its deadline
+	ErrLateReturn = &CodedError{Code: "late_return"}
+	// ErrTraceNumberExhausted is an ODFI without unused trace number sequences
+	ErrTraceNumberExhausted = &CodedError{Code: "trace_number_exhausted"}
+	// ErrInvalidX12 is a Addenda05 payment related information that is not a valid X12 820 remittance
+	ErrInvalidX12 = &CodedError{Code: "invalid_x12"}
+	// ErrInvalidCSV is a CSV file that can not be imported
+	ErrInvalidCSV = &CodedError{Code: "invalid_csv"}
+	// ErrISO20022Conversion is a file that can not be converted to an ISO 20022 credit transfer
+	ErrISO20022Conversion = &CodedError{Code: "iso20022_conversion"}
+)
+
+// Sentinel errors of BatchError
+var (
+	// ErrInvalidBatch is the code of a BatchError without a more specific code
+	ErrInvalidBatch = &CodedError{Code: "invalid_batch"}
+	// ErrBatchHeaderControl is a batch header field that is not equal to the batch control
+	ErrBatchHeaderControl = &CodedError{Code: "batch_header_control"}
+	// ErrBatchOutOfBalance is a batch control count, amount or hash that does not match its entries
+	ErrBatchOutOfBalance = &CodedError{Code: "batch_out_of_balance"}
+	// ErrBatchAscending is a trace or sequence number that is not in ascending order
+	ErrBatchAscending = &CodedError{Code: "batch_ascending"}
+	// ErrBatchCompanyEntryDescription is a CompanyEntryDescription not allowed for the batch
+	ErrBatchCompanyEntryDescription = &CodedError{Code: "batch_company_entry_description"}
+	// ErrBatchOriginatorDNE is a DNE batch without Originator Status Code 2
+	ErrBatchOriginatorDNE = &CodedError{Code: "batch_originator_dne"}
+	// ErrBatchTraceNumberNotODFI is an entry trace number that does not start with the ODFI
+	ErrBatchTraceNumberNotODFI = &CodedError{Code: "batch_trace_number_not_odfi"}
+	// ErrBatchAddendaIndicator is an AddendaRecordIndicator that does not match the addenda of an entry
+	ErrBatchAddendaIndicator = &CodedError{Code: "batch_addenda_indicator"}
+	// ErrBatchAddendaTraceNumber is an addenda trace number that does not match its entry
+	ErrBatchAddendaTraceNumber = &CodedError{Code: "batch_addenda_trace_number"}
+	// ErrBatchEntries is a batch without entries
+	ErrBatchEntries = &CodedError{Code: "batch_entries"}
+	// ErrBatchAddendaCount is an entry with a number of addenda that its batch does not allow
+	ErrBatchAddendaCount = &CodedError{Code: "batch_addenda_count"}
+	// ErrBatchAddendaType is an addenda type that is not allowed in the batch
+	ErrBatchAddendaType = &CodedError{Code: "batch_addenda_type"}
+	// ErrBatchTransactionCode is a transaction code that is not allowed in the batch
+	ErrBatchTransactionCode = &CodedError{Code: "batch_transaction_code"}
+	// ErrBatchTransactionCodeAddenda is an entry with addenda that its transaction code does not allow
+	ErrBatchTransactionCodeAddenda = &CodedError{Code: "batch_transaction_code_addenda"}
+	// ErrBatchSECType is a batch header SEC code that does not match the batch type
+	ErrBatchSECType = &CodedError{Code: "batch_sec_type"}
+	// ErrBatchServiceClassCode is a Service Class Code not allowed for the batch
+	ErrBatchServiceClassCode = &CodedError{Code: "batch_service_class_code"}
+	// ErrBatchForwardReturn is a batch of both forward and return entries
+	ErrBatchForwardReturn = &CodedError{Code: "batch_forward_return"}
+	// ErrBatchAmount is an entry amount above the limit of its SEC code
+	ErrBatchAmount = &CodedError{Code: "batch_amount"}
+	// ErrBatchCheckSerialNumber is a check entry without a check serial number
+	ErrBatchCheckSerialNumber = &CodedError{Code: "batch_check_serial_number"}
+	// ErrBatchPaymentType is a WEB entry with an unknown payment type
+	ErrBatchPaymentType = &CodedError{Code: "batch_payment_type"}
+	// ErrBatchHealthcareEFT is a CCD health care claim payment without a valid TRN reassociation segment
+	ErrBatchHealthcareEFT = &CodedError{Code: "batch_healthcare_eft"}
+	// ErrSameDayDescriptiveDate is a Same Day batch without a SDHHMM descriptive date
+	ErrSameDayDescriptiveDate = &CodedError{Code: "same_day_descriptive_date"}
+	// ErrSameDayAmount is a Same Day entry above the Same Day ACH entry limit
+	ErrSameDayAmount = &CodedError{Code: "same_day_amount"}
+	// ErrSameDayIAT is a Same Day batch of IAT entries
+	ErrSameDayIAT = &CodedError{Code: "same_day_iat"}
+)
+
+// Sentinel errors of FileError
+var (
+	// ErrInvalidFile is the code of a FileError without a more specific code
+	ErrInvalidFile = &CodedError{Code: "invalid_file"}
+	// ErrFileOutOfBalance is a file control count, amount or hash that does not match its batches
+	ErrFileOutOfBalance = &CodedError{Code: "file_out_of_balance"}
+	// ErrRecordLength is a record that is not 94 characters
+	ErrRecordLength = &CodedError{Code: "record_length"}
+	// ErrUnknownRecordType is a record with an unknown record type
+	ErrUnknownRecordType = &CodedError{Code: "unknown_record_type"}
+	// ErrFileBatches is a file without batches
+	ErrFileBatches = &CodedError{Code: "file_batches"}
+	// ErrFileBatchOutside is a batch record outside of a batch
+	ErrFileBatchOutside = &CodedError{Code: "file_batch_outside"}
+	// ErrFileBatchInside is a batch header inside of a batch
+	ErrFileBatchInside = &CodedError{Code: "file_batch_inside"}
+	// ErrFileHeader is a file without exactly one file header
+	ErrFileHeader = &CodedError{Code: "file_header"}
+	// ErrFileControl is a file without exactly one file control
+	ErrFileControl = &CodedError{Code: "file_control"}
+	// ErrFileSECNotImplemented is a batch of an unsupported Standard Entry Class Code
+	ErrFileSECNotImplemented = &CodedError{Code: "file_sec_not_implemented"}
+	// ErrFileHeaderFormat is a file header record size, blocking factor or format code that is not allowed
+	ErrFileHeaderFormat = &CodedError{Code: "file_header_format"}
+	// ErrReaderLimit is a file with more lines, batches or addenda than the limits of the Reader
+	ErrReaderLimit = &CodedError{Code: "reader_limit"}
+	// ErrFileIDModifierExhausted is a date on which every FileIDModifier has been used
+	ErrFileIDModifierExhausted = &CodedError{Code: "file_id_modifier_exhausted"}
+)
+
+// codedMsg is the error of a validator message with the code of its sentinel, so the
+// FieldError built from it has the code of the message
+type codedMsg struct {
+	code ErrorCode
+	msg  string
+}
+
+// errorWithCode returns an error of msg with the code of sentinel
+func errorWithCode(sentinel *CodedError, msg string) error {
+	return &codedMsg{code: sentinel.Code, msg: msg}
+}
+
+func (e *codedMsg) Error() string {
+	return e.msg
+}
+
+func (e *codedMsg) errorCode() ErrorCode {
+	return e.code
+}
+
+// Is returns true if target is the sentinel of the code of the message
+func (e *codedMsg) Is(target error) bool {
+	return isCode(target, e.code)
+}
+
+// isCode returns true if target is a sentinel of code
+func isCode(target error, code ErrorCode) bool {
+	sentinel, ok := target.(*CodedError)
+	return ok && sentinel.Code == code
+}
+
+// errorCode returns the Code of the FieldError, or the code of ErrInvalidField when it is empty
+func (e *FieldError) errorCode() ErrorCode {
+	if e.Code == "" {
+		return ErrInvalidField.Code
+	}
+	return e.Code
+}
+
+// Is returns true if target is ErrInvalidField or the sentinel of the code of the FieldError
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalidField || isCode(target, e.errorCode())
+}
+
+// errorCode returns the Code of the BatchError, or the code of ErrInvalidBatch when it is empty
+func (e *BatchError) errorCode() ErrorCode {
+	if e.Code == "" {
+		return ErrInvalidBatch.Code
+	}
+	return e.Code
+}
+
+// Is returns true if target is ErrInvalidBatch or the sentinel of the code of the BatchError
+func (e *BatchError) Is(target error) bool {
+	return target == ErrInvalidBatch || isCode(target, e.errorCode())
+}
+
+// errorCode returns the Code of the FileError, or the code of ErrInvalidFile when it is empty
+func (e *FileError) errorCode() ErrorCode {
+	if e.Code == "" {
+		return ErrInvalidFile.Code
+	}
+	return e.Code
+}
+
+// Is returns true if target is ErrInvalidFile or the sentinel of the code of the FileError
+func (e *FileError) Is(target error) bool {
+	return target == ErrInvalidFile || isCode(target, e.errorCode())
+}
+
+// Code returns the ErrorCode of the error that caused the ParseError
+func (e *ParseError) Code() ErrorCode {
+	return ErrorCodeOf(e.Err)
+}
+
+// Unwrap returns the error that caused the ParseError
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCodeOf returns the ErrorCode of the first FieldError, BatchError or FileError in the
+// chain of err, or an empty ErrorCode if there is none.
+func ErrorCodeOf(err error) ErrorCode {
+	var coded interface {
+		errorCode() ErrorCode
+	}
+	if errors.As(err, &coded) {
+		return coded.errorCode()
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// msgScreener is a Screener that fails with its message
+type msgScreener string
+
+func (s msgScreener) Screen(party ScreeningParty) ([]ScreeningMatch, error) {
+	return nil, errors.New(string(s))
+}
+
+// testErrorCodes validates the code of an error is its Code rather than its message
+func testErrorCodes(t testing.TB) {
+	err := &FieldError{FieldName: "BatchCount", Msg: "calculated 2 is out-of-balance with control 1"}
+	if code := ErrorCodeOf(err); code != ErrInvalidField.Code {
+		t.Errorf("got %s want %s", code, ErrInvalidField.Code)
+	}
+	err.Code = ErrFieldRequired.Code
+	if code := ErrorCodeOf(err); code != ErrFieldRequired.Code || !errors.Is(err, ErrFieldRequired) {
+		t.Errorf("got %s want %s", code, ErrFieldRequired.Code)
+	}
+
+	v := validator{}
+	if code := ErrorCodeOf(v.isAlphanumeric("\x01")); code != ErrNonAlphanumeric.Code {
+		t.Errorf("got %s want %s", code, ErrNonAlphanumeric.Code)
+	}
+	if !errors.Is(v.isSECCode("ABC"), ErrInvalidSECCode) {
+		t.Error("expected ErrInvalidSECCode")
+	}
+	if code := ErrorCodeOf(errors.New(msgSECCode)); code != "" {
+		t.Errorf("got %s from the message of an error", code)
+	}
+
+	// the message of a Screener is not classified by its text
+	batch := mockIATBatch()
+	batch.SetScreener(msgScreener(msgBatchAscending))
+	_, screenErr := batch.Screen()
+	if !errors.Is(screenErr, ErrInvalidBatch) || errors.Is(screenErr, ErrBatchAscending) {
+		t.Errorf("%T: %s", screenErr, screenErr)
+	}
+}
+
+// TestErrorCodes tests the code of an error is its Code rather than its message
+func TestErrorCodes(t *testing.T) {
+	testErrorCodes(t)
+}
+
+// BenchmarkErrorCodes benchmarks the code of an error is its Code rather than its message
+func BenchmarkErrorCodes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testErrorCodes(b)
+	}
+}
+
+// testErrorsIs validates matching validation errors to their sentinels with errors.Is
+func testErrorsIs(t testing.TB) {
+	ed := mockEntryDetail()
+	ed.CheckDigit = "0"
+	err := ed.Validate()
+	if !errors.Is(err, ErrInvalidCheckDigit) || !errors.Is(err, ErrInvalidField) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if errors.Is(err, ErrBatchOutOfBalance) || errors.Is(err, ErrInvalidBatch) {
+		t.Errorf("%T: %s matches a batch error", err, err)
+	}
+	if code := ErrorCodeOf(err); code != "invalid_check_digit" {
+		t.Errorf("ErrorCode %q", code)
+	}
+
+	batch := mockBatchPPD()
+	batch.GetControl().TotalCreditEntryDollarAmount = 1
+	err = batch.Validate()
+	if !errors.Is(err, ErrBatchOutOfBalance) || errors.Is(err, ErrFileOutOfBalance) {
+		t.Errorf("%T: %s", err, err)
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || batchErr.Code != ErrBatchOutOfBalance.Code {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	err = &FileError{FieldName: "BatchCount", Msg: "calculated 2 is out-of-balance with control 1", Code: ErrFileOutOfBalance.Code}
+	if !errors.Is(err, ErrFileOutOfBalance) || errors.Is(err, ErrBatchOutOfBalance) {
+		t.Errorf("%T: %s", err, err)
+	}
+	if ErrorCodeOf(errors.New("not an ach error")) != "" {
+		t.Error("expected no ErrorCode")
+	}
+}
+
+// TestErrorsIs tests matching validation errors to their sentinels with errors.Is
+func TestErrorsIs(t *testing.T) {
+	testErrorsIs(t)
+}
+
+// BenchmarkErrorsIs benchmarks matching validation errors to their sentinels with errors.Is
+func BenchmarkErrorsIs(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testErrorsIs(b)
+	}
+}
+
+// testParseErrorPositions validates a ParseError has the line and positions of the field in error
+func testParseErrorPositions(t testing.TB) {
+	bs, err := ioutil.ReadFile("./test/data/ppd-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(bs), "\n")
+	entry := lines[2]
+	lines[2] = entry[:11] + "2" + entry[12:]
+
+	_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if parseErr.Line != 3 || parseErr.Start != 4 || parseErr.End != 11 {
+		t.Errorf("line %d positions %d-%d", parseErr.Line, parseErr.Start, parseErr.End)
+	}
+	if !errors.Is(err, ErrInvalidCheckDigit) || parseErr.Code() != ErrInvalidCheckDigit.Code {
+		t.Errorf("%T: %s", err, err)
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.FieldName != "RDFIIdentification" {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if fieldErr.Line != 3 || fieldErr.Start != 4 || fieldErr.End != 11 {
+		t.Errorf("FieldError line %d positions %d-%d", fieldErr.Line, fieldErr.Start, fieldErr.End)
+	}
+
+	// batch errors are reported at the field of the batch control
+	lines[2] = strings.Replace(entry, "0000010500", "0000010501", 1)
+	_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if parseErr.Line != 4 || parseErr.Start != 21 || parseErr.End != 32 {
+		t.Errorf("line %d positions %d-%d", parseErr.Line, parseErr.Start, parseErr.End)
+	}
+	var batchErr *BatchError
+	if !errors.Is(err, ErrBatchOutOfBalance) || !errors.As(err, &batchErr) {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if batchErr.Line != 4 || batchErr.Start != 21 || batchErr.End != 32 {
+		t.Errorf("BatchError line %d positions %d-%d", batchErr.Line, batchErr.Start, batchErr.End)
+	}
+}
+
+// TestParseErrorPositions tests a ParseError has the line and positions of the field in error
+func TestParseErrorPositions(t *testing.T) {
+	testParseErrorPositions(t)
+}
+
+// BenchmarkParseErrorPositions benchmarks a ParseError has the line and positions of the field in error
+func BenchmarkParseErrorPositions(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testParseErrorPositions(b)
+	}
+}
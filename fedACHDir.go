@@ -0,0 +1,169 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FedACHLineLength is the length of a participant record of the FedACH directory
+const FedACHLineLength = 155
+
+// Errors specific to the FedACH participant directory
+var (
+	msgFedACHLineLength    = "must be %d characters and found %d"
+	msgFedACHRoutingNumber = "is not a valid routing number"
+	msgRoutingNotFound     = "is not a FedACH participant"
+	msgRoutingRedirect     = "of %s has been replaced by routing number %s"
+	msgRoutingIneligible   = "of %s is not eligible to receive ACH entries"
+)
+
+// FedACHParticipant is a financial institution listed in the FedACH participant directory
+// published by the Federal Reserve Banks (FedACHdir.txt).
+type FedACHParticipant struct {
+	// RoutingNumber is the 9 digit routing number of the institution
+	RoutingNumber string `json:"routingNumber"`
+	// OfficeCode is O for a main office and B for a branch
+	OfficeCode string `json:"officeCode"`
+	// ServicingFRBNumber is the routing number of the Federal Reserve Bank servicing the institution
+	ServicingFRBNumber string `json:"servicingFRBNumber"`
+	// RecordTypeCode is 0 for a Federal Reserve Bank, 1 to send entries to the routing number
+	// and 2 to send entries to NewRoutingNumber
+	RecordTypeCode string `json:"recordTypeCode"`
+	// ChangeDate is the MMDDYY date the record was last changed
+	ChangeDate string `json:"changeDate"`
+	// NewRoutingNumber is the routing number entries are sent to when RecordTypeCode is 2
+	NewRoutingNumber string `json:"newRoutingNumber"`
+	// CustomerName is the name of the institution
+	CustomerName string `json:"customerName"`
+	// Address is the street address of the institution
+	Address string `json:"address"`
+	// City is the city of the institution
+	City string `json:"city"`
+	// State is the state code of the institution
+	State string `json:"state"`
+	// PostalCode is the 5 digit zip code of the institution
+	PostalCode string `json:"postalCode"`
+	// PostalCodeExtension is the 4 digit zip code extension of the institution
+	PostalCodeExtension string `json:"postalCodeExtension"`
+	// PhoneNumber is the 10 digit telephone number of the institution
+	PhoneNumber string `json:"phoneNumber"`
+	// StatusCode is the institution status code, 1 for an institution that receives entries
+	StatusCode string `json:"statusCode"`
+	// ViewCode is the data view code of the record
+	ViewCode string `json:"viewCode"`
+}
+
+// Parse takes a FedACH directory line and parses the participant values
+func (p *FedACHParticipant) Parse(line string) {
+	p.RoutingNumber = strings.TrimSpace(line[0:9])
+	p.OfficeCode = strings.TrimSpace(line[9:10])
+	p.ServicingFRBNumber = strings.TrimSpace(line[10:19])
+	p.RecordTypeCode = strings.TrimSpace(line[19:20])
+	p.ChangeDate = strings.TrimSpace(line[20:26])
+	p.NewRoutingNumber = strings.TrimSpace(line[26:35])
+	p.CustomerName = strings.TrimSpace(line[35:71])
+	p.Address = strings.TrimSpace(line[71:107])
+	p.City = strings.TrimSpace(line[107:127])
+	p.State = strings.TrimSpace(line[127:129])
+	p.PostalCode = strings.TrimSpace(line[129:134])
+	p.PostalCodeExtension = strings.TrimSpace(line[134:138])
+	p.PhoneNumber = strings.TrimSpace(line[138:148])
+	p.StatusCode = strings.TrimSpace(line[148:149])
+	p.ViewCode = strings.TrimSpace(line[149:150])
+}
+
+// ACHEligible returns true if entries can be sent to the participant routing number.
+// Participants whose entries are redirected to a new routing number are not eligible.
+func (p *FedACHParticipant) ACHEligible() bool {
+	return p.RecordTypeCode != "2" && p.StatusCode != "0"
+}
+
+// RoutingDirectory is a lookup of FedACH participants by routing number
+type RoutingDirectory struct {
+	participants map[string]*FedACHParticipant
+}
+
+// NewRoutingDirectory returns an empty RoutingDirectory
+func NewRoutingDirectory() *RoutingDirectory {
+	return &RoutingDirectory{
+		participants: make(map[string]*FedACHParticipant),
+	}
+}
+
+// ReadFedACHDirectory reads a FedACH participant directory file into a RoutingDirectory.
+// Blank lines are skipped and the filler at the end of each line is optional.
+func ReadFedACHDirectory(r io.Reader) (*RoutingDirectory, error) {
+	dir := NewRoutingDirectory()
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(line) < FedACHLineLength-5 || len(line) > FedACHLineLength {
+			msg := fmt.Sprintf(msgFedACHLineLength, FedACHLineLength, len(line))
+			err := &FileError{FieldName: "RecordLength", Value: strconv.Itoa(len(line)), Msg: msg, Code: ErrRecordLength.Code}
+			return dir, &ParseError{Line: lineNum, Record: "FedACHParticipant", Err: err}
+		}
+		p := new(FedACHParticipant)
+		p.Parse(line)
+		if len(p.RoutingNumber) != 9 || !isDigits(p.RoutingNumber) {
+			err := &FieldError{FieldName: "RoutingNumber", Value: p.RoutingNumber, Msg: msgFedACHRoutingNumber, Code: ErrInvalidRoutingNumber.Code}
+			return dir, &ParseError{Line: lineNum, Record: "FedACHParticipant", Err: err}
+		}
+		dir.Add(p)
+	}
+	return dir, scanner.Err()
+}
+
+// Add adds or replaces the participant of p.RoutingNumber
+func (d *RoutingDirectory) Add(p *FedACHParticipant) {
+	d.participants[p.RoutingNumber] = p
+}
+
+// Lookup returns the participant of a 9 digit routing number or nil if it is not in the directory
+func (d *RoutingDirectory) Lookup(routingNumber string) *FedACHParticipant {
+	return d.participants[strings.TrimSpace(routingNumber)]
+}
+
+// Len returns the number of participants in the directory
+func (d *RoutingDirectory) Len() int {
+	return len(d.participants)
+}
+
+// validateRouting returns a FieldError if routingNumber is not an eligible participant
+// of the directory. Redirected routing numbers report their new routing number.
+func (d *RoutingDirectory) validateRouting(fieldName, routingNumber string) error {
+	p := d.Lookup(routingNumber)
+	if p == nil {
+		return &FieldError{FieldName: fieldName, Value: routingNumber, Msg: msgRoutingNotFound, Code: ErrRoutingNotFound.Code}
+	}
+	if p.RecordTypeCode == "2" {
+		msg := fmt.Sprintf(msgRoutingRedirect, p.CustomerName, p.NewRoutingNumber)
+		return &FieldError{FieldName: fieldName, Value: routingNumber, Msg: msg, Code: ErrRoutingRedirect.Code}
+	}
+	if !p.ACHEligible() {
+		msg := fmt.Sprintf(msgRoutingIneligible, p.CustomerName)
+		return &FieldError{FieldName: fieldName, Value: routingNumber, Msg: msg, Code: ErrRoutingIneligible.Code}
+	}
+	return nil
+}
+
+// isDigits returns true if s only contains the numerals 0-9
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
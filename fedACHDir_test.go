@@ -0,0 +1,206 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// mockRoutingDirectory reads the FedACH directory of test data
+func mockRoutingDirectory(t testing.TB) *RoutingDirectory {
+	f, err := os.Open("./test/data/FedACHdir.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	dir, err := ReadFedACHDirectory(f)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return dir
+}
+
+// testReadFedACHDirectory validates parsing the participants of a FedACH directory
+func testReadFedACHDirectory(t testing.TB) {
+	dir := mockRoutingDirectory(t)
+	if dir.Len() != 5 {
+		t.Fatalf("expected 5 participants got %d", dir.Len())
+	}
+	p := dir.Lookup("121042882")
+	if p == nil {
+		t.Fatal("121042882 was not found")
+	}
+	if p.CustomerName != "WELLS FARGO BANK NA" || p.City != "MINNEAPOLIS" || p.State != "MN" {
+		t.Errorf("unexpected participant %#v", p)
+	}
+	if p.ServicingFRBNumber != "121000374" || p.PostalCode != "55479" || p.PhoneNumber != "8007452426" {
+		t.Errorf("unexpected participant %#v", p)
+	}
+	if !p.ACHEligible() {
+		t.Error("121042882 should be ACH eligible")
+	}
+	redirect := dir.Lookup("231380104")
+	if redirect.ACHEligible() || redirect.NewRoutingNumber != "231380117" {
+		t.Errorf("unexpected redirect %#v", redirect)
+	}
+	if dir.Lookup("999999999") != nil {
+		t.Error("999999999 should not be found")
+	}
+}
+
+// TestReadFedACHDirectory tests parsing the participants of a FedACH directory
+func TestReadFedACHDirectory(t *testing.T) {
+	testReadFedACHDirectory(t)
+}
+
+// BenchmarkReadFedACHDirectory benchmarks parsing the participants of a FedACH directory
+func BenchmarkReadFedACHDirectory(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReadFedACHDirectory(b)
+	}
+}
+
+// testReadFedACHDirectoryErr validates errors for invalid directory lines
+func testReadFedACHDirectoryErr(t testing.TB) {
+	tests := map[string]string{
+		"RecordLength":  "121042882O121000374",
+		"RoutingNumber": "12104288XO1210003741090118000000000" + strings.Repeat(" ", 115),
+	}
+	for field, doc := range tests {
+		_, err := ReadFedACHDirectory(strings.NewReader("\n" + doc + "\n"))
+		p, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%s: expected ParseError got %T: %v", field, err, err)
+			continue
+		}
+		if p.Line != 2 {
+			t.Errorf("%s: unexpected line %d", field, p.Line)
+		}
+		switch e := p.Err.(type) {
+		case *FieldError:
+			if e.FieldName != field {
+				t.Errorf("%s: unexpected FieldName %s", field, e.FieldName)
+			}
+		case *FileError:
+			if e.FieldName != field {
+				t.Errorf("%s: unexpected FieldName %s", field, e.FieldName)
+			}
+		default:
+			t.Errorf("%s: %T: %s", field, p.Err, p.Err)
+		}
+	}
+}
+
+// TestReadFedACHDirectoryErr tests errors for invalid directory lines
+func TestReadFedACHDirectoryErr(t *testing.T) {
+	testReadFedACHDirectoryErr(t)
+}
+
+// BenchmarkReadFedACHDirectoryErr benchmarks errors for invalid directory lines
+func BenchmarkReadFedACHDirectoryErr(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReadFedACHDirectoryErr(b)
+	}
+}
+
+// testEntryDetailValidateWith validates the RDFI of an entry against a RoutingDirectory
+func testEntryDetailValidateWith(t testing.TB) {
+	opts := &ValidateOpts{RoutingDirectory: mockRoutingDirectory(t)}
+	entry := mockEntryDetail()
+	if err := entry.ValidateWith(opts); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := entry.ValidateWith(nil); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	tests := map[string]string{
+		"231380104": "has been replaced by routing number 231380117",
+		"091000019": msgRoutingNotFound,
+	}
+	for routing, msg := range tests {
+		entry.SetRDFI(routing)
+		err := entry.ValidateWith(opts)
+		e, ok := err.(*FieldError)
+		if !ok {
+			t.Errorf("%s: expected FieldError got %T: %v", routing, err, err)
+			continue
+		}
+		if e.FieldName != "RDFIIdentification" || !strings.Contains(e.Msg, msg) {
+			t.Errorf("%s: %s", routing, e.Error())
+		}
+	}
+}
+
+// TestEntryDetailValidateWith tests the RDFI of an entry against a RoutingDirectory
+func TestEntryDetailValidateWith(t *testing.T) {
+	testEntryDetailValidateWith(t)
+}
+
+// BenchmarkEntryDetailValidateWith benchmarks the RDFI of an entry against a RoutingDirectory
+func BenchmarkEntryDetailValidateWith(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testEntryDetailValidateWith(b)
+	}
+}
+
+// testReaderRoutingDirectory validates reading a file checked against a RoutingDirectory
+func testReaderRoutingDirectory(t testing.TB) {
+	dir := mockRoutingDirectory(t)
+	f, err := os.Open("./test/data/ppd-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r := NewReader(f)
+	r.SetValidation(&ValidateOpts{RoutingDirectory: dir})
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.ValidateWith(&ValidateOpts{RoutingDirectory: dir}); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// the file is rejected by a directory without its RDFI
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	empty := NewRoutingDirectory()
+	empty.Add(dir.Lookup("076401251"))
+	r = NewReader(f)
+	r.SetValidation(&ValidateOpts{RoutingDirectory: empty})
+	_, err = r.Read()
+	p, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("expected ParseError got %T: %v", err, err)
+	}
+	if e, ok := p.Err.(*FieldError); !ok || e.FieldName != "RDFIIdentification" {
+		t.Errorf("%T: %s", p.Err, p.Err)
+	}
+	if err := file.ValidateWith(&ValidateOpts{RoutingDirectory: empty}); err == nil {
+		t.Error("expected a BatchError")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "RDFIIdentification" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestReaderRoutingDirectory tests reading a file checked against a RoutingDirectory
+func TestReaderRoutingDirectory(t *testing.T) {
+	testReaderRoutingDirectory(t)
+}
+
+// BenchmarkReaderRoutingDirectory benchmarks reading a file checked against a RoutingDirectory
+func BenchmarkReaderRoutingDirectory(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderRoutingDirectory(b)
+	}
+}
@@ -5,8 +5,10 @@
 package ach
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"sync"
 )
 
 // First position of all Record Types. These codes are uniquely assigned to
@@ -28,11 +30,13 @@ var (
 	msgFileCalculatedControlEquality = "calculated %v is out-of-balance with control %v"
 	// specific messages
 	msgRecordLength      = "must be 94 characters and found %d"
+	msgFileBatches       = "must have []*Batches to be built"
 	msgFileBatchOutside  = "outside of current batch"
 	msgFileBatchInside   = "inside of current batch"
 	msgFileControl       = "none or more than one file control exists"
 	msgFileHeader        = "none or more than one file headers exists"
 	msgUnknownRecordType = "%s is an unknown record type"
+	msgReaderLimit       = "exceeds the limit of %d"
 	msgFileNoneSEC       = "%v Standard Entry Class Code is not implemented"
 	msgFileIATSEC        = "%v Standard Entry Class Code should use iatBatch"
 )
@@ -42,6 +46,12 @@ type FileError struct {
 	FieldName string
 	Value     string
 	Msg       string
+	// Code is the code of the error, ErrInvalidFile when empty
+	Code ErrorCode
+	// Line is the line of the record read by a Reader, 0 otherwise
+	Line int
+	// Start and End are the positions of the field in the record, 0 when unknown
+	Start, End int
 }
 
 func (e *FileError) Error() string {
@@ -80,7 +90,7 @@ func (f *File) Create() error {
 	}
 	// Requires at least one Batch in the new file.
 	if len(f.Batches) <= 0 && len(f.IATBatches) <= 0 {
-		return &FileError{FieldName: "Batches", Value: strconv.Itoa(len(f.Batches)), Msg: "must have []*Batches to be built"}
+		return &FileError{FieldName: "Batches", Value: strconv.Itoa(len(f.Batches)), Msg: msgFileBatches, Code: ErrFileBatches.Code}
 	}
 	// add 2 for FileHeader/control and reset if build was called twice do to error
 	totalRecordsInFile := 2
@@ -122,11 +132,15 @@ func (f *File) Create() error {
 	// create FileControl from calculated values
 	fc := NewFileControl()
 	fc.BatchCount = batchSeq - 1
-	// blocking factor of 10 is static default value in f.Header.blockingFactor.
-	if (totalRecordsInFile % 10) != 0 {
-		fc.BlockCount = totalRecordsInFile/10 + 1
+	// blocks of the blocking factor of the header, 10 by default
+	blockingFactor, _ := strconv.Atoi(f.Header.blockingFactor)
+	if blockingFactor <= 0 {
+		blockingFactor = 10
+	}
+	if (totalRecordsInFile % blockingFactor) != 0 {
+		fc.BlockCount = totalRecordsInFile/blockingFactor + 1
 	} else {
-		fc.BlockCount = totalRecordsInFile / 10
+		fc.BlockCount = totalRecordsInFile / blockingFactor
 	}
 	fc.EntryAddendaCount = fileEntryAddendaCount
 	fc.EntryHash = fileEntryHashSum
@@ -167,7 +181,7 @@ func (f *File) Validate() error {
 	// The value of the Batch Count Field is equal to the number of Company/Batch/Header Records in the file.
 	if f.Control.BatchCount != (len(f.Batches) + len(f.IATBatches)) {
 		msg := fmt.Sprintf(msgFileCalculatedControlEquality, len(f.Batches), f.Control.BatchCount)
-		return &FileError{FieldName: "BatchCount", Value: strconv.Itoa(len(f.Batches)), Msg: msg}
+		return &FileError{FieldName: "BatchCount", Value: strconv.Itoa(len(f.Batches)), Msg: msg, Code: ErrFileOutOfBalance.Code}
 	}
 
 	if err := f.isEntryAddendaCount(); err != nil {
@@ -181,6 +195,159 @@ func (f *File) Validate() error {
 	return f.isEntryHash()
 }
 
+// ValidateWith performs Validate and the optional checks of opts on the file header and
+// every batch header and entry of the file.
+func (f *File) ValidateWith(opts *ValidateOpts) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	if err := f.Header.ValidateWith(opts); err != nil {
+		return err
+	}
+	for _, batch := range f.Batches {
+		if err := f.validateBatchWith(batch, opts); err != nil {
+			return err
+		}
+	}
+	for _, iatBatch := range f.IATBatches {
+		if err := f.validateIATBatchWith(iatBatch, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateWithContext performs Validate, the Validate of every batch and IAT batch, and the
+// optional checks of opts when opts is not nil. The batches are validated by opts.Workers
+// goroutines, each batch with its entries by a single goroutine. The error returned is of the
+// first batch in error in the order of the file whatever the number of workers, or ctx.Err()
+// when ctx is done before the batches before it are validated.
+func (f *File) ValidateWithContext(ctx context.Context, opts *ValidateOpts) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if opts != nil {
+		if err := f.Header.ValidateWith(opts); err != nil {
+			return err
+		}
+	}
+	n := len(f.Batches) + len(f.IATBatches)
+	validate := func(i int) error {
+		if i < len(f.Batches) {
+			batch := f.Batches[i]
+			if err := batch.Validate(); err != nil || opts == nil {
+				return err
+			}
+			return f.validateBatchWith(batch, opts)
+		}
+		iatBatch := f.IATBatches[i-len(f.Batches)]
+		if err := iatBatch.Validate(); err != nil || opts == nil {
+			return err
+		}
+		return f.validateIATBatchWith(iatBatch, opts)
+	}
+
+	workers := 1
+	if opts != nil && opts.Workers > 1 {
+		workers = opts.Workers
+	}
+	if workers > n {
+		workers = n
+	}
+	var (
+		mu        sync.Mutex
+		next      int
+		firstErr  = n
+		errs      = make([]error, n)
+		validated = make([]bool, n)
+		wg        sync.WaitGroup
+	)
+	// batches are taken in the order of the file, so a worker stops at the first batch after
+	// the first batch in error
+	take := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next >= firstErr || ctx.Err() != nil {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, ok := take(); ok; i, ok = take() {
+				err := validate(i)
+				mu.Lock()
+				errs[i], validated[i] = err, true
+				if err != nil && i < firstErr {
+					firstErr = i
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		if !validated[i] {
+			return ctx.Err()
+		}
+		if errs[i] != nil {
+			return errs[i]
+		}
+	}
+	return nil
+}
+
+// validateBatchWith performs the optional checks of opts on the header and entries of batch
+func (f *File) validateBatchWith(batch Batcher, opts *ValidateOpts) error {
+	if err := batch.GetHeader().ValidateWith(opts); err != nil {
+		return batchValidateError(batch.GetHeader().BatchNumber, err)
+	}
+	if opts.SameDay {
+		if err := validateSameDay(batch, f.Header.FileCreationDate); err != nil {
+			return err
+		}
+	}
+	for _, entry := range batch.GetEntries() {
+		if err := entry.ValidateWith(opts); err != nil {
+			return batchValidateError(batch.GetHeader().BatchNumber, err)
+		}
+	}
+	return nil
+}
+
+// validateIATBatchWith performs the optional checks of opts on the header and entries of iatBatch
+func (f *File) validateIATBatchWith(iatBatch IATBatch, opts *ValidateOpts) error {
+	if err := iatBatch.GetHeader().ValidateWith(opts); err != nil {
+		return batchValidateError(iatBatch.GetHeader().BatchNumber, err)
+	}
+	if opts.SameDay {
+		if err := validateSameDayIAT(iatBatch, f.Header.FileCreationDate); err != nil {
+			return err
+		}
+	}
+	for _, entry := range iatBatch.GetEntries() {
+		if err := entry.ValidateWith(opts); err != nil {
+			return batchValidateError(iatBatch.GetHeader().BatchNumber, err)
+		}
+	}
+	return nil
+}
+
+// batchValidateError converts the field error of an entry in to a batch error for a consistent api
+func batchValidateError(batchNumber int, err error) error {
+	if e, ok := err.(*FieldError); ok {
+		return &BatchError{BatchNumber: batchNumber, FieldName: e.FieldName, Msg: e.Value + " " + e.Msg, Code: e.Code}
+	}
+	return &BatchError{BatchNumber: batchNumber, FieldName: "FieldError", Msg: err.Error(), Code: ErrorCodeOf(err)}
+}
+
 // isEntryAddendaCount is prepared by hashing the RDFI’s 8-digit Routing Number in each entry.
 //The Entry Hash provides a check against inadvertent alteration of data
 func (f *File) isEntryAddendaCount() error {
@@ -195,7 +362,7 @@ func (f *File) isEntryAddendaCount() error {
 	}
 	if f.Control.EntryAddendaCount != count {
 		msg := fmt.Sprintf(msgFileCalculatedControlEquality, count, f.Control.EntryAddendaCount)
-		return &FileError{FieldName: "EntryAddendaCount", Value: f.Control.EntryAddendaCountField(), Msg: msg}
+		return &FileError{FieldName: "EntryAddendaCount", Value: f.Control.EntryAddendaCountField(), Msg: msg, Code: ErrFileOutOfBalance.Code}
 	}
 	return nil
 }
@@ -216,11 +383,11 @@ func (f *File) isFileAmount() error {
 	}
 	if f.Control.TotalDebitEntryDollarAmountInFile != debit {
 		msg := fmt.Sprintf(msgFileCalculatedControlEquality, debit, f.Control.TotalDebitEntryDollarAmountInFile)
-		return &FileError{FieldName: "TotalDebitEntryDollarAmountInFile", Value: f.Control.TotalDebitEntryDollarAmountInFileField(), Msg: msg}
+		return &FileError{FieldName: "TotalDebitEntryDollarAmountInFile", Value: f.Control.TotalDebitEntryDollarAmountInFileField(), Msg: msg, Code: ErrFileOutOfBalance.Code}
 	}
 	if f.Control.TotalCreditEntryDollarAmountInFile != credit {
 		msg := fmt.Sprintf(msgFileCalculatedControlEquality, credit, f.Control.TotalCreditEntryDollarAmountInFile)
-		return &FileError{FieldName: "TotalCreditEntryDollarAmountInFile", Value: f.Control.TotalCreditEntryDollarAmountInFileField(), Msg: msg}
+		return &FileError{FieldName: "TotalCreditEntryDollarAmountInFile", Value: f.Control.TotalCreditEntryDollarAmountInFileField(), Msg: msg, Code: ErrFileOutOfBalance.Code}
 	}
 	return nil
 }
@@ -230,7 +397,7 @@ func (f *File) isEntryHash() error {
 	hashField := f.calculateEntryHash()
 	if hashField != f.Control.EntryHashField() {
 		msg := fmt.Sprintf(msgFileCalculatedControlEquality, hashField, f.Control.EntryHashField())
-		return &FileError{FieldName: "EntryHash", Value: f.Control.EntryHashField(), Msg: msg}
+		return &FileError{FieldName: "EntryHash", Value: f.Control.EntryHashField(), Msg: msg, Code: ErrFileOutOfBalance.Code}
 	}
 	return nil
 }
@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -41,6 +42,8 @@ type FileControl struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // Parse takes the input record string and parses the FileControl values
@@ -87,6 +90,26 @@ func (fc *FileControl) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the FileControl record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (fc *FileControl) AppendTo(b []byte) []byte {
+	b = append(b, fc.recordType...)
+	b = fc.appendNumericField(b, fc.BatchCount, 6)
+	b = fc.appendNumericField(b, fc.BlockCount, 6)
+	b = fc.appendNumericField(b, fc.EntryAddendaCount, 8)
+	b = fc.appendNumericField(b, fc.EntryHash, 10)
+	b = fc.appendNumericField(b, fc.TotalDebitEntryDollarAmountInFile, 12)
+	b = fc.appendNumericField(b, fc.TotalCreditEntryDollarAmountInFile, 12)
+	b = append(b, fc.reserved...)
+	return b
+}
+
+// WriteTo writes the FileControl record to w as the 94 characters of String.
+func (fc *FileControl) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(fc.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (fc *FileControl) Validate() error {
@@ -95,7 +118,7 @@ func (fc *FileControl) Validate() error {
 	}
 	if fc.recordType != "9" {
 		msg := fmt.Sprintf(msgRecordType, 9)
-		return &FieldError{FieldName: "recordType", Value: fc.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: fc.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	return nil
 }
@@ -104,19 +127,19 @@ func (fc *FileControl) Validate() error {
 // invalid the ACH transfer will be returned.
 func (fc *FileControl) fieldInclusion() error {
 	if fc.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: fc.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: fc.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fc.BatchCount == 0 {
-		return &FieldError{FieldName: "BatchCount", Value: fc.BatchCountField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "BatchCount", Value: fc.BatchCountField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fc.BlockCount == 0 {
-		return &FieldError{FieldName: "BlockCount", Value: fc.BlockCountField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "BlockCount", Value: fc.BlockCountField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fc.EntryAddendaCount == 0 {
-		return &FieldError{FieldName: "EntryAddendaCount", Value: fc.EntryAddendaCountField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "EntryAddendaCount", Value: fc.EntryAddendaCountField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fc.EntryHash == 0 {
-		return &FieldError{FieldName: "EntryAddendaCount", Value: fc.EntryAddendaCountField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "EntryAddendaCount", Value: fc.EntryAddendaCountField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
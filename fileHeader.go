@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 )
@@ -14,7 +15,7 @@ import (
 var (
 	msgRecordType     = "received expecting %d"
 	msgRecordSize     = "is not 094"
-	msgBlockingFactor = "is not 10"
+	msgBlockingFactor = "is not from 01 to 99"
 	msgFormatCode     = "is not 1"
 )
 
@@ -69,7 +70,7 @@ type FileHeader struct {
 	// (a block is 940 characters). For all files moving between a DFI and an ACH
 	// Operator (either way), the value "10" must be used. If the number of records
 	// within the file is not a multiple of ten, the remainder of the block must
-	// be nine-filled.
+	// be nine-filled. A Writer with another BlockingFactor writes its own.
 	blockingFactor string
 
 	// FormatCode a code to allow for future format variations. As
@@ -90,6 +91,8 @@ type FileHeader struct {
 	validator
 	// converters is composed for ACH to GoLang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewFileHeader returns a new FileHeader with default values for none exported fields
@@ -124,8 +127,8 @@ func (fh *FileHeader) Parse(record string) {
 	fh.FileIDModifier = record[33:34]
 	// 35-37 always "094"
 	fh.recordSize = "094"
-	//38-39 always "10"
-	fh.blockingFactor = "10"
+	//38-39 "10" for NACHA files, or the BlockingFactor of the Writer
+	fh.blockingFactor = record[37:39]
 	//40 always "1"
 	fh.formatCode = "1"
 	//41-63 The name of the ODFI. example "SILICON VALLEY BANK    "
@@ -156,6 +159,33 @@ func (fh *FileHeader) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the FileHeader record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (fh *FileHeader) AppendTo(b []byte) []byte {
+	b = append(b, fh.recordType...)
+	b = append(b, fh.priorityCode...)
+	b = append(b, ' ')
+	b = fh.appendStringField(b, fh.ImmediateDestination, 9)
+	b = append(b, ' ')
+	b = fh.appendStringField(b, fh.ImmediateOrigin, 9)
+	b = fh.appendSimpleDate(b, fh.FileCreationDate)
+	b = fh.appendSimpleTime(b, fh.FileCreationTime)
+	b = append(b, fh.FileIDModifier...)
+	b = append(b, fh.recordSize...)
+	b = append(b, fh.blockingFactor...)
+	b = append(b, fh.formatCode...)
+	b = fh.appendAlphaField(b, fh.ImmediateDestinationName, 23)
+	b = fh.appendAlphaField(b, fh.ImmediateOriginName, 23)
+	b = fh.appendAlphaField(b, fh.ReferenceCode, 8)
+	return b
+}
+
+// WriteTo writes the FileHeader record to w as the 94 characters of String.
+func (fh *FileHeader) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(fh.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops the parsing.
 func (fh *FileHeader) Validate() error {
@@ -164,38 +194,38 @@ func (fh *FileHeader) Validate() error {
 	}
 	if fh.recordType != "1" {
 		msg := fmt.Sprintf(msgRecordType, 1)
-		return &FieldError{FieldName: "recordType", Value: fh.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: fh.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := fh.isUpperAlphanumeric(fh.FileIDModifier); err != nil {
-		return &FieldError{FieldName: "FileIDModifier", Value: fh.FileIDModifier, Msg: err.Error()}
+		return &FieldError{FieldName: "FileIDModifier", Value: fh.FileIDModifier, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if len(fh.FileIDModifier) != 1 {
 		msg := fmt.Sprintf(msgValidFieldLength, 1)
-		return &FieldError{FieldName: "FileIDModifier", Value: fh.FileIDModifier, Msg: msg}
+		return &FieldError{FieldName: "FileIDModifier", Value: fh.FileIDModifier, Msg: msg, Code: ErrFieldLength.Code}
 	}
 	if fh.recordSize != "094" {
-		return &FieldError{FieldName: "recordSize", Value: fh.recordSize, Msg: msgRecordSize}
+		return &FieldError{FieldName: "recordSize", Value: fh.recordSize, Msg: msgRecordSize, Code: ErrFileHeaderFormat.Code}
 	}
-	if fh.blockingFactor != "10" {
-		return &FieldError{FieldName: "blockingFactor", Value: fh.blockingFactor, Msg: msgBlockingFactor}
+	if !isBlockingFactor(fh.blockingFactor) {
+		return &FieldError{FieldName: "blockingFactor", Value: fh.blockingFactor, Msg: msgBlockingFactor, Code: ErrFileHeaderFormat.Code}
 	}
 	if fh.formatCode != "1" {
-		return &FieldError{FieldName: "formatCode", Value: fh.formatCode, Msg: msgFormatCode}
+		return &FieldError{FieldName: "formatCode", Value: fh.formatCode, Msg: msgFormatCode, Code: ErrFileHeaderFormat.Code}
 	}
 	if err := fh.isAlphanumeric(fh.ImmediateDestinationName); err != nil {
-		return &FieldError{FieldName: "ImmediateDestinationName", Value: fh.ImmediateDestinationName, Msg: err.Error()}
+		return &FieldError{FieldName: "ImmediateDestinationName", Value: fh.ImmediateDestinationName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if fh.ImmediateOrigin == "000000000" {
-		return &FieldError{FieldName: "ImmediateOrigin", Value: fh.ImmediateOrigin, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ImmediateOrigin", Value: fh.ImmediateOrigin, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.ImmediateDestination == "000000000" {
-		return &FieldError{FieldName: "ImmediateDestination", Value: fh.ImmediateDestination, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ImmediateDestination", Value: fh.ImmediateDestination, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if err := fh.isAlphanumeric(fh.ImmediateOriginName); err != nil {
-		return &FieldError{FieldName: "ImmediateOriginName", Value: fh.ImmediateOriginName, Msg: err.Error()}
+		return &FieldError{FieldName: "ImmediateOriginName", Value: fh.ImmediateOriginName, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := fh.isAlphanumeric(fh.ReferenceCode); err != nil {
-		return &FieldError{FieldName: "ReferenceCode", Value: fh.ReferenceCode, Msg: err.Error()}
+		return &FieldError{FieldName: "ReferenceCode", Value: fh.ReferenceCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	// todo: handle test cases for before date
@@ -207,32 +237,48 @@ func (fh *FileHeader) Validate() error {
 	return nil
 }
 
+// ValidateWith performs Validate and the optional checks of opts
+func (fh *FileHeader) ValidateWith(opts *ValidateOpts) error {
+	if err := fh.Validate(); err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	if opts.RoutingDirectory != nil {
+		if err := opts.RoutingDirectory.validateRouting("ImmediateDestination", fh.ImmediateDestination); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the ACH transfer will be returned.
 func (fh *FileHeader) fieldInclusion() error {
 	if fh.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: fh.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: fh.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.ImmediateDestination == "" {
-		return &FieldError{FieldName: "ImmediateDestination", Value: fh.ImmediateDestinationField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ImmediateDestination", Value: fh.ImmediateDestinationField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.ImmediateOrigin == "" {
-		return &FieldError{FieldName: "ImmediateOrigin", Value: fh.ImmediateOriginField(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "ImmediateOrigin", Value: fh.ImmediateOriginField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.FileCreationDate.IsZero() {
-		return &FieldError{FieldName: "FileCreationDate", Value: fh.FileCreationDate.String(), Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "FileCreationDate", Value: fh.FileCreationDate.String(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.FileIDModifier == "" {
-		return &FieldError{FieldName: "FileIDModifier", Value: fh.FileIDModifier, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "FileIDModifier", Value: fh.FileIDModifier, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.recordSize == "" {
-		return &FieldError{FieldName: "recordSize", Value: fh.recordSize, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordSize", Value: fh.recordSize, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.blockingFactor == "" {
-		return &FieldError{FieldName: "blockingFactor", Value: fh.blockingFactor, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "blockingFactor", Value: fh.blockingFactor, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if fh.formatCode == "" {
-		return &FieldError{FieldName: "formatCode", Value: fh.formatCode, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "formatCode", Value: fh.formatCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -271,3 +317,8 @@ func (fh *FileHeader) ImmediateOriginNameField() string {
 func (fh *FileHeader) ReferenceCodeField() string {
 	return fh.alphaField(fh.ReferenceCode, 8)
 }
+
+// isBlockingFactor returns true if s is a two digit blocking factor from 01 to 99
+func isBlockingFactor(s string) bool {
+	return len(s) == 2 && s != "00" && s[0] >= '0' && s[0] <= '9' && s[1] >= '0' && s[1] <= '9'
+}
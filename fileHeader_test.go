@@ -227,25 +227,33 @@ func BenchmarkValidateRecordSize(b *testing.B) {
 	}
 }
 
-// testBlockingFactor validates blocking factor  is "10"
+// testBlockingFactor validates blocking factor is from 01 to 99
 func testBlockingFactor(t testing.TB) {
 	fh := mockFileHeader()
-	fh.blockingFactor = "99"
-	if err := fh.Validate(); err != nil {
-		if e, ok := err.(*FieldError); ok {
-			if e.FieldName != "blockingFactor" {
+	for _, factor := range []string{"00", "1 ", "A0"} {
+		fh.blockingFactor = factor
+		if err := fh.Validate(); err != nil {
+			if e, ok := err.(*FieldError); !ok || e.FieldName != "blockingFactor" {
 				t.Errorf("%T: %s", err, err)
 			}
+		} else {
+			t.Errorf("%q: expected error", factor)
+		}
+	}
+	for _, factor := range []string{"05", "10", "99"} {
+		fh.blockingFactor = factor
+		if err := fh.Validate(); err != nil {
+			t.Errorf("%q: %T: %s", factor, err, err)
 		}
 	}
 }
 
-// TestBlockingFactor tests validating blocking factor  is "10"
+// TestBlockingFactor tests validating blocking factor is from 01 to 99
 func TestBlockingFactor(t *testing.T) {
 	testBlockingFactor(t)
 }
 
-// BenchmarkBlockingFactor benchmarks validating blocking factor  is "10"
+// BenchmarkBlockingFactor benchmarks validating blocking factor is from 01 to 99
 func BenchmarkBlockingFactor(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
@@ -5,6 +5,8 @@
 package ach
 
 import (
+	"context"
+	"strconv"
 	"testing"
 )
 
@@ -363,3 +365,103 @@ func BenchmarkFileReturnEntries(b *testing.B) {
 		testFileReturnEntries(b)
 	}
 }
+
+// mockFileBatches creates an ACH file of PPD batches
+func mockFileBatches(t testing.TB, batches int) *File {
+	file := NewFile().SetHeader(mockFileHeader())
+	for i := 0; i < batches; i++ {
+		file.AddBatch(mockBatchPPD())
+	}
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return file
+}
+
+// testFileValidateWithContext validates the batches of a file validated by several workers
+func testFileValidateWithContext(t testing.TB) {
+	file := mockFileBatches(t, 50)
+	for _, workers := range []int{0, 1, 8, 100} {
+		if err := file.ValidateWithContext(context.Background(), &ValidateOpts{Workers: workers}); err != nil {
+			t.Errorf("workers %d: %T: %s", workers, err, err)
+		}
+	}
+	if err := file.ValidateWithContext(context.Background(), nil); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// the first batch in error is returned whatever the order the workers finish in
+	file.Batches[40].GetHeader().CompanyIdentification = "BATCH40"
+	file.Batches[7].GetHeader().CompanyIdentification = "BATCH7"
+	want := file.Batches[7].Validate()
+	for i := 0; i < 20; i++ {
+		err := file.ValidateWithContext(context.Background(), &ValidateOpts{Workers: 8})
+		if err == nil || err.Error() != want.Error() {
+			t.Fatalf("got %v want %v", err, want)
+		}
+	}
+
+	// a file error is returned before the batches are validated
+	file.Control.BatchCount = 1
+	if err := file.ValidateWithContext(context.Background(), &ValidateOpts{Workers: 8}); err == nil {
+		t.Error("expected a FileError")
+	} else if _, ok := err.(*FileError); !ok {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestFileValidateWithContext tests the batches of a file validated by several workers
+func TestFileValidateWithContext(t *testing.T) {
+	testFileValidateWithContext(t)
+}
+
+// BenchmarkFileValidateWithContext benchmarks the batches of a file validated by several workers
+func BenchmarkFileValidateWithContext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testFileValidateWithContext(b)
+	}
+}
+
+// testFileValidateWithContextCancel validates a file is not validated after its context is done
+func testFileValidateWithContextCancel(t testing.TB) {
+	file := mockFileBatches(t, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := file.ValidateWithContext(ctx, &ValidateOpts{Workers: 4}); err != context.Canceled {
+		t.Errorf("%T: %s", err, err)
+	}
+	// a file without batches has nothing to cancel
+	if err := NewFile().ValidateWithContext(ctx, nil); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestFileValidateWithContextCancel tests a file is not validated after its context is done
+func TestFileValidateWithContextCancel(t *testing.T) {
+	testFileValidateWithContextCancel(t)
+}
+
+// BenchmarkFileValidateWithContextCancel benchmarks a file is not validated after its context is done
+func BenchmarkFileValidateWithContextCancel(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testFileValidateWithContextCancel(b)
+	}
+}
+
+// BenchmarkFileValidateWorkers benchmarks validating a file of many batches by 1 and 8 workers
+func BenchmarkFileValidateWorkers(b *testing.B) {
+	file := mockFileBatches(b, 2000)
+	for _, workers := range []int{1, 8} {
+		opts := &ValidateOpts{Workers: workers}
+		b.Run(strconv.Itoa(workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := file.ValidateWithContext(context.Background(), opts); err != nil {
+					b.Fatalf("%T: %s", err, err)
+				}
+			}
+		})
+	}
+}
@@ -36,6 +36,8 @@ type IATBatch struct {
 
 	// category defines if the entry is a Forward, Return, or NOC
 	category string
+	// screener screens the parties of each entry on Validate when set
+	screener Screener
 	// Converters is composed for ACH to GoLang Converters
 	converters
 }
@@ -54,30 +56,30 @@ func (batch *IATBatch) verify() error {
 
 	// No entries in batch
 	if len(batch.Entries) <= 0 {
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries, Code: ErrBatchEntries.Code}
 	}
 	// verify field inclusion in all the records of the batch.
 	if err := batch.isFieldInclusion(); err != nil {
 		// convert the field error in to a batch error for a consistent api
 		if e, ok := err.(*FieldError); ok {
-			return &BatchError{BatchNumber: batchNumber, FieldName: e.FieldName, Msg: e.Msg}
+			return &BatchError{BatchNumber: batchNumber, FieldName: e.FieldName, Msg: e.Msg, Code: e.Code}
 		}
-		return &BatchError{BatchNumber: batchNumber, FieldName: "FieldError", Msg: err.Error()}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "FieldError", Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// validate batch header and control codes are the same
 	if batch.Header.ServiceClassCode != batch.Control.ServiceClassCode {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.ServiceClassCode, batch.Control.ServiceClassCode)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "ServiceClassCode", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "ServiceClassCode", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	// Control ODFIIdentification must be the same as batch header
 	if batch.Header.ODFIIdentification != batch.Control.ODFIIdentification {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.ODFIIdentification, batch.Control.ODFIIdentification)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "ODFIIdentification", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "ODFIIdentification", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	// batch number header and control must match
 	if batch.Header.BatchNumber != batch.Control.BatchNumber {
 		msg := fmt.Sprintf(msgBatchHeaderControlEquality, batch.Header.BatchNumber, batch.Control.BatchNumber)
-		return &BatchError{BatchNumber: batchNumber, FieldName: "BatchNumber", Msg: msg}
+		return &BatchError{BatchNumber: batchNumber, FieldName: "BatchNumber", Msg: msg, Code: ErrBatchHeaderControl.Code}
 	}
 	if err := batch.isBatchEntryCount(); err != nil {
 		return err
@@ -111,7 +113,7 @@ func (batch *IATBatch) build() error {
 		return err
 	}
 	if len(batch.Entries) <= 0 {
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "entries", Msg: msgBatchEntries, Code: ErrBatchEntries.Code}
 	}
 	// Create record sequence numbers
 	entryCount := 0
@@ -211,6 +213,30 @@ func (batch *IATBatch) AddEntry(entry *IATEntryDetail) {
 	batch.Entries = append(batch.Entries, entry)
 }
 
+// SetScreener sets the Screener of the parties of each entry. Validate screens every entry
+// and sets its OFAC screening indicators.
+func (batch *IATBatch) SetScreener(s Screener) {
+	batch.screener = s
+}
+
+// Screen screens the originator, receiver and correspondent parties of each entry with the
+// Screener of the batch, sets the OFAC screening indicators of the entries and returns the
+// potential matches. Entries are unchanged when the batch has no Screener.
+func (batch *IATBatch) Screen() ([]ScreeningMatch, error) {
+	if batch.screener == nil {
+		return nil, nil
+	}
+	var matches []ScreeningMatch
+	for _, entry := range batch.Entries {
+		found, err := screenEntry(batch.screener, entry)
+		if err != nil {
+			return matches, &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Screener", Msg: err.Error(), Code: ErrorCodeOf(err)}
+		}
+		matches = append(matches, found...)
+	}
+	return matches, nil
+}
+
 // Category returns IATBatch Category
 func (batch *IATBatch) Category() string {
 	return batch.category
@@ -271,7 +297,7 @@ func (batch *IATBatch) isBatchEntryCount() error {
 	}
 	if entryCount != batch.Control.EntryAddendaCount {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, entryCount, batch.Control.EntryAddendaCount)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryAddendaCount", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryAddendaCount", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 	return nil
 }
@@ -283,12 +309,12 @@ func (batch *IATBatch) isBatchAmount() error {
 	credit, debit := batch.calculateBatchAmounts()
 	if debit != batch.Control.TotalDebitEntryDollarAmount {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, debit, batch.Control.TotalDebitEntryDollarAmount)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalDebitEntryDollarAmount", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalDebitEntryDollarAmount", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 
 	if credit != batch.Control.TotalCreditEntryDollarAmount {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, credit, batch.Control.TotalCreditEntryDollarAmount)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalCreditEntryDollarAmount", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TotalCreditEntryDollarAmount", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 	return nil
 }
@@ -312,7 +338,7 @@ func (batch *IATBatch) isSequenceAscending() error {
 	for _, entry := range batch.Entries {
 		if entry.TraceNumber <= lastSeq {
 			msg := fmt.Sprintf(msgBatchAscending, entry.TraceNumber, lastSeq)
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAscending.Code}
 		}
 		lastSeq = entry.TraceNumber
 	}
@@ -324,7 +350,7 @@ func (batch *IATBatch) isEntryHash() error {
 	hashField := batch.calculateEntryHash()
 	if hashField != batch.Control.EntryHashField() {
 		msg := fmt.Sprintf(msgBatchCalculatedControlEquality, hashField, batch.Control.EntryHashField())
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryHash", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "EntryHash", Msg: msg, Code: ErrBatchOutOfBalance.Code}
 	}
 	return nil
 }
@@ -348,7 +374,7 @@ func (batch *IATBatch) isTraceNumberODFI() error {
 	for _, entry := range batch.Entries {
 		if batch.Header.ODFIIdentificationField() != entry.TraceNumberField()[:8] {
 			msg := fmt.Sprintf(msgBatchTraceNumberNotODFI, batch.Header.ODFIIdentificationField(), entry.TraceNumberField()[:8])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ODFIIdentificationField", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "ODFIIdentificationField", Msg: msg, Code: ErrBatchTraceNumberNotODFI.Code}
 		}
 	}
 
@@ -360,38 +386,38 @@ func (batch *IATBatch) isAddendaSequence() error {
 	for _, entry := range batch.Entries {
 		// addenda without indicator flag of 1
 		if entry.AddendaRecordIndicator != 1 {
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "AddendaRecordIndicator", Msg: msgIATBatchAddendaIndicator}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "AddendaRecordIndicator", Msg: msgIATBatchAddendaIndicator, Code: ErrBatchAddendaIndicator.Code}
 		}
 		// Verify Addenda* entry detail sequence numbers are valid
 		entryTN := entry.TraceNumberField()[8:]
 
 		if entry.Addenda10.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda10.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 		if entry.Addenda11.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda11.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 		if entry.Addenda12.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda12.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 		if entry.Addenda13.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda13.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 		if entry.Addenda14.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda14.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 		if entry.Addenda15.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda15.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 		if entry.Addenda16.EntryDetailSequenceNumberField() != entryTN {
 			msg := fmt.Sprintf(msgBatchAddendaTraceNumber, entry.Addenda16.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 		}
 
 		// check if sequence is ascending for addendumer - Addenda17 and Addenda18
@@ -403,26 +429,26 @@ func (batch *IATBatch) isAddendaSequence() error {
 
 				if a.SequenceNumber < lastAddenda17Seq {
 					msg := fmt.Sprintf(msgBatchAscending, a.SequenceNumber, lastAddenda17Seq)
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "SequenceNumber", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "SequenceNumber", Msg: msg, Code: ErrBatchAscending.Code}
 				}
 				lastAddenda17Seq = a.SequenceNumber
 				// check that we are in the correct Entry Detail
 				if !(a.EntryDetailSequenceNumberField() == entry.TraceNumberField()[8:]) {
 					msg := fmt.Sprintf(msgBatchAddendaTraceNumber, a.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 				}
 			}
 			if a, ok := IATAddenda.(*Addenda18); ok {
 
 				if a.SequenceNumber < lastAddenda18Seq {
 					msg := fmt.Sprintf(msgBatchAscending, a.SequenceNumber, lastAddenda18Seq)
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "SequenceNumber", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "SequenceNumber", Msg: msg, Code: ErrBatchAscending.Code}
 				}
 				lastAddenda18Seq = a.SequenceNumber
 				// check that we are in the correct Entry Detail
 				if !(a.EntryDetailSequenceNumberField() == entry.TraceNumberField()[8:]) {
 					msg := fmt.Sprintf(msgBatchAddendaTraceNumber, a.EntryDetailSequenceNumberField(), entry.TraceNumberField()[8:])
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "TraceNumber", Msg: msg, Code: ErrBatchAddendaTraceNumber.Code}
 				}
 			}
 		}
@@ -440,7 +466,7 @@ func (batch *IATBatch) isCategory() error {
 				continue
 			}*/
 			if batch.Entries[i].Category != category {
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Category", Msg: msgBatchForwardReturn}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Category", Msg: msgBatchForwardReturn, Code: ErrBatchForwardReturn.Code}
 			}
 		}
 	}
@@ -450,31 +476,31 @@ func (batch *IATBatch) isCategory() error {
 func (batch *IATBatch) addendaFieldInclusion(entry *IATEntryDetail) error {
 	if entry.Addenda10 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda10", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda10", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	if entry.Addenda11 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda11", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda11", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	if entry.Addenda12 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda12", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda12", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	if entry.Addenda13 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda13", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda13", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	if entry.Addenda14 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda14", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda14", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	if entry.Addenda15 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda15", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda15", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	if entry.Addenda16 == nil {
 		msg := fmt.Sprint(msgIATBatchAddendaRequired)
-		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda16", Msg: msg}
+		return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addenda16", Msg: msg, Code: ErrFieldRequired.Code}
 	}
 	return nil
 }
@@ -502,7 +528,7 @@ func (batch *IATBatch) Validate() error {
 
 		// Addendum cannot be greater than 7, There can be a maximum of 2 Addenda17 and a maximum of 5 Addenda18
 		if len(entry.Addendum) > 7 {
-			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchIATAddendum}
+			return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchIATAddendum, Code: ErrBatchAddendaCount.Code}
 		}
 
 		// Counter for addendumer for 17, 18,, and 99
@@ -519,26 +545,29 @@ func (batch *IATBatch) Validate() error {
 				addenda17Count = addenda17Count + 1
 				if addenda17Count > 2 {
 					msg := fmt.Sprintf(msgBatchIATAddendumCount, addenda17Count, "17")
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaCount.Code}
 				}
 			case "18":
 				addenda18Count = addenda18Count + 1
 				if addenda18Count > 5 {
 					msg := fmt.Sprintf(msgBatchIATAddendumCount, addenda18Count, "18")
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaCount.Code}
 				}
 			case "99":
 				addenda99Count = addenda99Count + 1
 				if addenda99Count > 1 {
 					msg := fmt.Sprintf(msgBatchIATAddendumCount, addenda99Count, "99")
-					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg}
+					return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msg, Code: ErrBatchAddendaCount.Code}
 				}
 			default:
-				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchIATInvalidAddendumer}
+				return &BatchError{BatchNumber: batch.Header.BatchNumber, FieldName: "Addendum", Msg: msgBatchIATInvalidAddendumer, Code: ErrBatchAddendaType.Code}
 			}
 		}
 	}
 	// Add type specific validation.
 	// ...
+	if _, err := batch.Screen(); err != nil {
+		return err
+	}
 	return nil
 }
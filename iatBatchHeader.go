@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -127,7 +128,10 @@ type IATBatchHeader struct {
 	// EffectiveEntryDate the date on which the entries are to settle format YYMMDD
 	EffectiveEntryDate time.Time `json:"effectiveEntryDate,omitempty"`
 
-	// SettlementDate Leave blank, this field is inserted by the ACH operator
+	// SettlementDate is the date the ACH Operator settles the entries of the batch. It is
+	// inserted by the ACH Operator as a Julian day and left blank by Originators.
+	SettlementDate time.Time `json:"settlementDate,omitempty"`
+	// settlementDate is the Julian day of the SettlementDate as read
 	settlementDate string
 	// OriginatorStatusCode refers to the ODFI initiating the Entry.
 	// 0 ADV File prepared by an ACH Operator.
@@ -154,6 +158,8 @@ type IATBatchHeader struct {
 
 	// converters is composed for ACH to golang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewIATBatchHeader returns a new BatchHeader with default values for non exported fields
@@ -208,8 +214,11 @@ func (iatBh *IATBatchHeader) Parse(record string) {
 	// 70-75 Date transactions are to be posted to the receivers’ account.
 	// You almost always want the transaction to post as soon as possible, so put tomorrow's date in YYMMDD format
 	iatBh.EffectiveEntryDate = iatBh.parseSimpleDate(record[69:75])
-	// 76-79 Always blank (just fill with spaces)
+	// 76-78 Julian day of the settlement date inserted by the ACH Operator, blank for Originators
 	iatBh.settlementDate = "   "
+	if isDigits(record[75:78]) {
+		iatBh.settlementDate = record[75:78]
+	}
 	// 79-79 Always 1
 	iatBh.OriginatorStatusCode = iatBh.parseNumField(record[78:79])
 	// 80-87 Your ODFI's routing number without the last digit. The last digit is simply a
@@ -237,13 +246,50 @@ func (iatBh *IATBatchHeader) String() string {
 	buf.WriteString(iatBh.ISOOriginatingCurrencyCodeField())
 	buf.WriteString(iatBh.ISODestinationCurrencyCodeField())
 	buf.WriteString(iatBh.EffectiveEntryDateField())
-	buf.WriteString(iatBh.settlementDateField())
+	buf.WriteString(iatBh.SettlementDateField())
 	buf.WriteString(fmt.Sprintf("%v", iatBh.OriginatorStatusCode))
 	buf.WriteString(iatBh.ODFIIdentificationField())
 	buf.WriteString(iatBh.BatchNumberField())
 	return buf.String()
 }
 
+// AppendTo appends the IATBatchHeader record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (iatBh *IATBatchHeader) AppendTo(b []byte) []byte {
+	b = append(b, iatBh.recordType...)
+	b = strconv.AppendInt(b, int64(iatBh.ServiceClassCode), 10)
+	b = iatBh.appendAlphaField(b, iatBh.IATIndicator, 16)
+	b = iatBh.appendAlphaField(b, iatBh.ForeignExchangeIndicator, 2)
+	b = iatBh.appendNumericField(b, iatBh.ForeignExchangeReferenceIndicator, 1)
+	if iatBh.ForeignExchangeReferenceIndicator == 3 {
+		b = append(b, "               "...)
+	} else {
+		b = iatBh.appendAlphaField(b, iatBh.ForeignExchangeReference, 15)
+	}
+	b = iatBh.appendAlphaField(b, iatBh.ISODestinationCountryCode, 2)
+	b = iatBh.appendAlphaField(b, iatBh.OriginatorIdentification, 10)
+	b = append(b, iatBh.StandardEntryClassCode...)
+	b = iatBh.appendAlphaField(b, iatBh.CompanyEntryDescription, 10)
+	b = iatBh.appendAlphaField(b, iatBh.ISOOriginatingCurrencyCode, 3)
+	b = iatBh.appendAlphaField(b, iatBh.ISODestinationCurrencyCode, 3)
+	b = iatBh.appendSimpleDate(b, iatBh.EffectiveEntryDate)
+	if !iatBh.SettlementDate.IsZero() {
+		b = iatBh.appendNumericField(b, iatBh.SettlementDate.YearDay(), 3)
+	} else {
+		b = iatBh.appendAlphaField(b, iatBh.settlementDate, 3)
+	}
+	b = strconv.AppendInt(b, int64(iatBh.OriginatorStatusCode), 10)
+	b = iatBh.appendStringField(b, iatBh.ODFIIdentification, 8)
+	b = iatBh.appendNumericField(b, iatBh.BatchNumber, 7)
+	return b
+}
+
+// WriteTo writes the IATBatchHeader record to w as the 94 characters of String.
+func (iatBh *IATBatchHeader) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(iatBh.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (iatBh *IATBatchHeader) Validate() error {
@@ -252,103 +298,132 @@ func (iatBh *IATBatchHeader) Validate() error {
 	}
 	if iatBh.recordType != "5" {
 		msg := fmt.Sprintf(msgRecordType, 5)
-		return &FieldError{FieldName: "recordType", Value: iatBh.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: iatBh.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := iatBh.isServiceClass(iatBh.ServiceClassCode); err != nil {
 		return &FieldError{FieldName: "ServiceClassCode",
-			Value: strconv.Itoa(iatBh.ServiceClassCode), Msg: err.Error()}
+			Value: strconv.Itoa(iatBh.ServiceClassCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isForeignExchangeIndicator(iatBh.ForeignExchangeIndicator); err != nil {
 		return &FieldError{FieldName: "ForeignExchangeIndicator",
-			Value: iatBh.ForeignExchangeIndicator, Msg: err.Error()}
+			Value: iatBh.ForeignExchangeIndicator, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isForeignExchangeReferenceIndicator(iatBh.ForeignExchangeReferenceIndicator); err != nil {
 		return &FieldError{FieldName: "ForeignExchangeReferenceIndicator",
-			Value: strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator), Msg: err.Error()}
+			Value: strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isAlphanumeric(iatBh.ISODestinationCountryCode); err != nil {
 		return &FieldError{FieldName: "ISODestinationCountryCode",
-			Value: iatBh.ISODestinationCountryCode, Msg: err.Error()}
+			Value: iatBh.ISODestinationCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := iatBh.isCountryCode(iatBh.ISODestinationCountryCode); err != nil {
+		return &FieldError{FieldName: "ISODestinationCountryCode",
+			Value: iatBh.ISODestinationCountryCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isAlphanumeric(iatBh.OriginatorIdentification); err != nil {
 		return &FieldError{FieldName: "OriginatorIdentification",
-			Value: iatBh.OriginatorIdentification, Msg: err.Error()}
+			Value: iatBh.OriginatorIdentification, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isSECCode(iatBh.StandardEntryClassCode); err != nil {
 		return &FieldError{FieldName: "StandardEntryClassCode",
-			Value: iatBh.StandardEntryClassCode, Msg: err.Error()}
+			Value: iatBh.StandardEntryClassCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isAlphanumeric(iatBh.CompanyEntryDescription); err != nil {
 		return &FieldError{FieldName: "CompanyEntryDescription",
-			Value: iatBh.CompanyEntryDescription, Msg: err.Error()}
+			Value: iatBh.CompanyEntryDescription, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isAlphanumeric(iatBh.ISOOriginatingCurrencyCode); err != nil {
 		return &FieldError{FieldName: "ISOOriginatingCurrencyCode",
-			Value: iatBh.ISOOriginatingCurrencyCode, Msg: err.Error()}
+			Value: iatBh.ISOOriginatingCurrencyCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := iatBh.isCurrencyCode(iatBh.ISOOriginatingCurrencyCode); err != nil {
+		return &FieldError{FieldName: "ISOOriginatingCurrencyCode",
+			Value: iatBh.ISOOriginatingCurrencyCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 
 	if err := iatBh.isAlphanumeric(iatBh.ISODestinationCurrencyCode); err != nil {
 		return &FieldError{FieldName: "ISODestinationCurrencyCode",
-			Value: iatBh.ISODestinationCurrencyCode, Msg: err.Error()}
+			Value: iatBh.ISODestinationCurrencyCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
+	}
+	if err := iatBh.isCurrencyCode(iatBh.ISODestinationCurrencyCode); err != nil {
+		return &FieldError{FieldName: "ISODestinationCurrencyCode",
+			Value: iatBh.ISODestinationCurrencyCode, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := iatBh.isOriginatorStatusCode(iatBh.OriginatorStatusCode); err != nil {
 		return &FieldError{FieldName: "OriginatorStatusCode",
-			Value: strconv.Itoa(iatBh.OriginatorStatusCode), Msg: err.Error()}
+			Value: strconv.Itoa(iatBh.OriginatorStatusCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	return nil
 }
 
+// ValidateWith performs Validate and the optional checks of opts. An EffectiveEntryDate
+// that is not a banking day is moved forward when opts.RollForwardEffectiveDate is set.
+func (iatBh *IATBatchHeader) ValidateWith(opts *ValidateOpts) error {
+	if err := iatBh.Validate(); err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	date, err := opts.validateEffectiveEntryDate(iatBh.EffectiveEntryDate)
+	if err != nil {
+		return err
+	}
+	iatBh.EffectiveEntryDate = date
+	return nil
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the ACH transfer will be returned.
 func (iatBh *IATBatchHeader) fieldInclusion() error {
 	if iatBh.recordType == "" {
-		return &FieldError{FieldName: "recordType", Value: iatBh.recordType, Msg: msgFieldInclusion}
+		return &FieldError{FieldName: "recordType", Value: iatBh.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.ServiceClassCode == 0 {
 		return &FieldError{FieldName: "ServiceClassCode",
-			Value: strconv.Itoa(iatBh.ServiceClassCode), Msg: msgFieldInclusion}
+			Value: strconv.Itoa(iatBh.ServiceClassCode), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.ForeignExchangeIndicator == "" {
 		return &FieldError{FieldName: "ForeignExchangeIndicator",
-			Value: iatBh.ForeignExchangeIndicator, Msg: msgFieldInclusion}
+			Value: iatBh.ForeignExchangeIndicator, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.ForeignExchangeReferenceIndicator == 0 {
 		return &FieldError{FieldName: "ForeignExchangeReferenceIndicator",
-			Value: strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator), Msg: msgFieldRequired}
+			Value: strconv.Itoa(iatBh.ForeignExchangeReferenceIndicator), Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}
 	// ToDo: It can be space filled based on ForeignExchangeReferenceIndicator just use a validator to handle -
 	// ToDo: Calling Field ok for validation?
 	/*	if iatBh.ForeignExchangeReference == "" {
 		return &FieldError{FieldName: "ForeignExchangeReference",
-			Value: iatBh.ForeignExchangeReference, Msg: msgFieldRequired}
+			Value: iatBh.ForeignExchangeReference, Msg: msgFieldRequired, Code: ErrFieldRequired.Code}
 	}*/
 	if iatBh.ISODestinationCountryCode == "" {
 		return &FieldError{FieldName: "ISODestinationCountryCode",
-			Value: iatBh.ISODestinationCountryCode, Msg: msgFieldInclusion}
+			Value: iatBh.ISODestinationCountryCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.OriginatorIdentification == "" {
 		return &FieldError{FieldName: "OriginatorIdentification",
-			Value: iatBh.OriginatorIdentification, Msg: msgFieldInclusion}
+			Value: iatBh.OriginatorIdentification, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.StandardEntryClassCode == "" {
 		return &FieldError{FieldName: "StandardEntryClassCode",
-			Value: iatBh.StandardEntryClassCode, Msg: msgFieldInclusion}
+			Value: iatBh.StandardEntryClassCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.CompanyEntryDescription == "" {
 		return &FieldError{FieldName: "CompanyEntryDescription",
-			Value: iatBh.CompanyEntryDescription, Msg: msgFieldInclusion}
+			Value: iatBh.CompanyEntryDescription, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.ISOOriginatingCurrencyCode == "" {
 		return &FieldError{FieldName: "ISOOriginatingCurrencyCode",
-			Value: iatBh.ISOOriginatingCurrencyCode, Msg: msgFieldInclusion}
+			Value: iatBh.ISOOriginatingCurrencyCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.ISODestinationCurrencyCode == "" {
 		return &FieldError{FieldName: "ISODestinationCurrencyCode",
-			Value: iatBh.ISODestinationCurrencyCode, Msg: msgFieldInclusion}
+			Value: iatBh.ISODestinationCurrencyCode, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if iatBh.ODFIIdentification == "" {
 		return &FieldError{FieldName: "ODFIIdentification",
-			Value: iatBh.ODFIIdentificationField(), Msg: msgFieldInclusion}
+			Value: iatBh.ODFIIdentificationField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -418,7 +493,18 @@ func (iatBh *IATBatchHeader) BatchNumberField() string {
 	return iatBh.numericField(iatBh.BatchNumber, 7)
 }
 
-// settlementDateField gets the settlementDate
-func (iatBh *IATBatchHeader) settlementDateField() string {
+// SettlementDateField gets the Julian day of the SettlementDate, or blanks if it is not set
+func (iatBh *IATBatchHeader) SettlementDateField() string {
+	if !iatBh.SettlementDate.IsZero() {
+		return iatBh.formatJulianDate(iatBh.SettlementDate)
+	}
 	return iatBh.alphaField(iatBh.settlementDate, 3)
 }
+
+// resolveSettlementDate sets the SettlementDate of the Julian day read to the date of that day
+// nearest to the FileCreationDate of the file
+func (iatBh *IATBatchHeader) resolveSettlementDate(fileCreationDate time.Time) {
+	if iatBh.SettlementDate.IsZero() {
+		iatBh.SettlementDate = iatBh.parseJulianDate(iatBh.settlementDate, fileCreationDate)
+	}
+}
@@ -7,6 +7,7 @@ package ach
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockIATBatchHeaderFF creates a IAT BatchHeader that is Fixed-Fixed
@@ -825,3 +826,69 @@ func BenchmarkIATBHODFIIdentification(b *testing.B) {
 		testIATBHODFIIdentification(b)
 	}
 }
+
+// testIATBatchHeaderISOCodes validates the ISO country and currency codes of an IAT batch header
+func testIATBatchHeaderISOCodes(t testing.TB) {
+	tests := map[string]func(bh *IATBatchHeader){
+		"ISODestinationCountryCode":  func(bh *IATBatchHeader) { bh.ISODestinationCountryCode = "UK" },
+		"ISOOriginatingCurrencyCode": func(bh *IATBatchHeader) { bh.ISOOriginatingCurrencyCode = "CAN" },
+		"ISODestinationCurrencyCode": func(bh *IATBatchHeader) { bh.ISODestinationCurrencyCode = "US$" },
+	}
+	for field, set := range tests {
+		bh := mockIATBatchHeaderFF()
+		set(bh)
+		err := bh.Validate()
+		if e, ok := err.(*FieldError); !ok || e.FieldName != field {
+			t.Errorf("%s: %T: %s", field, err, err)
+		}
+	}
+}
+
+// TestIATBatchHeaderISOCodes tests the ISO country and currency codes of an IAT batch header
+func TestIATBatchHeaderISOCodes(t *testing.T) {
+	testIATBatchHeaderISOCodes(t)
+}
+
+// BenchmarkIATBatchHeaderISOCodes benchmarks the ISO country and currency codes of an IAT batch header
+func BenchmarkIATBatchHeaderISOCodes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATBatchHeaderISOCodes(b)
+	}
+}
+
+// testIATBatchHeaderSettlementDate validates the Julian settlement date inserted by the ACH Operator
+func testIATBatchHeaderSettlementDate(t testing.TB) {
+	var line = "5220                FF3               US123456789 IATTRADEPAYMTCADUSD1806211731231380100000001"
+	r := NewReader(strings.NewReader(line))
+	r.File.Header.FileCreationDate = time.Date(2018, time.June, 20, 0, 0, 0, 0, time.UTC)
+	r.line = line
+	if err := r.parseIATBatchHeader(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	record := r.IATCurrentBatch.GetHeader()
+	if !record.SettlementDate.Equal(time.Date(2018, time.June, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("SettlementDate %v", record.SettlementDate)
+	}
+	if record.String() != line {
+		t.Errorf("got %q", record.String())
+	}
+	record.SettlementDate = time.Time{}
+	record.settlementDate = ""
+	if record.SettlementDateField() != "   " {
+		t.Errorf("Originator SettlementDate %q", record.SettlementDateField())
+	}
+}
+
+// TestIATBatchHeaderSettlementDate tests the Julian settlement date inserted by the ACH Operator
+func TestIATBatchHeaderSettlementDate(t *testing.T) {
+	testIATBatchHeaderSettlementDate(t)
+}
+
+// BenchmarkIATBatchHeaderSettlementDate benchmarks the Julian settlement date inserted by the ACH Operator
+func BenchmarkIATBatchHeaderSettlementDate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATBatchHeaderSettlementDate(b)
+	}
+}
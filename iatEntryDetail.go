@@ -6,6 +6,7 @@ package ach
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -106,6 +107,8 @@ type IATEntryDetail struct {
 	validator
 	// converters is composed for ACH to golang Converters
 	converters
+	// original is the line the record was read from by a lossless Reader
+	original
 }
 
 // NewIATEntryDetail returns a new IATEntryDetail with default values for non exported fields
@@ -170,6 +173,31 @@ func (ed *IATEntryDetail) String() string {
 	return buf.String()
 }
 
+// AppendTo appends the IATEntryDetail record to b as the 94 characters of String and returns the
+// extended buffer, without allocating when b has room for the record.
+func (ed *IATEntryDetail) AppendTo(b []byte) []byte {
+	b = append(b, ed.recordType...)
+	b = strconv.AppendInt(b, int64(ed.TransactionCode), 10)
+	b = ed.appendStringField(b, ed.RDFIIdentification, 8)
+	b = append(b, ed.CheckDigit...)
+	b = ed.appendNumericField(b, ed.AddendaRecords, 4)
+	b = ed.appendAlphaField(b, ed.reserved, 13)
+	b = ed.appendNumericField(b, ed.Amount, 10)
+	b = ed.appendAlphaField(b, ed.DFIAccountNumber, 35)
+	b = ed.appendAlphaField(b, ed.reservedTwo, 2)
+	b = ed.appendAlphaField(b, ed.OFACSreeningIndicator, 1)
+	b = ed.appendAlphaField(b, ed.SecondaryOFACSreeningIndicator, 1)
+	b = strconv.AppendInt(b, int64(ed.AddendaRecordIndicator), 10)
+	b = ed.appendNumericField(b, ed.TraceNumber, 15)
+	return b
+}
+
+// WriteTo writes the IATEntryDetail record to w as the 94 characters of String.
+func (ed *IATEntryDetail) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(ed.AppendTo(make([]byte, 0, RecordLength)))
+	return int64(n), err
+}
+
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (ed *IATEntryDetail) Validate() error {
@@ -178,24 +206,41 @@ func (ed *IATEntryDetail) Validate() error {
 	}
 	if ed.recordType != "6" {
 		msg := fmt.Sprintf(msgRecordType, 6)
-		return &FieldError{FieldName: "recordType", Value: ed.recordType, Msg: msg}
+		return &FieldError{FieldName: "recordType", Value: ed.recordType, Msg: msg, Code: ErrUnknownRecordType.Code}
 	}
 	if err := ed.isTransactionCode(ed.TransactionCode); err != nil {
-		return &FieldError{FieldName: "TransactionCode", Value: strconv.Itoa(ed.TransactionCode), Msg: err.Error()}
+		return &FieldError{FieldName: "TransactionCode", Value: strconv.Itoa(ed.TransactionCode), Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if err := ed.isAlphanumeric(ed.DFIAccountNumber); err != nil {
-		return &FieldError{FieldName: "DFIAccountNumber", Value: ed.DFIAccountNumber, Msg: err.Error()}
+		return &FieldError{FieldName: "DFIAccountNumber", Value: ed.DFIAccountNumber, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	// CheckDigit calculations
 	calculated := ed.CalculateCheckDigit(ed.RDFIIdentificationField())
 
 	edCheckDigit, err := strconv.Atoi(ed.CheckDigit)
 	if err != nil {
-		return &FieldError{FieldName: "CheckDigit", Value: ed.CheckDigit, Msg: err.Error()}
+		return &FieldError{FieldName: "CheckDigit", Value: ed.CheckDigit, Msg: err.Error(), Code: ErrorCodeOf(err)}
 	}
 	if calculated != edCheckDigit {
 		msg := fmt.Sprintf(msgValidCheckDigit, calculated)
-		return &FieldError{FieldName: "RDFIIdentification", Value: ed.CheckDigit, Msg: msg}
+		return &FieldError{FieldName: "RDFIIdentification", Value: ed.CheckDigit, Msg: msg, Code: ErrInvalidCheckDigit.Code}
+	}
+	return nil
+}
+
+// ValidateWith performs Validate and the optional checks of opts. The RDFI of an IAT
+// entry is the gateway routing number and is checked against opts.RoutingDirectory.
+func (ed *IATEntryDetail) ValidateWith(opts *ValidateOpts) error {
+	if err := ed.Validate(); err != nil {
+		return err
+	}
+	if opts == nil {
+		return nil
+	}
+	if opts.RoutingDirectory != nil {
+		if err := opts.RoutingDirectory.validateRouting("RDFIIdentification", ed.RDFIIdentification+ed.CheckDigit); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -205,31 +250,31 @@ func (ed *IATEntryDetail) Validate() error {
 func (ed *IATEntryDetail) fieldInclusion() error {
 	if ed.recordType == "" {
 		return &FieldError{FieldName: "recordType",
-			Value: ed.recordType, Msg: msgFieldInclusion}
+			Value: ed.recordType, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.TransactionCode == 0 {
 		return &FieldError{FieldName: "TransactionCode",
-			Value: strconv.Itoa(ed.TransactionCode), Msg: msgFieldInclusion}
+			Value: strconv.Itoa(ed.TransactionCode), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.RDFIIdentification == "" {
 		return &FieldError{FieldName: "RDFIIdentification",
-			Value: ed.RDFIIdentificationField(), Msg: msgFieldInclusion}
+			Value: ed.RDFIIdentificationField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.AddendaRecords == 0 {
 		return &FieldError{FieldName: "AddendaRecords",
-			Value: strconv.Itoa(ed.AddendaRecords), Msg: msgFieldInclusion}
+			Value: strconv.Itoa(ed.AddendaRecords), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.DFIAccountNumber == "" {
 		return &FieldError{FieldName: "DFIAccountNumber",
-			Value: ed.DFIAccountNumber, Msg: msgFieldInclusion}
+			Value: ed.DFIAccountNumber, Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.AddendaRecordIndicator == 0 {
 		return &FieldError{FieldName: "AddendaRecordIndicator",
-			Value: strconv.Itoa(ed.AddendaRecordIndicator), Msg: msgFieldInclusion}
+			Value: strconv.Itoa(ed.AddendaRecordIndicator), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	if ed.TraceNumber == 0 {
 		return &FieldError{FieldName: "TraceNumber",
-			Value: ed.TraceNumberField(), Msg: msgFieldInclusion}
+			Value: ed.TraceNumberField(), Msg: msgFieldInclusion, Code: ErrFieldInclusion.Code}
 	}
 	return nil
 }
@@ -0,0 +1,858 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	msgISO20022Debit         = "debit entries can not be converted to an ISO 20022 credit transfer"
+	msgISO20022Intermediary  = "%v Addenda18 found and %v intermediary agents are available in %v"
+	msgISO20022Amount        = "is not a valid ISO 20022 amount"
+	msgISO20022RoutingNumber = "must be a 9 digit routing number"
+	msgISO20022Transactions  = "no credit transfer transactions found"
+)
+
+// ISO 20022 message names, account types and date layouts
+const (
+	pain001Name = "pain.001"
+	pacs008Name = "pacs.008"
+	// isoSavingsAccount is the proprietary account type of savings accounts, all others are checking
+	isoSavingsAccount = "SVGS"
+	// isoCheckingAccount is the proprietary account type of checking accounts
+	isoCheckingAccount = "CACC"
+	// isoDate is the layout of ISODate elements
+	isoDate = "2006-01-02"
+	// isoDateTime is the layout of ISODateTime elements
+	isoDateTime = "2006-01-02T15:04:05"
+)
+
+// Pain001Document is a subset of the ISO 20022 pain.001.001.03 customer credit transfer initiation
+// message covering the elements exchanged with ACH batches.
+//
+// IAT entries are converted into a payment information block each, as the originator
+// (Addenda11 and Addenda12) and the originating DFI (Addenda13) can differ between entries.
+// Domestic batches are converted into a single payment information block.
+type Pain001Document struct {
+	XMLName    xml.Name          `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 Document"`
+	Initiation Pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+// Pain001Initiation is the CstmrCdtTrfInitn element of a pain.001 message
+type Pain001Initiation struct {
+	GroupHeader        ISOGroupHeader          `xml:"GrpHdr"`
+	PaymentInformation []ISOPaymentInformation `xml:"PmtInf"`
+}
+
+// Pacs008Document is a subset of the ISO 20022 pacs.008.001.02 FI to FI customer credit transfer
+// message covering the elements exchanged with IAT batches.
+type Pacs008Document struct {
+	XMLName  xml.Name        `xml:"urn:iso:std:iso:20022:tech:xsd:pacs.008.001.02 Document"`
+	Transfer Pacs008Transfer `xml:"FIToFICstmrCdtTrf"`
+}
+
+// Pacs008Transfer is the FIToFICstmrCdtTrf element of a pacs.008 message
+type Pacs008Transfer struct {
+	GroupHeader  ISOGroupHeader         `xml:"GrpHdr"`
+	Transactions []ISOInterbankTransfer `xml:"CdtTrfTxInf"`
+}
+
+// ISOGroupHeader is the GrpHdr element shared by pain.001 and pacs.008 messages
+type ISOGroupHeader struct {
+	MessageID                      string     `xml:"MsgId"`
+	CreationDateTime               string     `xml:"CreDtTm"`
+	NumberOfTransactions           int        `xml:"NbOfTxs"`
+	ControlSum                     string     `xml:"CtrlSum,omitempty"`
+	InitiatingParty                *ISOParty  `xml:"InitgPty,omitempty"`
+	TotalInterbankSettlementAmount *ISOAmount `xml:"TtlIntrBkSttlmAmt,omitempty"`
+	InterbankSettlementDate        string     `xml:"IntrBkSttlmDt,omitempty"`
+	SettlementMethod               string     `xml:"SttlmInf>SttlmMtd,omitempty"`
+}
+
+// ISOPaymentInformation is the PmtInf element of a pain.001 message
+type ISOPaymentInformation struct {
+	PaymentInformationID  string              `xml:"PmtInfId"`
+	PaymentMethod         string              `xml:"PmtMtd"`
+	NumberOfTransactions  int                 `xml:"NbOfTxs"`
+	ControlSum            string              `xml:"CtrlSum,omitempty"`
+	PaymentType           *ISOPaymentType     `xml:"PmtTpInf,omitempty"`
+	RequestedExecution    string              `xml:"ReqdExctnDt"`
+	Debtor                ISOParty            `xml:"Dbtr"`
+	DebtorAccount         *ISOAccount         `xml:"DbtrAcct,omitempty"`
+	DebtorAgent           ISOAgent            `xml:"DbtrAgt"`
+	CreditTransferDetails []ISOCreditTransfer `xml:"CdtTrfTxInf"`
+}
+
+// ISOCreditTransfer is the CdtTrfTxInf element of a pain.001 message
+type ISOCreditTransfer struct {
+	PaymentID        ISOPaymentID   `xml:"PmtId"`
+	InstructedAmount ISOAmount      `xml:"Amt>InstdAmt"`
+	Intermediary1    *ISOAgent      `xml:"IntrmyAgt1,omitempty"`
+	Intermediary2    *ISOAgent      `xml:"IntrmyAgt2,omitempty"`
+	Intermediary3    *ISOAgent      `xml:"IntrmyAgt3,omitempty"`
+	CreditorAgent    ISOAgent       `xml:"CdtrAgt"`
+	Creditor         ISOParty       `xml:"Cdtr"`
+	CreditorAccount  ISOAccount     `xml:"CdtrAcct"`
+	Purpose          string         `xml:"Purp>Prtry,omitempty"`
+	Remittance       *ISORemittance `xml:"RmtInf,omitempty"`
+}
+
+// ISOInterbankTransfer is the CdtTrfTxInf element of a pacs.008 message
+type ISOInterbankTransfer struct {
+	PaymentID                 ISOPaymentID    `xml:"PmtId"`
+	PaymentType               *ISOPaymentType `xml:"PmtTpInf,omitempty"`
+	InterbankSettlementAmount ISOAmount       `xml:"IntrBkSttlmAmt"`
+	InstructedAmount          *ISOAmount      `xml:"InstdAmt,omitempty"`
+	ChargeBearer              string          `xml:"ChrgBr"`
+	InstructingAgent          *ISOAgent       `xml:"InstgAgt,omitempty"`
+	InstructedAgent           *ISOAgent       `xml:"InstdAgt,omitempty"`
+	Intermediary1             *ISOAgent       `xml:"IntrmyAgt1,omitempty"`
+	Intermediary2             *ISOAgent       `xml:"IntrmyAgt2,omitempty"`
+	Intermediary3             *ISOAgent       `xml:"IntrmyAgt3,omitempty"`
+	Debtor                    ISOParty        `xml:"Dbtr"`
+	DebtorAccount             *ISOAccount     `xml:"DbtrAcct,omitempty"`
+	DebtorAgent               ISOAgent        `xml:"DbtrAgt"`
+	CreditorAgent             ISOAgent        `xml:"CdtrAgt"`
+	Creditor                  ISOParty        `xml:"Cdtr"`
+	CreditorAccount           ISOAccount      `xml:"CdtrAcct"`
+	Purpose                   string          `xml:"Purp>Prtry,omitempty"`
+	Remittance                *ISORemittance  `xml:"RmtInf,omitempty"`
+}
+
+// ISOPaymentType is the PmtTpInf element, carrying the SEC code and company entry description
+type ISOPaymentType struct {
+	LocalInstrument string `xml:"LclInstrm>Prtry,omitempty"`
+	CategoryPurpose string `xml:"CtgyPurp>Prtry,omitempty"`
+}
+
+// ISOPaymentID is the PmtId element. InstructionID carries the ACH trace number.
+type ISOPaymentID struct {
+	InstructionID string `xml:"InstrId,omitempty"`
+	EndToEndID    string `xml:"EndToEndId"`
+	TransactionID string `xml:"TxId,omitempty"`
+}
+
+// ISOAmount is an amount with its ISO 4217 currency code
+type ISOAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// ISOParty is a debtor, creditor or initiating party
+type ISOParty struct {
+	Name           string            `xml:"Nm,omitempty"`
+	PostalAddress  *ISOPostalAddress `xml:"PstlAdr,omitempty"`
+	Identification string            `xml:"Id>OrgId>Othr>Id,omitempty"`
+}
+
+// ISOPostalAddress is the PstlAdr element of a party or agent
+type ISOPostalAddress struct {
+	StreetName         string `xml:"StrtNm,omitempty"`
+	PostCode           string `xml:"PstCd,omitempty"`
+	TownName           string `xml:"TwnNm,omitempty"`
+	CountrySubDivision string `xml:"CtrySubDvsn,omitempty"`
+	Country            string `xml:"Ctry,omitempty"`
+}
+
+// ISOAccount is a debtor or creditor account
+type ISOAccount struct {
+	Identification string `xml:"Id>Othr>Id"`
+	Type           string `xml:"Tp>Prtry,omitempty"`
+	Currency       string `xml:"Ccy,omitempty"`
+}
+
+// ISOAgent is a financial institution acting as debtor, creditor, instructing,
+// instructed or intermediary agent
+type ISOAgent struct {
+	FinancialInstitution ISOFinancialInstitution `xml:"FinInstnId"`
+}
+
+// ISOFinancialInstitution identifies an agent by BIC, clearing system member ID or another scheme.
+type ISOFinancialInstitution struct {
+	BIC           string            `xml:"BIC,omitempty"`
+	MemberID      string            `xml:"ClrSysMmbId>MmbId,omitempty"`
+	Name          string            `xml:"Nm,omitempty"`
+	PostalAddress *ISOPostalAddress `xml:"PstlAdr,omitempty"`
+	OtherID       string            `xml:"Othr>Id,omitempty"`
+	OtherScheme   string            `xml:"Othr>SchmeNm>Prtry,omitempty"`
+}
+
+// ISORemittance is the RmtInf element holding unstructured remittance lines
+type ISORemittance struct {
+	Unstructured []string `xml:"Ustrd"`
+}
+
+// ReadPain001 decodes a pain.001 document from r
+func ReadPain001(r io.Reader) (*Pain001Document, error) {
+	doc := &Pain001Document{}
+	if err := xml.NewDecoder(r).Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Write encodes the pain.001 document as indented XML to w
+func (doc *Pain001Document) Write(w io.Writer) error {
+	return writeISO20022(w, doc)
+}
+
+// ReadPacs008 decodes a pacs.008 document from r
+func ReadPacs008(r io.Reader) (*Pacs008Document, error) {
+	doc := &Pacs008Document{}
+	if err := xml.NewDecoder(r).Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Write encodes the pacs.008 document as indented XML to w
+func (doc *Pacs008Document) Write(w io.Writer) error {
+	return writeISO20022(w, doc)
+}
+
+// writeISO20022 writes the XML declaration followed by the indented document
+func writeISO20022(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// IATBatchToPain001 converts a valid IATBatch of credit entries into a pain.001 document
+// with a payment information block per entry.
+//
+// The originator (Addenda11, Addenda12) becomes the debtor, the originating DFI (Addenda13)
+// the debtor agent, the receiver (Addenda10, Addenda15, Addenda16) the creditor, and the
+// receiving DFI (Addenda14) the creditor agent. The RDFI of the entry, usually the gateway,
+// is the first intermediary agent followed by the foreign correspondent banks (Addenda18),
+// so at most two Addenda18 records can be converted. Addenda17 records are unstructured
+// remittance information. An IAT batch does not carry the account of the originator, so the
+// DebtorAccount of each payment information block is left empty for the caller to set.
+func IATBatchToPain001(batch IATBatch, messageID string, created time.Time) (*Pain001Document, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+	bh := batch.GetHeader()
+	doc := &Pain001Document{}
+	doc.Initiation.GroupHeader = ISOGroupHeader{
+		MessageID:            messageID,
+		CreationDateTime:     created.Format(isoDateTime),
+		NumberOfTransactions: len(batch.GetEntries()),
+		InitiatingParty:      &ISOParty{Identification: bh.OriginatorIdentification},
+	}
+	total := 0
+	for _, entry := range batch.GetEntries() {
+		if err := isoCreditEntry(bh.BatchNumber, entry.TransactionCode); err != nil {
+			return nil, err
+		}
+		correspondents := iatCorrespondents(entry)
+		if len(correspondents) > 2 {
+			msg := fmt.Sprintf(msgISO20022Intermediary, len(correspondents), 2, pain001Name)
+			return nil, &BatchError{BatchNumber: bh.BatchNumber, FieldName: "Addenda18", Msg: msg, Code: ErrISO20022Conversion.Code}
+		}
+		agents := append([]*ISOAgent{{FinancialInstitution: ISOFinancialInstitution{MemberID: entry.RDFIIdentification + entry.CheckDigit}}}, correspondents...)
+
+		tx := ISOCreditTransfer{
+			PaymentID: ISOPaymentID{
+				InstructionID: entry.TraceNumberField(),
+				EndToEndID:    isoEndToEndID(entry.Addenda10.ForeignTraceNumber),
+			},
+			InstructedAmount: ISOAmount{Currency: "USD", Value: formatDollars(entry.Amount)},
+			CreditorAgent:    *isoAgent(entry.Addenda14.RDFIName, entry.Addenda14.RDFIIDNumberQualifier, entry.Addenda14.RDFIIdentification, entry.Addenda14.RDFIBranchCountryCode),
+			Creditor:         iatCreditor(entry),
+			CreditorAccount:  ISOAccount{Identification: strings.TrimSpace(entry.DFIAccountNumber), Type: isoAccountType(entry.TransactionCode), Currency: bh.ISODestinationCurrencyCode},
+			Purpose:          entry.Addenda10.TransactionTypeCode,
+			Remittance:       iatRemittance(entry),
+		}
+		tx.Intermediary1, tx.Intermediary2, tx.Intermediary3 = isoIntermediaries(agents)
+
+		total += entry.Amount
+		doc.Initiation.PaymentInformation = append(doc.Initiation.PaymentInformation, ISOPaymentInformation{
+			PaymentInformationID:  fmt.Sprintf("%v-%v", bh.BatchNumberField(), entry.TraceNumberField()),
+			PaymentMethod:         "TRF",
+			NumberOfTransactions:  1,
+			ControlSum:            formatDollars(entry.Amount),
+			PaymentType:           &ISOPaymentType{LocalInstrument: bh.StandardEntryClassCode, CategoryPurpose: bh.CompanyEntryDescription},
+			RequestedExecution:    isoFormatDate(bh.EffectiveEntryDate),
+			Debtor:                iatDebtor(bh, entry),
+			DebtorAgent:           *isoAgent(entry.Addenda13.ODFIName, entry.Addenda13.ODFIIDNumberQualifier, entry.Addenda13.ODFIIdentification, entry.Addenda13.ODFIBranchCountryCode),
+			CreditTransferDetails: []ISOCreditTransfer{tx},
+		})
+	}
+	doc.Initiation.GroupHeader.ControlSum = formatDollars(total)
+	return doc, nil
+}
+
+// Pain001ToIATBatch converts a pain.001 document into an IATBatch. Header fields which are
+// not carried in pain.001 (i.e. ForeignExchangeIndicator, ODFIIdentification) are copied from bh.
+// Addenda10 ForeignPaymentAmount is set to the instructed amount.
+func Pain001ToIATBatch(doc *Pain001Document, bh *IATBatchHeader) (IATBatch, error) {
+	header := *bh
+	batch := NewIATBatch(&header)
+	for i, info := range doc.Initiation.PaymentInformation {
+		if i == 0 {
+			if info.PaymentType != nil && info.PaymentType.CategoryPurpose != "" {
+				header.CompanyEntryDescription = info.PaymentType.CategoryPurpose
+			}
+			if t, err := isoParseDate(info.RequestedExecution); err == nil {
+				header.EffectiveEntryDate = t
+			}
+			if info.DebtorAccount != nil && info.DebtorAccount.Currency != "" {
+				header.ISOOriginatingCurrencyCode = info.DebtorAccount.Currency
+			}
+			if info.Debtor.Identification != "" {
+				header.OriginatorIdentification = info.Debtor.Identification
+			}
+		}
+		for _, tx := range info.CreditTransferDetails {
+			if tx.CreditorAccount.Currency != "" {
+				header.ISODestinationCurrencyCode = tx.CreditorAccount.Currency
+			}
+			agents := isoAgents(tx.Intermediary1, tx.Intermediary2, tx.Intermediary3)
+			if len(agents) == 0 {
+				return batch, &FieldError{FieldName: "IntrmyAgt1", Msg: msgISO20022RoutingNumber, Code: ErrInvalidRoutingNumber.Code}
+			}
+			entry, err := iatEntry(info.Debtor, info.DebtorAgent, tx.Creditor, tx.CreditorAgent, tx.CreditorAccount,
+				agents[0], agents[1:], tx.InstructedAmount, tx.InstructedAmount, tx.PaymentID, tx.Purpose, tx.Remittance)
+			if err != nil {
+				return batch, err
+			}
+			batch.AddEntry(entry)
+		}
+	}
+	if len(batch.GetEntries()) == 0 {
+		return batch, &FieldError{FieldName: "CdtTrfTxInf", Msg: msgISO20022Transactions, Code: ErrISO20022Conversion.Code}
+	}
+	if err := batch.Create(); err != nil {
+		return batch, err
+	}
+	return batch, nil
+}
+
+// IATBatchToPacs008 converts a valid IATBatch of credit entries into a pacs.008 document.
+//
+// The ODFI of the batch header is the instructing agent and the RDFI of the entry, usually
+// the gateway, the instructed agent. The foreign correspondent banks (Addenda18) are the
+// intermediary agents, so at most three Addenda18 records can be converted. The entry amount
+// is the interbank settlement amount in USD and the Addenda10 ForeignPaymentAmount the
+// instructed amount in the originating currency. Other addenda are mapped as in IATBatchToPain001,
+// and the DebtorAccount of each transaction is left empty for the caller to set.
+func IATBatchToPacs008(batch IATBatch, messageID string, created time.Time) (*Pacs008Document, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+	bh := batch.GetHeader()
+	doc := &Pacs008Document{}
+	total := 0
+	for _, entry := range batch.GetEntries() {
+		if err := isoCreditEntry(bh.BatchNumber, entry.TransactionCode); err != nil {
+			return nil, err
+		}
+		correspondents := iatCorrespondents(entry)
+		if len(correspondents) > 3 {
+			msg := fmt.Sprintf(msgISO20022Intermediary, len(correspondents), 3, pacs008Name)
+			return nil, &BatchError{BatchNumber: bh.BatchNumber, FieldName: "Addenda18", Msg: msg, Code: ErrISO20022Conversion.Code}
+		}
+		tx := ISOInterbankTransfer{
+			PaymentID: ISOPaymentID{
+				InstructionID: entry.TraceNumberField(),
+				EndToEndID:    isoEndToEndID(entry.Addenda10.ForeignTraceNumber),
+				TransactionID: entry.TraceNumberField(),
+			},
+			PaymentType:               &ISOPaymentType{LocalInstrument: bh.StandardEntryClassCode, CategoryPurpose: bh.CompanyEntryDescription},
+			InterbankSettlementAmount: ISOAmount{Currency: "USD", Value: formatDollars(entry.Amount)},
+			InstructedAmount:          &ISOAmount{Currency: bh.ISOOriginatingCurrencyCode, Value: formatDollars(entry.Addenda10.ForeignPaymentAmount)},
+			ChargeBearer:              "SLEV",
+			InstructingAgent:          &ISOAgent{FinancialInstitution: ISOFinancialInstitution{MemberID: bh.ODFIIdentification}},
+			InstructedAgent:           &ISOAgent{FinancialInstitution: ISOFinancialInstitution{MemberID: entry.RDFIIdentification + entry.CheckDigit}},
+			Debtor:                    iatDebtor(bh, entry),
+			DebtorAgent:               *isoAgent(entry.Addenda13.ODFIName, entry.Addenda13.ODFIIDNumberQualifier, entry.Addenda13.ODFIIdentification, entry.Addenda13.ODFIBranchCountryCode),
+			CreditorAgent:             *isoAgent(entry.Addenda14.RDFIName, entry.Addenda14.RDFIIDNumberQualifier, entry.Addenda14.RDFIIdentification, entry.Addenda14.RDFIBranchCountryCode),
+			Creditor:                  iatCreditor(entry),
+			CreditorAccount:           ISOAccount{Identification: strings.TrimSpace(entry.DFIAccountNumber), Type: isoAccountType(entry.TransactionCode), Currency: bh.ISODestinationCurrencyCode},
+			Purpose:                   entry.Addenda10.TransactionTypeCode,
+			Remittance:                iatRemittance(entry),
+		}
+		tx.Intermediary1, tx.Intermediary2, tx.Intermediary3 = isoIntermediaries(correspondents)
+		total += entry.Amount
+		doc.Transfer.Transactions = append(doc.Transfer.Transactions, tx)
+	}
+	doc.Transfer.GroupHeader = ISOGroupHeader{
+		MessageID:                      messageID,
+		CreationDateTime:               created.Format(isoDateTime),
+		NumberOfTransactions:           len(doc.Transfer.Transactions),
+		TotalInterbankSettlementAmount: &ISOAmount{Currency: "USD", Value: formatDollars(total)},
+		InterbankSettlementDate:        isoFormatDate(bh.EffectiveEntryDate),
+		SettlementMethod:               "CLRG",
+	}
+	return doc, nil
+}
+
+// Pacs008ToIATBatch converts a pacs.008 document into an IATBatch. Header fields which are
+// not carried in pacs.008 (i.e. ForeignExchangeIndicator, ServiceClassCode) are copied from bh.
+func Pacs008ToIATBatch(doc *Pacs008Document, bh *IATBatchHeader) (IATBatch, error) {
+	header := *bh
+	batch := NewIATBatch(&header)
+	if t, err := isoParseDate(doc.Transfer.GroupHeader.InterbankSettlementDate); err == nil {
+		header.EffectiveEntryDate = t
+	}
+	for i, tx := range doc.Transfer.Transactions {
+		if i == 0 {
+			if tx.PaymentType != nil && tx.PaymentType.CategoryPurpose != "" {
+				header.CompanyEntryDescription = tx.PaymentType.CategoryPurpose
+			}
+			if tx.InstructedAmount != nil && tx.InstructedAmount.Currency != "" {
+				header.ISOOriginatingCurrencyCode = tx.InstructedAmount.Currency
+			}
+			if tx.CreditorAccount.Currency != "" {
+				header.ISODestinationCurrencyCode = tx.CreditorAccount.Currency
+			}
+			if tx.Debtor.Identification != "" {
+				header.OriginatorIdentification = tx.Debtor.Identification
+			}
+			if tx.InstructingAgent != nil && tx.InstructingAgent.FinancialInstitution.MemberID != "" {
+				header.ODFIIdentification = tx.InstructingAgent.FinancialInstitution.MemberID
+			}
+		}
+		if tx.InstructedAgent == nil {
+			return batch, &FieldError{FieldName: "InstdAgt", Msg: msgISO20022RoutingNumber, Code: ErrInvalidRoutingNumber.Code}
+		}
+		foreign := tx.InterbankSettlementAmount
+		if tx.InstructedAmount != nil {
+			foreign = *tx.InstructedAmount
+		}
+		entry, err := iatEntry(tx.Debtor, tx.DebtorAgent, tx.Creditor, tx.CreditorAgent, tx.CreditorAccount,
+			tx.InstructedAgent, isoAgents(tx.Intermediary1, tx.Intermediary2, tx.Intermediary3),
+			tx.InterbankSettlementAmount, foreign, tx.PaymentID, tx.Purpose, tx.Remittance)
+		if err != nil {
+			return batch, err
+		}
+		batch.AddEntry(entry)
+	}
+	if len(batch.GetEntries()) == 0 {
+		return batch, &FieldError{FieldName: "CdtTrfTxInf", Msg: msgISO20022Transactions, Code: ErrISO20022Conversion.Code}
+	}
+	if err := batch.Create(); err != nil {
+		return batch, err
+	}
+	return batch, nil
+}
+
+// BatchToPain001 converts a valid domestic batch of credit entries into a pain.001 document
+// with a single payment information block. The company of the batch header is the debtor,
+// the ODFI the debtor agent, the RDFI of each entry its creditor agent and Addenda05
+// records unstructured remittance information. The CompanyIdentification is the identifier of
+// the debtor rather than an account, so the DebtorAccount is left empty for the caller to set.
+func BatchToPain001(batch Batcher, messageID string, created time.Time) (*Pain001Document, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+	bh := batch.GetHeader()
+	info := ISOPaymentInformation{
+		PaymentInformationID: bh.BatchNumberField(),
+		PaymentMethod:        "TRF",
+		PaymentType:          &ISOPaymentType{LocalInstrument: bh.StandardEntryClassCode, CategoryPurpose: bh.CompanyEntryDescription},
+		RequestedExecution:   isoFormatDate(bh.EffectiveEntryDate),
+		Debtor:               ISOParty{Name: bh.CompanyName, Identification: bh.CompanyIdentification},
+		DebtorAgent:          ISOAgent{FinancialInstitution: ISOFinancialInstitution{MemberID: bh.ODFIIdentification}},
+	}
+	total := 0
+	for _, entry := range batch.GetEntries() {
+		if err := isoCreditEntry(bh.BatchNumber, entry.TransactionCode); err != nil {
+			return nil, err
+		}
+		tx := ISOCreditTransfer{
+			PaymentID: ISOPaymentID{
+				InstructionID: entry.TraceNumberField(),
+				EndToEndID:    isoEndToEndID(entry.IdentificationNumber),
+			},
+			InstructedAmount: ISOAmount{Currency: "USD", Value: formatDollars(entry.Amount)},
+			CreditorAgent:    ISOAgent{FinancialInstitution: ISOFinancialInstitution{MemberID: entry.RDFIIdentification + entry.CheckDigit}},
+			Creditor:         ISOParty{Name: strings.TrimSpace(entry.IndividualName)},
+			CreditorAccount:  ISOAccount{Identification: strings.TrimSpace(entry.DFIAccountNumber), Type: isoAccountType(entry.TransactionCode)},
+		}
+		var lines []string
+		for _, addenda := range entry.Addendum {
+			if addenda05, ok := addenda.(*Addenda05); ok {
+				lines = append(lines, addenda05.PaymentRelatedInformation)
+			}
+		}
+		if len(lines) > 0 {
+			tx.Remittance = &ISORemittance{Unstructured: lines}
+		}
+		total += entry.Amount
+		info.CreditTransferDetails = append(info.CreditTransferDetails, tx)
+	}
+	info.NumberOfTransactions = len(info.CreditTransferDetails)
+	info.ControlSum = formatDollars(total)
+
+	doc := &Pain001Document{}
+	doc.Initiation.GroupHeader = ISOGroupHeader{
+		MessageID:            messageID,
+		CreationDateTime:     created.Format(isoDateTime),
+		NumberOfTransactions: info.NumberOfTransactions,
+		ControlSum:           info.ControlSum,
+		InitiatingParty:      &ISOParty{Name: bh.CompanyName, Identification: bh.CompanyIdentification},
+	}
+	doc.Initiation.PaymentInformation = []ISOPaymentInformation{info}
+	return doc, nil
+}
+
+// Pain001ToBatch converts a pain.001 document into a domestic batch of the SEC code of bh.
+// Savings creditor accounts ("SVGS") are credited with transaction code 32, all others with 22.
+// Unstructured remittance lines become Addenda05 records.
+func Pain001ToBatch(doc *Pain001Document, bh *BatchHeader) (Batcher, error) {
+	header := *bh
+	var entries []*EntryDetail
+	for i, info := range doc.Initiation.PaymentInformation {
+		if i == 0 {
+			if info.PaymentType != nil && info.PaymentType.CategoryPurpose != "" {
+				header.CompanyEntryDescription = info.PaymentType.CategoryPurpose
+			}
+			if t, err := isoParseDate(info.RequestedExecution); err == nil {
+				header.EffectiveEntryDate = t
+			}
+			if header.CompanyName == "" {
+				header.CompanyName = info.Debtor.Name
+			}
+		}
+		for _, tx := range info.CreditTransferDetails {
+			ed := NewEntryDetail()
+			ed.TransactionCode = 22
+			if tx.CreditorAccount.Type == isoSavingsAccount {
+				ed.TransactionCode = 32
+			}
+			routing := tx.CreditorAgent.FinancialInstitution.MemberID
+			if len(routing) != 9 {
+				return nil, &FieldError{FieldName: "CdtrAgt", Value: routing, Msg: msgISO20022RoutingNumber, Code: ErrInvalidRoutingNumber.Code}
+			}
+			ed.SetRDFI(routing)
+			ed.DFIAccountNumber = tx.CreditorAccount.Identification
+			amount, err := parseDollars(tx.InstructedAmount.Value)
+			if err != nil {
+				return nil, &FieldError{FieldName: "InstdAmt", Value: tx.InstructedAmount.Value, Msg: msgISO20022Amount, Code: ErrISO20022Conversion.Code}
+			}
+			ed.Amount = amount
+			ed.IndividualName = tx.Creditor.Name
+			if tx.PaymentID.EndToEndID != isoNotProvided {
+				ed.IdentificationNumber = tx.PaymentID.EndToEndID
+			}
+			ed.TraceNumber = isoTraceNumber(tx.PaymentID.InstructionID, header.ODFIIdentification)
+			if tx.Remittance != nil {
+				for _, line := range tx.Remittance.Unstructured {
+					addenda05 := NewAddenda05()
+					addenda05.PaymentRelatedInformation = line
+					ed.AddAddenda(addenda05)
+				}
+			}
+			entries = append(entries, ed)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, &FieldError{FieldName: "CdtTrfTxInf", Msg: msgISO20022Transactions, Code: ErrISO20022Conversion.Code}
+	}
+	batch, err := NewBatch(&header)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		if entry.TraceNumber == 0 {
+			entry.SetTraceNumber(header.ODFIIdentification, i+1)
+		}
+		if batch.GetHeader().StandardEntryClassCode == "CTX" {
+			name := entry.IndividualName
+			entry.SetCTXAddendaRecords(len(entry.Addendum))
+			entry.SetCTXReceivingCompany(name)
+		}
+		batch.AddEntry(entry)
+	}
+	if err := batch.Create(); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// isoNotProvided is the EndToEndId used when the originator did not provide a reference
+const isoNotProvided = "NOTPROVIDED"
+
+// isoCreditEntry returns an error when transactionCode is not a credit
+func isoCreditEntry(batchNumber, transactionCode int) error {
+	ed := EntryDetail{TransactionCode: transactionCode}
+	if ed.CreditOrDebit() != "C" {
+		return &BatchError{BatchNumber: batchNumber, FieldName: "TransactionCode", Msg: msgISO20022Debit, Code: ErrISO20022Conversion.Code}
+	}
+	return nil
+}
+
+// isoAccountType returns the proprietary account type of transactionCode
+func isoAccountType(transactionCode int) string {
+	if transactionCode/10 == 3 {
+		return isoSavingsAccount
+	}
+	return isoCheckingAccount
+}
+
+// isoEndToEndID returns reference or NOTPROVIDED when it is blank
+func isoEndToEndID(reference string) string {
+	if s := strings.TrimSpace(reference); s != "" {
+		return s
+	}
+	return isoNotProvided
+}
+
+// isoTraceNumber returns the trace number carried in an InstrId when it belongs to odfi,
+// otherwise 0 so a sequenced trace number is assigned.
+func isoTraceNumber(instructionID, odfi string) int {
+	if len(instructionID) != 15 || !strings.HasPrefix(instructionID, odfi) {
+		return 0
+	}
+	n, err := strconv.Atoi(instructionID)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// isoFormatDate formats t as an ISODate, or returns an empty string for the zero time
+func isoFormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(isoDate)
+}
+
+// isoParseDate parses an ISODate
+func isoParseDate(s string) (time.Time, error) {
+	return time.Parse(isoDate, s)
+}
+
+// isoAgent returns the agent of an IAT addenda record identified by a NACHA ID number qualifier:
+// "01" national clearing system, "02" BIC code, "03" IBAN.
+func isoAgent(name, qualifier, id, country string) *ISOAgent {
+	fi := ISOFinancialInstitution{Name: strings.TrimSpace(name)}
+	id = strings.TrimSpace(id)
+	switch qualifier {
+	case "01":
+		fi.MemberID = id
+	case "02":
+		fi.BIC = id
+	default:
+		fi.OtherID = id
+		fi.OtherScheme = qualifier
+	}
+	if country = strings.TrimSpace(country); country != "" {
+		fi.PostalAddress = &ISOPostalAddress{Country: country}
+	}
+	return &ISOAgent{FinancialInstitution: fi}
+}
+
+// isoAgentFields returns the NACHA name, ID number qualifier, ID and branch country of agent
+func isoAgentFields(agent ISOAgent) (name, qualifier, id, country string) {
+	fi := agent.FinancialInstitution
+	name = fi.Name
+	if fi.PostalAddress != nil {
+		country = fi.PostalAddress.Country
+	}
+	switch {
+	case fi.BIC != "":
+		return name, "02", fi.BIC, country
+	case fi.OtherID != "":
+		qualifier = fi.OtherScheme
+		if qualifier == "" {
+			qualifier = "03"
+		}
+		return name, qualifier, fi.OtherID, country
+	}
+	return name, "01", fi.MemberID, country
+}
+
+// isoIntermediaries spreads agents over the three intermediary agent elements
+func isoIntermediaries(agents []*ISOAgent) (one, two, three *ISOAgent) {
+	all := make([]*ISOAgent, 3)
+	copy(all, agents)
+	return all[0], all[1], all[2]
+}
+
+// isoAgents returns the intermediary agents which are set, in order
+func isoAgents(agents ...*ISOAgent) []*ISOAgent {
+	var set []*ISOAgent
+	for _, agent := range agents {
+		if agent != nil {
+			set = append(set, agent)
+		}
+	}
+	return set
+}
+
+// iatCorrespondents returns the foreign correspondent banks (Addenda18) of entry as agents
+func iatCorrespondents(entry *IATEntryDetail) []*ISOAgent {
+	var agents []*ISOAgent
+	for _, addenda := range entry.Addendum {
+		if addenda18, ok := addenda.(*Addenda18); ok {
+			agents = append(agents, isoAgent(addenda18.ForeignCorrespondentBankName,
+				addenda18.ForeignCorrespondentBankIDNumberQualifier,
+				addenda18.ForeignCorrespondentBankIDNumber,
+				addenda18.ForeignCorrespondentBankBranchCountryCode))
+		}
+	}
+	return agents
+}
+
+// iatRemittance returns the payment related information (Addenda17) of entry
+func iatRemittance(entry *IATEntryDetail) *ISORemittance {
+	var lines []string
+	for _, addenda := range entry.Addendum {
+		if addenda17, ok := addenda.(*Addenda17); ok {
+			lines = append(lines, addenda17.PaymentRelatedInformation)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return &ISORemittance{Unstructured: lines}
+}
+
+// iatDebtor returns the originator (Addenda11, Addenda12) of entry as a party
+func iatDebtor(bh *IATBatchHeader, entry *IATEntryDetail) ISOParty {
+	city, state := splitIATAddress(entry.Addenda12.OriginatorCityStateProvince)
+	country, postal := splitIATAddress(entry.Addenda12.OriginatorCountryPostalCode)
+	return ISOParty{
+		Name: strings.TrimSpace(entry.Addenda11.OriginatorName),
+		PostalAddress: &ISOPostalAddress{
+			StreetName:         strings.TrimSpace(entry.Addenda11.OriginatorStreetAddress),
+			PostCode:           postal,
+			TownName:           city,
+			CountrySubDivision: state,
+			Country:            country,
+		},
+		Identification: bh.OriginatorIdentification,
+	}
+}
+
+// iatCreditor returns the receiver (Addenda10, Addenda15, Addenda16) of entry as a party
+func iatCreditor(entry *IATEntryDetail) ISOParty {
+	city, state := splitIATAddress(entry.Addenda16.ReceiverCityStateProvince)
+	country, postal := splitIATAddress(entry.Addenda16.ReceiverCountryPostalCode)
+	return ISOParty{
+		Name: strings.TrimSpace(entry.Addenda10.Name),
+		PostalAddress: &ISOPostalAddress{
+			StreetName:         strings.TrimSpace(entry.Addenda15.ReceiverStreetAddress),
+			PostCode:           postal,
+			TownName:           city,
+			CountrySubDivision: state,
+			Country:            country,
+		},
+		Identification: strings.TrimSpace(entry.Addenda15.ReceiverIDNumber),
+	}
+}
+
+// iatEntry builds an IATEntryDetail and its addenda records from the parties and agents of a transaction
+func iatEntry(debtor ISOParty, debtorAgent ISOAgent, creditor ISOParty, creditorAgent ISOAgent, account ISOAccount,
+	rdfi *ISOAgent, correspondents []*ISOAgent, amount, foreignAmount ISOAmount, id ISOPaymentID, purpose string, remittance *ISORemittance) (*IATEntryDetail, error) {
+
+	entry := NewIATEntryDetail()
+	entry.TransactionCode = 22
+	if account.Type == isoSavingsAccount {
+		entry.TransactionCode = 32
+	}
+	routing := rdfi.FinancialInstitution.MemberID
+	if len(routing) != 9 {
+		return nil, &FieldError{FieldName: "MmbId", Value: routing, Msg: msgISO20022RoutingNumber, Code: ErrInvalidRoutingNumber.Code}
+	}
+	entry.SetRDFI(routing)
+	entry.DFIAccountNumber = account.Identification
+	cents, err := parseDollars(amount.Value)
+	if err != nil {
+		return nil, &FieldError{FieldName: "Amt", Value: amount.Value, Msg: msgISO20022Amount, Code: ErrISO20022Conversion.Code}
+	}
+	entry.Amount = cents
+	foreign, err := parseDollars(foreignAmount.Value)
+	if err != nil {
+		return nil, &FieldError{FieldName: "InstdAmt", Value: foreignAmount.Value, Msg: msgISO20022Amount, Code: ErrISO20022Conversion.Code}
+	}
+	if n, err := strconv.Atoi(id.InstructionID); err == nil && len(id.InstructionID) == 15 {
+		entry.TraceNumber = n
+	}
+
+	entry.Addenda10 = NewAddenda10()
+	entry.Addenda10.TransactionTypeCode = purpose
+	entry.Addenda10.ForeignPaymentAmount = foreign
+	if id.EndToEndID != isoNotProvided {
+		entry.Addenda10.ForeignTraceNumber = id.EndToEndID
+	}
+	entry.Addenda10.Name = creditor.Name
+
+	originator := isoPostalAddress(debtor.PostalAddress)
+	entry.Addenda11 = NewAddenda11()
+	entry.Addenda11.OriginatorName = debtor.Name
+	entry.Addenda11.OriginatorStreetAddress = originator.StreetName
+	entry.Addenda12 = NewAddenda12()
+	entry.Addenda12.OriginatorCityStateProvince = joinIATAddress(originator.TownName, originator.CountrySubDivision)
+	entry.Addenda12.OriginatorCountryPostalCode = joinIATAddress(originator.Country, originator.PostCode)
+
+	entry.Addenda13 = NewAddenda13()
+	entry.Addenda13.ODFIName, entry.Addenda13.ODFIIDNumberQualifier, entry.Addenda13.ODFIIdentification, entry.Addenda13.ODFIBranchCountryCode = isoAgentFields(debtorAgent)
+	entry.Addenda14 = NewAddenda14()
+	entry.Addenda14.RDFIName, entry.Addenda14.RDFIIDNumberQualifier, entry.Addenda14.RDFIIdentification, entry.Addenda14.RDFIBranchCountryCode = isoAgentFields(creditorAgent)
+
+	receiver := isoPostalAddress(creditor.PostalAddress)
+	entry.Addenda15 = NewAddenda15()
+	entry.Addenda15.ReceiverIDNumber = creditor.Identification
+	entry.Addenda15.ReceiverStreetAddress = receiver.StreetName
+	entry.Addenda16 = NewAddenda16()
+	entry.Addenda16.ReceiverCityStateProvince = joinIATAddress(receiver.TownName, receiver.CountrySubDivision)
+	entry.Addenda16.ReceiverCountryPostalCode = joinIATAddress(receiver.Country, receiver.PostCode)
+
+	if remittance != nil {
+		for _, line := range remittance.Unstructured {
+			addenda17 := NewAddenda17()
+			addenda17.PaymentRelatedInformation = line
+			entry.AddIATAddenda(addenda17)
+		}
+	}
+	for _, agent := range correspondents {
+		addenda18 := NewAddenda18()
+		addenda18.ForeignCorrespondentBankName, addenda18.ForeignCorrespondentBankIDNumberQualifier,
+			addenda18.ForeignCorrespondentBankIDNumber, addenda18.ForeignCorrespondentBankBranchCountryCode = isoAgentFields(*agent)
+		entry.AddIATAddenda(addenda18)
+	}
+	entry.AddendaRecords = 7 + len(entry.Addendum)
+	return entry, nil
+}
+
+// isoPostalAddress returns address or an empty address when it is nil
+func isoPostalAddress(address *ISOPostalAddress) ISOPostalAddress {
+	if address == nil {
+		return ISOPostalAddress{}
+	}
+	return *address
+}
+
+// splitIATAddress splits an Addenda12 or Addenda16 address field formatted as "first*second\",
+// which is either "City*State\" or "Country*PostalCode\".
+func splitIATAddress(s string) (first, second string) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "\\")
+	if i := strings.Index(s, "*"); i >= 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+	return s, ""
+}
+
+// joinIATAddress formats first and second as an IAT addenda address field "first*second\"
+func joinIATAddress(first, second string) string {
+	return first + "*" + second + "\\"
+}
@@ -0,0 +1,327 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockISO20022IATBatch creates an IAT batch with remittance and correspondent bank addenda
+func mockISO20022IATBatch() IATBatch {
+	batch := mockIATBatch()
+	entry := batch.GetEntries()[0]
+	entry.AddIATAddenda(mockAddenda17())
+	entry.AddIATAddenda(mockAddenda18())
+	entry.AddIATAddenda(mockAddenda18B())
+	entry.AddendaRecords = 7 + len(entry.Addendum)
+	batch.GetHeader().EffectiveEntryDate = time.Date(2018, time.October, 22, 0, 0, 0, 0, time.UTC)
+	if err := batch.Create(); err != nil {
+		panic(err)
+	}
+	return batch
+}
+
+// compareIATEntries reports differences between the records of two IAT entries
+func compareIATEntries(t testing.TB, want, got *IATEntryDetail) {
+	records := [][2]string{
+		{want.String(), got.String()},
+		{want.Addenda10.String(), got.Addenda10.String()},
+		{want.Addenda11.String(), got.Addenda11.String()},
+		{want.Addenda12.String(), got.Addenda12.String()},
+		{want.Addenda13.String(), got.Addenda13.String()},
+		{want.Addenda14.String(), got.Addenda14.String()},
+		{want.Addenda15.String(), got.Addenda15.String()},
+		{want.Addenda16.String(), got.Addenda16.String()},
+	}
+	if len(want.Addendum) != len(got.Addendum) {
+		t.Fatalf("expected %d addenda got %d", len(want.Addendum), len(got.Addendum))
+	}
+	for i := range want.Addendum {
+		records = append(records, [2]string{want.Addendum[i].String(), got.Addendum[i].String()})
+	}
+	for _, r := range records {
+		if r[0] != r[1] {
+			t.Errorf("\nwant %s\n got %s", r[0], r[1])
+		}
+	}
+}
+
+// testIATBatchPain001 validates converting an IATBatch to pain.001 and back
+func testIATBatchPain001(t testing.TB) {
+	batch := mockISO20022IATBatch()
+	doc, err := IATBatchToPain001(batch, "MSG1", time.Now())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	// the batch does not carry the account of the debtor
+	if strings.Contains(buf.String(), "<DbtrAcct>") {
+		t.Errorf("DbtrAcct found in\n%s", buf.String())
+	}
+	for _, s := range []string{"pain.001.001.03", "<Nm>BEK Solutions</Nm>", "<TwnNm>JacobsTown</TwnNm>", "<Ustrd>This is an international payment</Ustrd>"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("%s not found in\n%s", s, buf.String())
+		}
+	}
+	read, err := ReadPain001(&buf)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if read.Initiation.GroupHeader.ControlSum != "1000.00" {
+		t.Errorf("ControlSum %s", read.Initiation.GroupHeader.ControlSum)
+	}
+
+	back, err := Pain001ToIATBatch(read, mockIATBatchHeaderFF())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if back.GetHeader().String() != batch.GetHeader().String() {
+		t.Errorf("\nwant %s\n got %s", batch.GetHeader().String(), back.GetHeader().String())
+	}
+	compareIATEntries(t, batch.GetEntries()[0], back.GetEntries()[0])
+	if back.GetControl().String() != batch.GetControl().String() {
+		t.Errorf("\nwant %s\n got %s", batch.GetControl().String(), back.GetControl().String())
+	}
+}
+
+// TestIATBatchPain001 tests converting an IATBatch to pain.001 and back
+func TestIATBatchPain001(t *testing.T) {
+	testIATBatchPain001(t)
+}
+
+// BenchmarkIATBatchPain001 benchmarks converting an IATBatch to pain.001 and back
+func BenchmarkIATBatchPain001(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATBatchPain001(b)
+	}
+}
+
+// testIATBatchPacs008 validates converting an IATBatch to pacs.008 and back
+func testIATBatchPacs008(t testing.TB) {
+	batch := mockISO20022IATBatch()
+	doc, err := IATBatchToPacs008(batch, "MSG1", time.Now())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	// the batch does not carry the account of the debtor
+	if strings.Contains(buf.String(), "<DbtrAcct>") {
+		t.Errorf("DbtrAcct found in\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `<TtlIntrBkSttlmAmt Ccy="USD">1000.00</TtlIntrBkSttlmAmt>`) {
+		t.Errorf("TtlIntrBkSttlmAmt not found in\n%s", buf.String())
+	}
+	read, err := ReadPacs008(&buf)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(read.Transfer.Transactions) != 1 {
+		t.Fatalf("expected 1 transaction got %d", len(read.Transfer.Transactions))
+	}
+	if read.Transfer.Transactions[0].Intermediary2 == nil {
+		t.Error("Addenda18 records were not converted to intermediary agents")
+	}
+
+	back, err := Pacs008ToIATBatch(read, mockIATBatchHeaderFF())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if back.GetHeader().String() != batch.GetHeader().String() {
+		t.Errorf("\nwant %s\n got %s", batch.GetHeader().String(), back.GetHeader().String())
+	}
+	compareIATEntries(t, batch.GetEntries()[0], back.GetEntries()[0])
+}
+
+// TestIATBatchPacs008 tests converting an IATBatch to pacs.008 and back
+func TestIATBatchPacs008(t *testing.T) {
+	testIATBatchPacs008(t)
+}
+
+// BenchmarkIATBatchPacs008 benchmarks converting an IATBatch to pacs.008 and back
+func BenchmarkIATBatchPacs008(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATBatchPacs008(b)
+	}
+}
+
+// testIATBatchISO20022Intermediaries validates too many Addenda18 records are rejected
+func testIATBatchISO20022Intermediaries(t testing.TB) {
+	batch := mockISO20022IATBatch()
+	entry := batch.GetEntries()[0]
+	entry.AddIATAddenda(mockAddenda18C())
+	entry.AddendaRecords++
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if _, err := IATBatchToPain001(batch, "MSG1", time.Now()); err != nil {
+		if e, ok := err.(*BatchError); ok {
+			if e.FieldName != "Addenda18" {
+				t.Errorf("%T: %s", err, err)
+			}
+		} else {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected an error for 3 Addenda18 records in pain.001")
+	}
+	if _, err := IATBatchToPacs008(batch, "MSG1", time.Now()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIATBatchISO20022Intermediaries tests too many Addenda18 records are rejected
+func TestIATBatchISO20022Intermediaries(t *testing.T) {
+	testIATBatchISO20022Intermediaries(t)
+}
+
+// BenchmarkIATBatchISO20022Intermediaries benchmarks too many Addenda18 records are rejected
+func BenchmarkIATBatchISO20022Intermediaries(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATBatchISO20022Intermediaries(b)
+	}
+}
+
+// testBatchPain001 validates converting a domestic credit batch to pain.001 and back
+func testBatchPain001(t testing.TB) {
+	bh := mockBatchPPDHeader()
+	bh.EffectiveEntryDate = time.Date(2018, time.October, 22, 0, 0, 0, 0, time.UTC)
+	batch := NewBatchPPD(bh)
+	batch.AddEntry(mockPPDEntryDetail())
+	savings := mockPPDEntryDetail2()
+	savings.TransactionCode = 32
+	savings.SetTraceNumber(bh.ODFIIdentification, 2)
+	savings.AddAddenda(mockAddenda05())
+	batch.AddEntry(savings)
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	doc, err := BatchToPain001(batch, "MSG1", time.Now())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	// the batch does not carry the account of the debtor
+	if strings.Contains(buf.String(), "<DbtrAcct>") {
+		t.Errorf("DbtrAcct found in\n%s", buf.String())
+	}
+	read, err := ReadPain001(&buf)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := read.Initiation.PaymentInformation[0].NumberOfTransactions; n != 2 {
+		t.Errorf("NbOfTxs %d expected 2", n)
+	}
+
+	back, err := Pain001ToBatch(read, mockBatchPPDHeader())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if back.GetHeader().String() != batch.GetHeader().String() {
+		t.Errorf("\nwant %s\n got %s", batch.GetHeader().String(), back.GetHeader().String())
+	}
+	for i, entry := range batch.GetEntries() {
+		if entry.String() != back.GetEntries()[i].String() {
+			t.Errorf("\nwant %s\n got %s", entry.String(), back.GetEntries()[i].String())
+		}
+	}
+	if back.GetEntries()[1].Addendum[0].String() != savings.Addendum[0].String() {
+		t.Errorf("\nwant %s\n got %s", savings.Addendum[0].String(), back.GetEntries()[1].Addendum[0].String())
+	}
+}
+
+// TestBatchPain001 tests converting a domestic credit batch to pain.001 and back
+func TestBatchPain001(t *testing.T) {
+	testBatchPain001(t)
+}
+
+// BenchmarkBatchPain001 benchmarks converting a domestic credit batch to pain.001 and back
+func BenchmarkBatchPain001(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testBatchPain001(b)
+	}
+}
+
+// testBatchPain001Debit validates debit entries can not be converted to a credit transfer
+func testBatchPain001Debit(t testing.TB) {
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = 225
+	batch := NewBatchPPD(bh)
+	entry := mockPPDEntryDetail()
+	entry.TransactionCode = 27
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if _, err := BatchToPain001(batch, "MSG1", time.Now()); err != nil {
+		if e, ok := err.(*BatchError); ok {
+			if e.FieldName != "TransactionCode" {
+				t.Errorf("%T: %s", err, err)
+			}
+		} else {
+			t.Errorf("%T: %s", err, err)
+		}
+	} else {
+		t.Error("expected an error converting a debit to pain.001")
+	}
+}
+
+// TestBatchPain001Debit tests debit entries can not be converted to a credit transfer
+func TestBatchPain001Debit(t *testing.T) {
+	testBatchPain001Debit(t)
+}
+
+// BenchmarkBatchPain001Debit benchmarks debit entries can not be converted to a credit transfer
+func BenchmarkBatchPain001Debit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testBatchPain001Debit(b)
+	}
+}
+
+// testSplitIATAddress validates splitting and joining IAT address fields
+func testSplitIATAddress(t testing.TB) {
+	city, state := splitIATAddress("JacobsTown*PA\\")
+	if city != "JacobsTown" || state != "PA" {
+		t.Errorf("got %q %q", city, state)
+	}
+	if s := joinIATAddress(city, state); s != "JacobsTown*PA\\" {
+		t.Errorf("got %q", s)
+	}
+	if first, second := splitIATAddress("JacobsTown"); first != "JacobsTown" || second != "" {
+		t.Errorf("got %q %q", first, second)
+	}
+}
+
+// TestSplitIATAddress tests splitting and joining IAT address fields
+func TestSplitIATAddress(t *testing.T) {
+	testSplitIATAddress(t)
+}
+
+// BenchmarkSplitIATAddress benchmarks splitting and joining IAT address fields
+func BenchmarkSplitIATAddress(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testSplitIATAddress(b)
+	}
+}
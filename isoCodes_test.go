@@ -0,0 +1,88 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"testing"
+)
+
+// testISOCodes validates ISO 3166-1 country and ISO 4217 currency codes
+func testISOCodes(t testing.TB) {
+	v := validator{}
+	for _, code := range []string{"US", "CA", "DE", "GB", "AQ", "CI"} {
+		if err := v.isCountryCode(code); err != nil {
+			t.Errorf("%s: %v", code, err)
+		}
+	}
+	for _, code := range []string{"", "us", "USA", "ZZ", "XK"} {
+		if err := v.isCountryCode(code); err == nil {
+			t.Errorf("%q should not be a country code", code)
+		}
+	}
+	for _, code := range []string{"USD", "CAD", "EUR", "JPY", "CHF"} {
+		if err := v.isCurrencyCode(code); err != nil {
+			t.Errorf("%s: %v", code, err)
+		}
+	}
+	for _, code := range []string{"", "usd", "US", "ZZZ"} {
+		if err := v.isCurrencyCode(code); err == nil {
+			t.Errorf("%q should not be a currency code", code)
+		}
+	}
+	if len(countryCodeDict) != 249 {
+		t.Errorf("expected 249 countries got %d", len(countryCodeDict))
+	}
+}
+
+// TestISOCodes tests ISO 3166-1 country and ISO 4217 currency codes
+func TestISOCodes(t *testing.T) {
+	testISOCodes(t)
+}
+
+// BenchmarkISOCodes benchmarks ISO 3166-1 country and ISO 4217 currency codes
+func BenchmarkISOCodes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testISOCodes(b)
+	}
+}
+
+// testIATAddressDelimiters validates the * and \ delimiters of IAT address fields
+func testIATAddressDelimiters(t testing.TB) {
+	v := validator{}
+	for _, s := range []string{"JacobsTown*PA\\", "Ciudad de Mexico*\\", " LetterTown*AB\\   "} {
+		if err := v.isCityStateProvince(s); err != nil {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"JacobsTown*PA", "JacobsTown PA\\", "*PA\\", "Jacobs*Town*PA\\", "JacobsTown*P\\A\\"} {
+		if err := v.isCityStateProvince(s); err == nil {
+			t.Errorf("%q should not be a valid city and state", s)
+		}
+	}
+	for _, s := range []string{"US*19305\\", "CA*80014\\", "IE*\\"} {
+		if err := v.isCountryPostalCode(s); err != nil {
+			t.Errorf("%q: %v", s, err)
+		}
+	}
+	for _, s := range []string{"US19305\\", "US*19305", "USA*19305\\", "ZZ*19305\\"} {
+		if err := v.isCountryPostalCode(s); err == nil {
+			t.Errorf("%q should not be a valid country and postal code", s)
+		}
+	}
+}
+
+// TestIATAddressDelimiters tests the * and \ delimiters of IAT address fields
+func TestIATAddressDelimiters(t *testing.T) {
+	testIATAddressDelimiters(t)
+}
+
+// BenchmarkIATAddressDelimiters benchmarks the * and \ delimiters of IAT address fields
+func BenchmarkIATAddressDelimiters(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATAddressDelimiters(b)
+	}
+}
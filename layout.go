@@ -0,0 +1,499 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FieldType is the content of a record field
+type FieldType string
+
+const (
+	// FieldAlphanumeric fields are left-justified and space filled
+	FieldAlphanumeric FieldType = "alphanumeric"
+	// FieldNumeric fields are right-justified and zero filled
+	FieldNumeric FieldType = "numeric"
+	// FieldBlank fields are reserved and left blank
+	FieldBlank FieldType = "blank"
+)
+
+// FieldLayout describes the position of a field in a record. Start and End are the first
+// and last positions of the field, counted from 1 as in the NACHA rules, so the field of a
+// line is line[Start-1:End].
+type FieldLayout struct {
+	// Name is the name of the field in its record struct
+	Name string `json:"name"`
+	// Start is the position of the first character of the field
+	Start int `json:"start"`
+	// End is the position of the last character of the field
+	End int `json:"end"`
+	// Type is the content of the field
+	Type FieldType `json:"type"`
+	// Required is true for mandatory and required fields
+	Required bool `json:"required"`
+}
+
+// RecordLayout describes the fields of a record type
+type RecordLayout struct {
+	// Name is the name of the record struct, such as EntryDetail or Addenda98
+	Name string `json:"name"`
+	// Fields are the fields of the record in order, covering all 94 positions
+	Fields []FieldLayout `json:"fields"`
+}
+
+// DecodedField is the value of a field of a raw record
+type DecodedField struct {
+	FieldLayout
+	// Value is the content of the field. Alphanumeric and blank fields are trimmed of spaces.
+	Value string `json:"value"`
+}
+
+// DecodedRecord is a raw record split into its fields
+type DecodedRecord struct {
+	// Record is the name of the record layout of the line
+	Record string `json:"record"`
+	// Fields are the decoded fields of the line in order
+	Fields []DecodedField `json:"fields"`
+}
+
+// Errors specific to record layouts
+var (
+	msgLayoutAddendaTypeCode = "%s is an unknown addenda type code"
+)
+
+// fileHeaderLayout is the layout of FileHeader
+var fileHeaderLayout = RecordLayout{"FileHeader", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"priorityCode", 2, 3, FieldNumeric, true},
+	{"ImmediateDestination", 4, 13, FieldAlphanumeric, true},
+	{"ImmediateOrigin", 14, 23, FieldAlphanumeric, true},
+	{"FileCreationDate", 24, 29, FieldNumeric, true},
+	{"FileCreationTime", 30, 33, FieldNumeric, false},
+	{"FileIDModifier", 34, 34, FieldAlphanumeric, true},
+	{"recordSize", 35, 37, FieldNumeric, true},
+	{"blockingFactor", 38, 39, FieldNumeric, true},
+	{"formatCode", 40, 40, FieldNumeric, true},
+	{"ImmediateDestinationName", 41, 63, FieldAlphanumeric, false},
+	{"ImmediateOriginName", 64, 86, FieldAlphanumeric, false},
+	{"ReferenceCode", 87, 94, FieldAlphanumeric, false},
+}}
+
+// batchHeaderLayout is the layout of BatchHeader
+var batchHeaderLayout = RecordLayout{"BatchHeader", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"ServiceClassCode", 2, 4, FieldNumeric, true},
+	{"CompanyName", 5, 20, FieldAlphanumeric, true},
+	{"CompanyDiscretionaryData", 21, 40, FieldAlphanumeric, false},
+	{"CompanyIdentification", 41, 50, FieldAlphanumeric, true},
+	{"StandardEntryClassCode", 51, 53, FieldAlphanumeric, true},
+	{"CompanyEntryDescription", 54, 63, FieldAlphanumeric, true},
+	{"CompanyDescriptiveDate", 64, 69, FieldAlphanumeric, false},
+	{"EffectiveEntryDate", 70, 75, FieldNumeric, true},
+	{"settlementDate", 76, 78, FieldNumeric, false},
+	{"OriginatorStatusCode", 79, 79, FieldAlphanumeric, true},
+	{"ODFIIdentification", 80, 87, FieldNumeric, true},
+	{"BatchNumber", 88, 94, FieldNumeric, true},
+}}
+
+// entryDetailLayout is the layout of EntryDetail
+var entryDetailLayout = RecordLayout{"EntryDetail", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"TransactionCode", 2, 3, FieldNumeric, true},
+	{"RDFIIdentification", 4, 11, FieldNumeric, true},
+	{"CheckDigit", 12, 12, FieldNumeric, true},
+	{"DFIAccountNumber", 13, 29, FieldAlphanumeric, true},
+	{"Amount", 30, 39, FieldNumeric, true},
+	{"IdentificationNumber", 40, 54, FieldAlphanumeric, false},
+	{"IndividualName", 55, 76, FieldAlphanumeric, true},
+	{"DiscretionaryData", 77, 78, FieldAlphanumeric, false},
+	{"AddendaRecordIndicator", 79, 79, FieldNumeric, true},
+	{"TraceNumber", 80, 94, FieldNumeric, true},
+}}
+
+// batchControlLayout is the layout of BatchControl
+var batchControlLayout = RecordLayout{"BatchControl", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"ServiceClassCode", 2, 4, FieldNumeric, true},
+	{"EntryAddendaCount", 5, 10, FieldNumeric, true},
+	{"EntryHash", 11, 20, FieldNumeric, true},
+	{"TotalDebitEntryDollarAmount", 21, 32, FieldNumeric, true},
+	{"TotalCreditEntryDollarAmount", 33, 44, FieldNumeric, true},
+	{"CompanyIdentification", 45, 54, FieldAlphanumeric, true},
+	{"MessageAuthenticationCode", 55, 73, FieldAlphanumeric, false},
+	{"reserved", 74, 79, FieldBlank, false},
+	{"ODFIIdentification", 80, 87, FieldNumeric, true},
+	{"BatchNumber", 88, 94, FieldNumeric, true},
+}}
+
+// fileControlLayout is the layout of FileControl
+var fileControlLayout = RecordLayout{"FileControl", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"BatchCount", 2, 7, FieldNumeric, true},
+	{"BlockCount", 8, 13, FieldNumeric, true},
+	{"EntryAddendaCount", 14, 21, FieldNumeric, true},
+	{"EntryHash", 22, 31, FieldNumeric, true},
+	{"TotalDebitEntryDollarAmountInFile", 32, 43, FieldNumeric, true},
+	{"TotalCreditEntryDollarAmountInFile", 44, 55, FieldNumeric, true},
+	{"reserved", 56, 94, FieldBlank, false},
+}}
+
+// fillerLayout is the layout of the records of nines that pad the last block of a file
+var fillerLayout = RecordLayout{"Filler", []FieldLayout{
+	{"filler", 1, 94, FieldNumeric, false},
+}}
+
+// iatBatchHeaderLayout is the layout of IATBatchHeader
+var iatBatchHeaderLayout = RecordLayout{"IATBatchHeader", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"ServiceClassCode", 2, 4, FieldNumeric, true},
+	{"IATIndicator", 5, 20, FieldAlphanumeric, false},
+	{"ForeignExchangeIndicator", 21, 22, FieldAlphanumeric, true},
+	{"ForeignExchangeReferenceIndicator", 23, 23, FieldNumeric, true},
+	{"ForeignExchangeReference", 24, 38, FieldAlphanumeric, true},
+	{"ISODestinationCountryCode", 39, 40, FieldAlphanumeric, true},
+	{"OriginatorIdentification", 41, 50, FieldAlphanumeric, true},
+	{"StandardEntryClassCode", 51, 53, FieldAlphanumeric, true},
+	{"CompanyEntryDescription", 54, 63, FieldAlphanumeric, true},
+	{"ISOOriginatingCurrencyCode", 64, 66, FieldAlphanumeric, true},
+	{"ISODestinationCurrencyCode", 67, 69, FieldAlphanumeric, true},
+	{"EffectiveEntryDate", 70, 75, FieldNumeric, true},
+	{"settlementDate", 76, 78, FieldNumeric, false},
+	{"OriginatorStatusCode", 79, 79, FieldAlphanumeric, true},
+	{"ODFIIdentification", 80, 87, FieldNumeric, true},
+	{"BatchNumber", 88, 94, FieldNumeric, true},
+}}
+
+// iatEntryDetailLayout is the layout of IATEntryDetail
+var iatEntryDetailLayout = RecordLayout{"IATEntryDetail", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"TransactionCode", 2, 3, FieldNumeric, true},
+	{"RDFIIdentification", 4, 11, FieldNumeric, true},
+	{"CheckDigit", 12, 12, FieldNumeric, true},
+	{"AddendaRecords", 13, 16, FieldNumeric, true},
+	{"reserved", 17, 29, FieldBlank, false},
+	{"Amount", 30, 39, FieldNumeric, true},
+	{"DFIAccountNumber", 40, 74, FieldAlphanumeric, true},
+	{"reservedTwo", 75, 76, FieldBlank, false},
+	{"OFACSreeningIndicator", 77, 77, FieldAlphanumeric, false},
+	{"SecondaryOFACSreeningIndicator", 78, 78, FieldAlphanumeric, false},
+	{"AddendaRecordIndicator", 79, 79, FieldNumeric, true},
+	{"TraceNumber", 80, 94, FieldNumeric, true},
+}}
+
+// addenda02Layout is the layout of Addenda02
+var addenda02Layout = RecordLayout{"Addenda02", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ReferenceInformationOne", 4, 10, FieldAlphanumeric, false},
+	{"ReferenceInformationTwo", 11, 13, FieldAlphanumeric, false},
+	{"TerminalIdentificationCode", 14, 19, FieldAlphanumeric, true},
+	{"TransactionSerialNumber", 20, 25, FieldAlphanumeric, true},
+	{"TransactionDate", 26, 29, FieldNumeric, true},
+	{"AuthorizationCodeOrExpireDate", 30, 35, FieldAlphanumeric, false},
+	{"TerminalLocation", 36, 62, FieldAlphanumeric, true},
+	{"TerminalCity", 63, 77, FieldAlphanumeric, true},
+	{"TerminalState", 78, 79, FieldAlphanumeric, true},
+	{"TraceNumber", 80, 94, FieldNumeric, true},
+}}
+
+// addenda05Layout is the layout of Addenda05
+var addenda05Layout = RecordLayout{"Addenda05", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"PaymentRelatedInformation", 4, 83, FieldAlphanumeric, false},
+	{"SequenceNumber", 84, 87, FieldNumeric, true},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda10Layout is the layout of Addenda10
+var addenda10Layout = RecordLayout{"Addenda10", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"TransactionTypeCode", 4, 6, FieldAlphanumeric, true},
+	{"ForeignPaymentAmount", 7, 24, FieldNumeric, true},
+	{"ForeignTraceNumber", 25, 46, FieldAlphanumeric, false},
+	{"Name", 47, 81, FieldAlphanumeric, true},
+	{"reserved", 82, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda11Layout is the layout of Addenda11
+var addenda11Layout = RecordLayout{"Addenda11", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"OriginatorName", 4, 38, FieldAlphanumeric, true},
+	{"OriginatorStreetAddress", 39, 73, FieldAlphanumeric, true},
+	{"reserved", 74, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda12Layout is the layout of Addenda12
+var addenda12Layout = RecordLayout{"Addenda12", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"OriginatorCityStateProvince", 4, 38, FieldAlphanumeric, true},
+	{"OriginatorCountryPostalCode", 39, 73, FieldAlphanumeric, true},
+	{"reserved", 74, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda13Layout is the layout of Addenda13
+var addenda13Layout = RecordLayout{"Addenda13", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ODFIName", 4, 38, FieldAlphanumeric, true},
+	{"ODFIIDNumberQualifier", 39, 40, FieldAlphanumeric, true},
+	{"ODFIIdentification", 41, 74, FieldAlphanumeric, true},
+	{"ODFIBranchCountryCode", 75, 77, FieldAlphanumeric, true},
+	{"reserved", 78, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda14Layout is the layout of Addenda14
+var addenda14Layout = RecordLayout{"Addenda14", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"RDFIName", 4, 38, FieldAlphanumeric, true},
+	{"RDFIIDNumberQualifier", 39, 40, FieldAlphanumeric, true},
+	{"RDFIIdentification", 41, 74, FieldAlphanumeric, true},
+	{"RDFIBranchCountryCode", 75, 77, FieldAlphanumeric, true},
+	{"reserved", 78, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda15Layout is the layout of Addenda15
+var addenda15Layout = RecordLayout{"Addenda15", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ReceiverIDNumber", 4, 18, FieldAlphanumeric, false},
+	{"ReceiverStreetAddress", 19, 53, FieldAlphanumeric, true},
+	{"reserved", 54, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda16Layout is the layout of Addenda16
+var addenda16Layout = RecordLayout{"Addenda16", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ReceiverCityStateProvince", 4, 38, FieldAlphanumeric, true},
+	{"ReceiverCountryPostalCode", 39, 73, FieldAlphanumeric, true},
+	{"reserved", 74, 87, FieldBlank, false},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda17Layout is the layout of Addenda17
+var addenda17Layout = RecordLayout{"Addenda17", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"PaymentRelatedInformation", 4, 83, FieldAlphanumeric, false},
+	{"SequenceNumber", 84, 87, FieldNumeric, true},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda18Layout is the layout of Addenda18
+var addenda18Layout = RecordLayout{"Addenda18", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ForeignCorrespondentBankName", 4, 38, FieldAlphanumeric, true},
+	{"ForeignCorrespondentBankIDNumberQualifier", 39, 40, FieldAlphanumeric, true},
+	{"ForeignCorrespondentBankIDNumber", 41, 74, FieldAlphanumeric, true},
+	{"ForeignCorrespondentBankBranchCountryCode", 75, 77, FieldAlphanumeric, true},
+	{"reserved", 78, 83, FieldBlank, false},
+	{"SequenceNumber", 84, 87, FieldNumeric, true},
+	{"EntryDetailSequenceNumber", 88, 94, FieldNumeric, true},
+}}
+
+// addenda98Layout is the layout of Addenda98
+var addenda98Layout = RecordLayout{"Addenda98", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ChangeCode", 4, 6, FieldAlphanumeric, true},
+	{"OriginalTrace", 7, 21, FieldNumeric, true},
+	{"reserved", 22, 27, FieldBlank, false},
+	{"OriginalDFI", 28, 35, FieldNumeric, true},
+	{"CorrectedData", 36, 64, FieldAlphanumeric, true},
+	{"reserved", 65, 79, FieldBlank, false},
+	{"TraceNumber", 80, 94, FieldNumeric, true},
+}}
+
+// addenda99Layout is the layout of Addenda99
+var addenda99Layout = RecordLayout{"Addenda99", []FieldLayout{
+	{"recordType", 1, 1, FieldNumeric, true},
+	{"typeCode", 2, 3, FieldNumeric, true},
+	{"ReturnCode", 4, 6, FieldAlphanumeric, true},
+	{"OriginalTrace", 7, 21, FieldNumeric, true},
+	{"DateOfDeath", 22, 27, FieldNumeric, false},
+	{"OriginalDFI", 28, 35, FieldNumeric, true},
+	{"AddendaInformation", 36, 79, FieldAlphanumeric, false},
+	{"TraceNumber", 80, 94, FieldNumeric, true},
+}}
+
+// addendaLayouts are the addenda layouts by their type code
+var addendaLayouts = map[string]*RecordLayout{
+	"02": &addenda02Layout,
+	"05": &addenda05Layout,
+	"10": &addenda10Layout,
+	"11": &addenda11Layout,
+	"12": &addenda12Layout,
+	"13": &addenda13Layout,
+	"14": &addenda14Layout,
+	"15": &addenda15Layout,
+	"16": &addenda16Layout,
+	"17": &addenda17Layout,
+	"18": &addenda18Layout,
+	"98": &addenda98Layout,
+	"99": &addenda99Layout,
+}
+
+// recordLayouts are all record layouts in the order of RecordLayouts
+var recordLayouts = []*RecordLayout{
+	&fileHeaderLayout,
+	&batchHeaderLayout,
+	&iatBatchHeaderLayout,
+	&entryDetailLayout,
+	&iatEntryDetailLayout,
+	&addenda02Layout,
+	&addenda05Layout,
+	&addenda10Layout,
+	&addenda11Layout,
+	&addenda12Layout,
+	&addenda13Layout,
+	&addenda14Layout,
+	&addenda15Layout,
+	&addenda16Layout,
+	&addenda17Layout,
+	&addenda18Layout,
+	&addenda98Layout,
+	&addenda99Layout,
+	&batchControlLayout,
+	&fileControlLayout,
+	&fillerLayout,
+}
+
+// RecordLayouts returns the layouts of every record type
+func RecordLayouts() []RecordLayout {
+	layouts := make([]RecordLayout, 0, len(recordLayouts))
+	for _, layout := range recordLayouts {
+		layouts = append(layouts, layout.copy())
+	}
+	return layouts
+}
+
+// LookupRecordLayout returns the layout of the record type name, such as EntryDetail or
+// Addenda98. The returned bool is false if there is no record type name.
+func LookupRecordLayout(name string) (RecordLayout, bool) {
+	for _, layout := range recordLayouts {
+		if layout.Name == name {
+			return layout.copy(), true
+		}
+	}
+	return RecordLayout{}, false
+}
+
+// copy returns a copy of the layout, so the package layouts cannot be modified by callers
+func (layout *RecordLayout) copy() RecordLayout {
+	fields := make([]FieldLayout, len(layout.Fields))
+	copy(fields, layout.Fields)
+	return RecordLayout{Name: layout.Name, Fields: fields}
+}
+
+// Decode splits line into the fields of the layout
+func (layout RecordLayout) Decode(line string) (*DecodedRecord, error) {
+	if len(line) != RecordLength {
+		msg := fmt.Sprintf(msgRecordLength, len(line))
+		return nil, &FileError{FieldName: "RecordLength", Value: strconv.Itoa(len(line)), Msg: msg, Code: ErrRecordLength.Code}
+	}
+	record := &DecodedRecord{Record: layout.Name, Fields: make([]DecodedField, 0, len(layout.Fields))}
+	for _, field := range layout.Fields {
+		value := line[field.Start-1 : field.End]
+		if field.Type != FieldNumeric {
+			value = strings.TrimSpace(value)
+		}
+		record.Fields = append(record.Fields, DecodedField{FieldLayout: field, Value: value})
+	}
+	return record, nil
+}
+
+// DecodeRecord returns the fields of a raw 94 character record with their positions. The
+// record type is chosen from the line the same way Reader does, so IAT batch headers and
+// entries are told apart from domestic records by their content.
+func DecodeRecord(line string) (*DecodedRecord, error) {
+	if len(line) != RecordLength {
+		msg := fmt.Sprintf(msgRecordLength, len(line))
+		return nil, &FileError{FieldName: "RecordLength", Value: strconv.Itoa(len(line)), Msg: msg, Code: ErrRecordLength.Code}
+	}
+	layout, err := recordLayoutOf(line)
+	if err != nil {
+		return nil, err
+	}
+	return layout.Decode(line)
+}
+
+// recordLayoutOf returns the layout of line
+func recordLayoutOf(line string) (*RecordLayout, error) {
+	switch line[:1] {
+	case fileHeaderPos:
+		return &fileHeaderLayout, nil
+	case batchHeaderPos:
+		if line[50:53] == "IAT" {
+			return &iatBatchHeaderLayout, nil
+		}
+		return &batchHeaderLayout, nil
+	case entryDetailPos:
+		if line[16:29] == "             " {
+			return &iatEntryDetailLayout, nil
+		}
+		return &entryDetailLayout, nil
+	case entryAddendaPos:
+		if layout, ok := addendaLayouts[line[1:3]]; ok {
+			return layout, nil
+		}
+		msg := fmt.Sprintf(msgLayoutAddendaTypeCode, line[1:3])
+		return nil, &FileError{FieldName: "typeCode", Value: line[1:3], Msg: msg, Code: ErrInvalidAddendaTypeCode.Code}
+	case batchControlPos:
+		return &batchControlLayout, nil
+	case fileControlPos:
+		if line[:2] == "99" {
+			return &fillerLayout, nil
+		}
+		return &fileControlLayout, nil
+	}
+	msg := fmt.Sprintf(msgUnknownRecordType, line[:1])
+	return nil, &FileError{FieldName: "recordType", Value: line[:1], Msg: msg, Code: ErrUnknownRecordType.Code}
+}
+
+// fieldPositions returns the first and last positions in line of the field named by err, or
+// zeros if err does not name a field of the layout of line
+func fieldPositions(line string, err error) (int, int) {
+	var name string
+	var fieldErr *FieldError
+	var batchErr *BatchError
+	var fileErr *FileError
+	switch {
+	case errors.As(err, &fieldErr):
+		name = fieldErr.FieldName
+	case errors.As(err, &batchErr):
+		name = batchErr.FieldName
+	case errors.As(err, &fileErr):
+		name = fileErr.FieldName
+	}
+	if name == "" || len(line) != RecordLength {
+		return 0, 0
+	}
+	layout, e := recordLayoutOf(line)
+	if e != nil {
+		return 0, 0
+	}
+	for _, field := range layout.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field.Start, field.End
+		}
+	}
+	return 0, 0
+}
@@ -0,0 +1,291 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testRecordLayouts validates every record layout covers the 94 positions of a record
+func testRecordLayouts(t testing.TB) {
+	layouts := RecordLayouts()
+	if len(layouts) != 21 {
+		t.Errorf("expected 21 layouts got %d", len(layouts))
+	}
+	for _, layout := range layouts {
+		next := 1
+		for _, field := range layout.Fields {
+			if field.Start != next || field.End < field.Start {
+				t.Errorf("%s %s: positions %d-%d", layout.Name, field.Name, field.Start, field.End)
+			}
+			if field.Type == FieldBlank && field.Required {
+				t.Errorf("%s %s: blank field is required", layout.Name, field.Name)
+			}
+			next = field.End + 1
+		}
+		if next != RecordLength+1 {
+			t.Errorf("%s: fields end at %d", layout.Name, next-1)
+		}
+	}
+
+	layout, ok := LookupRecordLayout("Addenda98")
+	if !ok {
+		t.Fatal("Addenda98 layout not found")
+	}
+	if f := layout.Fields[5]; f.Name != "OriginalDFI" || f.Start != 28 || f.End != 35 || f.Type != FieldNumeric {
+		t.Errorf("unexpected field %#v", f)
+	}
+	layout.Fields[5].Name = "changed"
+	if layout, _ := LookupRecordLayout("Addenda98"); layout.Fields[5].Name != "OriginalDFI" {
+		t.Error("layout was modified by a caller")
+	}
+	if _, ok := LookupRecordLayout("Addenda01"); ok {
+		t.Error("expected no Addenda01 layout")
+	}
+}
+
+// TestRecordLayouts tests every record layout covers the 94 positions of a record
+func TestRecordLayouts(t *testing.T) {
+	testRecordLayouts(t)
+}
+
+// BenchmarkRecordLayouts benchmarks every record layout covers the 94 positions of a record
+func BenchmarkRecordLayouts(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testRecordLayouts(b)
+	}
+}
+
+// layoutField returns the value of the named layout field of a parsed record from its Field
+// accessor, or from the struct field for fields without one
+func layoutField(record interface{}, name string) (string, bool) {
+	v := reflect.ValueOf(record)
+	if m := v.MethodByName(name + "Field"); m.IsValid() {
+		return m.Call(nil)[0].String(), true
+	}
+	f := v.Elem().FieldByName(name)
+	switch {
+	case !f.IsValid():
+		return "", false
+	case f.Kind() == reflect.Int:
+		return strconv.FormatInt(f.Int(), 10), true
+	default:
+		return f.String(), true
+	}
+}
+
+// testRecordLayoutsMatchParse validates the position of every layout field against the
+// value parsed by its record type
+func testRecordLayoutsMatchParse(t testing.TB) {
+	fh := mockFileHeader()
+	fc := mockFileControl()
+	tests := []struct {
+		line   string
+		parsed interface{ Parse(string) }
+		layout *RecordLayout
+	}{
+		{fh.String(), &FileHeader{}, &fileHeaderLayout},
+		{mockBatchHeader().String(), &BatchHeader{}, &batchHeaderLayout},
+		{mockIATBatchHeaderFF().String(), &IATBatchHeader{}, &iatBatchHeaderLayout},
+		{mockEntryDetail().String(), &EntryDetail{}, &entryDetailLayout},
+		{mockIATEntryDetail().String(), &IATEntryDetail{}, &iatEntryDetailLayout},
+		{mockAddenda02().String(), &Addenda02{}, &addenda02Layout},
+		{mockAddenda05().String(), &Addenda05{}, &addenda05Layout},
+		{mockAddenda10().String(), &Addenda10{}, &addenda10Layout},
+		{mockAddenda11().String(), &Addenda11{}, &addenda11Layout},
+		{mockAddenda12().String(), &Addenda12{}, &addenda12Layout},
+		{mockAddenda13().String(), &Addenda13{}, &addenda13Layout},
+		{mockAddenda14().String(), &Addenda14{}, &addenda14Layout},
+		{mockAddenda15().String(), &Addenda15{}, &addenda15Layout},
+		{mockAddenda16().String(), &Addenda16{}, &addenda16Layout},
+		{mockAddenda17().String(), &Addenda17{}, &addenda17Layout},
+		{mockAddenda18().String(), &Addenda18{}, &addenda18Layout},
+		{mockAddenda98().String(), &Addenda98{}, &addenda98Layout},
+		{mockAddenda99().String(), &Addenda99{}, &addenda99Layout},
+		{mockBatchControl().String(), &BatchControl{}, &batchControlLayout},
+		{fc.String(), &FileControl{}, &fileControlLayout},
+	}
+	for _, test := range tests {
+		line := test.line
+		test.parsed.Parse(line)
+		for _, field := range test.layout.Fields {
+			want := line[field.Start-1 : field.End]
+			got, ok := layoutField(test.parsed, field.Name)
+			switch {
+			case !ok:
+				// blank fields without a struct field are only written by String
+				got, want = "", strings.TrimSpace(want)
+			case field.Type == FieldNumeric && len(got) != len(want):
+				// numeric struct fields without an accessor hold the value without zero padding
+				n, err := strconv.Atoi(want)
+				if err != nil {
+					t.Errorf("%s %s: %q is not numeric", test.layout.Name, field.Name, want)
+				}
+				want = strconv.Itoa(n)
+			case len(got) != len(want):
+				// alphanumeric struct fields without an accessor are parsed without padding
+				got, want = strings.TrimSpace(got), strings.TrimSpace(want)
+			}
+			if got != want {
+				t.Errorf("%s %s %d-%d: got %q want %q", test.layout.Name, field.Name, field.Start, field.End, got, want)
+			}
+		}
+	}
+}
+
+// TestRecordLayoutsMatchParse tests the position of every layout field against the value
+// parsed by its record type
+func TestRecordLayoutsMatchParse(t *testing.T) {
+	testRecordLayoutsMatchParse(t)
+}
+
+// BenchmarkRecordLayoutsMatchParse benchmarks the position of every layout field against
+// the value parsed by its record type
+func BenchmarkRecordLayoutsMatchParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testRecordLayoutsMatchParse(b)
+	}
+}
+
+// testDecodeRecord validates decoding the records built by each record type
+func testDecodeRecord(t testing.TB) {
+	fh := mockFileHeader()
+	fc := mockFileControl()
+	tests := []struct {
+		line   string
+		record string
+	}{
+		{fh.String(), "FileHeader"},
+		{mockBatchHeader().String(), "BatchHeader"},
+		{mockIATBatchHeaderFF().String(), "IATBatchHeader"},
+		{mockEntryDetail().String(), "EntryDetail"},
+		{mockIATEntryDetail().String(), "IATEntryDetail"},
+		{mockAddenda02().String(), "Addenda02"},
+		{mockAddenda05().String(), "Addenda05"},
+		{mockAddenda10().String(), "Addenda10"},
+		{mockAddenda11().String(), "Addenda11"},
+		{mockAddenda12().String(), "Addenda12"},
+		{mockAddenda13().String(), "Addenda13"},
+		{mockAddenda14().String(), "Addenda14"},
+		{mockAddenda15().String(), "Addenda15"},
+		{mockAddenda16().String(), "Addenda16"},
+		{mockAddenda17().String(), "Addenda17"},
+		{mockAddenda18().String(), "Addenda18"},
+		{mockAddenda98().String(), "Addenda98"},
+		{mockAddenda99().String(), "Addenda99"},
+		{mockBatchControl().String(), "BatchControl"},
+		{fc.String(), "FileControl"},
+		{strings.Repeat("9", RecordLength), "Filler"},
+	}
+	for _, test := range tests {
+		record, err := DecodeRecord(test.line)
+		if err != nil {
+			t.Fatalf("%s: %T: %s", test.record, err, err)
+		}
+		if record.Record != test.record {
+			t.Errorf("got %s want %s", record.Record, test.record)
+		}
+	}
+
+	ed := mockEntryDetail()
+	record, err := DecodeRecord(ed.String())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	values := make(map[string]DecodedField)
+	for _, field := range record.Fields {
+		values[field.Name] = field
+	}
+	if f := values["DFIAccountNumber"]; f.Value != strings.TrimSpace(ed.DFIAccountNumberField()) || f.Start != 13 || f.End != 29 {
+		t.Errorf("unexpected DFIAccountNumber %#v", f)
+	}
+	if f := values["Amount"]; f.Value != ed.AmountField() {
+		t.Errorf("unexpected Amount %#v", f)
+	}
+	if f := values["TraceNumber"]; f.Value != ed.TraceNumberField() {
+		t.Errorf("unexpected TraceNumber %#v", f)
+	}
+
+	addenda98 := mockAddenda98()
+	record, err = DecodeRecord(addenda98.String())
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if f := record.Fields[5]; f.Name != "OriginalDFI" || f.Value != addenda98.OriginalDFIField() {
+		t.Errorf("unexpected OriginalDFI %#v", f)
+	}
+}
+
+// TestDecodeRecord tests decoding the records built by each record type
+func TestDecodeRecord(t *testing.T) {
+	testDecodeRecord(t)
+}
+
+// BenchmarkDecodeRecord benchmarks decoding the records built by each record type
+func BenchmarkDecodeRecord(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testDecodeRecord(b)
+	}
+}
+
+// testDecodeRecordFile validates decoding every line of files and the errors of invalid lines
+func testDecodeRecordFile(t testing.TB) {
+	for _, name := range []string{"./test/data/ppd-debit.ach", "./test/data/20180716-IAT-A17-A18.ach"} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			record, err := DecodeRecord(line)
+			if err != nil {
+				t.Fatalf("%s: %T: %s", name, err, err)
+			}
+			var raw strings.Builder
+			for _, field := range record.Fields {
+				raw.WriteString(line[field.Start-1 : field.End])
+			}
+			if raw.String() != line {
+				t.Errorf("%s: %s fields do not cover %q", name, record.Record, line)
+			}
+		}
+		f.Close()
+	}
+
+	tests := map[string]string{
+		"short":   "101 031300012",
+		"addenda": "701" + strings.Repeat(" ", RecordLength-3),
+		"type":    "3" + strings.Repeat(" ", RecordLength-1),
+	}
+	for name, line := range tests {
+		if _, err := DecodeRecord(line); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if _, ok := err.(*FileError); !ok {
+			t.Errorf("%s: %T: %s", name, err, err)
+		}
+	}
+}
+
+// TestDecodeRecordFile tests decoding every line of files and the errors of invalid lines
+func TestDecodeRecordFile(t *testing.T) {
+	testDecodeRecordFile(t)
+}
+
+// BenchmarkDecodeRecordFile benchmarks decoding every line of files and the errors of invalid lines
+func BenchmarkDecodeRecordFile(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testDecodeRecordFile(b)
+	}
+}
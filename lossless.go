@@ -0,0 +1,54 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+// original is the line a record was read from by a lossless Reader. It keeps the reserved
+// positions and any content the record struct does not model, so an unchanged record is
+// written exactly as it was read.
+type original struct {
+	// line is the record as it was read
+	line string
+	// parsed is the String of the record when it was read
+	parsed string
+}
+
+// originalRecord is a record that keeps the line it was read from
+type originalRecord interface {
+	String() string
+	keepOriginal(line, parsed string)
+	originalLine(record []byte) (string, bool)
+}
+
+// keepOriginal records line as the original of a record whose String was parsed
+func (o *original) keepOriginal(line, parsed string) {
+	o.line = line
+	o.parsed = parsed
+}
+
+// originalLine returns the original line of a record written as record if the record has
+// not been modified since it was read
+func (o *original) originalLine(record []byte) (string, bool) {
+	if o.line != "" && string(record) == o.parsed {
+		return o.line, true
+	}
+	return "", false
+}
+
+// appendRecord appends the line the Writer writes for record to b. Records without AppendTo
+// are appended from their String.
+func appendRecord(b []byte, record interface{ String() string }) []byte {
+	start := len(b)
+	if r, ok := record.(interface{ AppendTo([]byte) []byte }); ok {
+		b = r.AppendTo(b)
+	} else {
+		b = append(b, record.String()...)
+	}
+	if r, ok := record.(originalRecord); ok {
+		if line, ok := r.originalLine(b[start:]); ok {
+			b = append(b[:start], line...)
+		}
+	}
+	return b
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// mockLosslessFile returns the lines of a file with content in positions the records do not model
+func mockLosslessFile(t testing.TB) []string {
+	bs, err := ioutil.ReadFile("./test/data/ppd-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(string(bs), "\n"), "\n")
+	// settlement date of the batch header inserted by the ACH Operator
+	lines[1] = lines[1][:75] + "123" + lines[1][78:]
+	// reserved positions of the batch control
+	lines[3] = lines[3][:73] + "ABCDEF" + lines[3][79:]
+	return lines
+}
+
+// writeLossless reads data with a lossless Reader and writes it with the format of the Reader
+func writeLossless(t testing.TB, data string, modify func(*File)) string {
+	r := NewReader(strings.NewReader(data))
+	r.SetLossless(true)
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if modify != nil {
+		modify(&file)
+	}
+	b := &bytes.Buffer{}
+	w := NewWriter(b)
+	w.LineEnding = r.LineEnding
+	w.BlockPadding = r.BlockPadding
+	w.BlockingFactor = r.BlockingFactor
+	if err := w.Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return b.String()
+}
+
+// testReaderLossless validates a file read by a lossless Reader is written unchanged
+func testReaderLossless(t testing.TB) {
+	lines := mockLosslessFile(t)
+	data := strings.Join(lines, "\r\n") + "\r\n"
+	if out := writeLossless(t, data, nil); out != data {
+		t.Errorf("lossless file changed\n%s\n%s", data, out)
+	}
+
+	// without lossless the unmodelled positions are lost
+	file, err := NewReader(strings.NewReader(data)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if s := file.Batches[0].GetControl().String(); s == lines[3] {
+		t.Errorf("unexpected batch control %q", s)
+	}
+
+	// modified records are written from their fields
+	out := writeLossless(t, data, func(file *File) {
+		file.Batches[0].GetEntries()[0].IndividualName = "Wade Arnold"
+	})
+	written := strings.Split(out, "\r\n")
+	if written[1] != lines[1] || written[3] != lines[3] {
+		t.Errorf("unmodified records changed\n%s", out)
+	}
+	if written[2] == lines[2] || !strings.Contains(written[2], "Wade Arnold") {
+		t.Errorf("modified entry %q", written[2])
+	}
+}
+
+// TestReaderLossless tests a file read by a lossless Reader is written unchanged
+func TestReaderLossless(t *testing.T) {
+	testReaderLossless(t)
+}
+
+// BenchmarkReaderLossless benchmarks a file read by a lossless Reader is written unchanged
+func BenchmarkReaderLossless(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderLossless(b)
+	}
+}
+
+// testReaderLosslessIAT validates an IAT file read by a lossless Reader is written unchanged
+func testReaderLosslessIAT(t testing.TB) {
+	bs, err := ioutil.ReadFile("./test/data/20180716-IAT-A17-A18.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(string(bs), "\n"), "\n")
+	for i, line := range lines {
+		// reserved positions of the Addenda11
+		if strings.HasPrefix(line, "711") {
+			lines[i] = line[:73] + "RESERVED" + line[81:]
+		}
+	}
+	data := strings.Join(lines, "\n") + "\n"
+	if out := writeLossless(t, data, nil); out != data {
+		t.Errorf("lossless file changed\n%s\n%s", data, out)
+	}
+}
+
+// TestReaderLosslessIAT tests an IAT file read by a lossless Reader is written unchanged
+func TestReaderLosslessIAT(t *testing.T) {
+	testReaderLosslessIAT(t)
+}
+
+// BenchmarkReaderLosslessIAT benchmarks an IAT file read by a lossless Reader is written unchanged
+func BenchmarkReaderLosslessIAT(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderLosslessIAT(b)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+// Masked returns a copy of the file with account numbers, card numbers and identification
+// numbers replaced by their last four characters, for JSON responses and logs. The file is
+// not modified. Masked files are for display and are not meant to be transmitted.
+//
+// The DFIAccountNumber of every entry, the IdentificationNumber of every entry except the
+// check serial numbers of ARC, BOC, POP and RCK, the card account number of SHR entries, the
+// ReceiverIDNumber of IAT Addenda15 and the corrected account numbers of NOC Addenda98 are
+// masked.
+//
+// Only batches of the Standard Entry Class Codes of NewBatch can be copied; the error of
+// NewBatch is returned for any other batch.
+func (f *File) Masked() (*File, error) {
+	out := NewFile()
+	out.ID = f.ID
+	out.Header = f.Header
+	out.Control = f.Control
+
+	for _, batch := range f.Batches {
+		bh := *batch.GetHeader()
+		masked, err := NewBatch(&bh)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range batch.GetEntries() {
+			masked.AddEntry(maskEntryDetail(entry, bh.StandardEntryClassCode))
+		}
+		if bc := batch.GetControl(); bc != nil {
+			control := *bc
+			masked.SetControl(&control)
+		}
+		out.AddBatch(masked)
+	}
+
+	for _, iatBatch := range f.IATBatches {
+		masked := iatBatch
+		if bh := iatBatch.GetHeader(); bh != nil {
+			header := *bh
+			masked.SetHeader(&header)
+		}
+		if bc := iatBatch.GetControl(); bc != nil {
+			control := *bc
+			masked.SetControl(&control)
+		}
+		masked.Entries = nil
+		for _, entry := range iatBatch.GetEntries() {
+			masked.AddEntry(maskIATEntryDetail(entry))
+		}
+		out.AddIATBatch(masked)
+	}
+	return out, nil
+}
+
+// maskEntryDetail returns a masked copy of an entry of a batch with Standard Entry Class Code sec
+func maskEntryDetail(ed *EntryDetail, sec string) *EntryDetail {
+	masked := *ed
+	masked.DFIAccountNumber = maskNumber(ed.DFIAccountNumber)
+	switch sec {
+	case "ARC", "BOC", "POP", "RCK":
+		// IdentificationNumber is the check serial number
+	case "SHR":
+		// IdentificationNumber is the card expiration date and document reference number and
+		// IndividualName is the card account number
+		masked.IdentificationNumber = maskNumber(ed.IdentificationNumber)
+		masked.IndividualName = maskNumber(ed.IndividualName)
+	default:
+		masked.IdentificationNumber = maskNumber(ed.IdentificationNumber)
+	}
+	masked.Addendum = maskAddendum(ed.Addendum)
+	return &masked
+}
+
+// maskIATEntryDetail returns a masked copy of an IAT entry
+func maskIATEntryDetail(ed *IATEntryDetail) *IATEntryDetail {
+	masked := *ed
+	masked.DFIAccountNumber = maskNumber(ed.DFIAccountNumber)
+	if ed.Addenda10 != nil {
+		addenda10 := *ed.Addenda10
+		masked.Addenda10 = &addenda10
+	}
+	if ed.Addenda11 != nil {
+		addenda11 := *ed.Addenda11
+		masked.Addenda11 = &addenda11
+	}
+	if ed.Addenda12 != nil {
+		addenda12 := *ed.Addenda12
+		masked.Addenda12 = &addenda12
+	}
+	if ed.Addenda13 != nil {
+		addenda13 := *ed.Addenda13
+		masked.Addenda13 = &addenda13
+	}
+	if ed.Addenda14 != nil {
+		addenda14 := *ed.Addenda14
+		masked.Addenda14 = &addenda14
+	}
+	if ed.Addenda15 != nil {
+		addenda15 := *ed.Addenda15
+		addenda15.ReceiverIDNumber = maskNumber(ed.Addenda15.ReceiverIDNumber)
+		masked.Addenda15 = &addenda15
+	}
+	if ed.Addenda16 != nil {
+		addenda16 := *ed.Addenda16
+		masked.Addenda16 = &addenda16
+	}
+	masked.Addendum = maskAddendum(ed.Addendum)
+	return &masked
+}
+
+// maskAddendum returns copies of the addenda of an entry. The CorrectedData of NOC change
+// codes that correct an account number is masked.
+func maskAddendum(addendum []Addendumer) []Addendumer {
+	if addendum == nil {
+		return nil
+	}
+	masked := make([]Addendumer, 0, len(addendum))
+	for _, addenda := range addendum {
+		switch a := addenda.(type) {
+		case *Addenda02:
+			c := *a
+			masked = append(masked, &c)
+		case *Addenda05:
+			c := *a
+			masked = append(masked, &c)
+		case *Addenda17:
+			c := *a
+			masked = append(masked, &c)
+		case *Addenda18:
+			c := *a
+			masked = append(masked, &c)
+		case *Addenda98:
+			c := *a
+			switch c.ChangeCode {
+			case "C01", "C03", "C06", "C07":
+				c.CorrectedData = maskNumber(c.CorrectedData)
+			}
+			masked = append(masked, &c)
+		case *Addenda99:
+			c := *a
+			masked = append(masked, &c)
+		default:
+			masked = append(masked, addenda)
+		}
+	}
+	return masked
+}
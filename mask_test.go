@@ -0,0 +1,108 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"testing"
+)
+
+// testFileMasked validates masking the account, card and identification numbers of a file
+func testFileMasked(t testing.TB) {
+	file := NewFile().SetHeader(mockFileHeader())
+	ppd := mockBatchPPD()
+	ppd.GetEntries()[0].IdentificationNumber = "123456789"
+	file.AddBatch(ppd)
+	shr := mockBatchSHR()
+	file.AddBatch(shr)
+	iatBatch := mockIATBatch()
+	file.AddIATBatch(iatBatch)
+
+	masked, err := file.Masked()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	entry := masked.Batches[0].GetEntries()[0]
+	if entry.DFIAccountNumber != "*****6789" || entry.IdentificationNumber != "*****6789" {
+		t.Errorf("PPD entry %q %q", entry.DFIAccountNumber, entry.IdentificationNumber)
+	}
+	if entry.IndividualName != ppd.GetEntries()[0].IndividualName {
+		t.Errorf("IndividualName %q", entry.IndividualName)
+	}
+	card := masked.Batches[1].GetEntries()[0].SHRIndividualCardAccountNumberField()
+	if card != maskNumber(shr.GetEntries()[0].IndividualName) || card[len(card)-4:] != shr.GetEntries()[0].IndividualName[18:] {
+		t.Errorf("SHR card account number %q", card)
+	}
+	iatEntry := masked.IATBatches[0].GetEntries()[0]
+	if iatEntry.DFIAccountNumber != maskNumber(iatBatch.GetEntries()[0].DFIAccountNumber) {
+		t.Errorf("IAT DFIAccountNumber %q", iatEntry.DFIAccountNumber)
+	}
+	if iatEntry.Addenda15.ReceiverIDNumber != "***********3987" {
+		t.Errorf("Addenda15 ReceiverIDNumber %q", iatEntry.Addenda15.ReceiverIDNumber)
+	}
+
+	// the original file is unchanged
+	if ppd.GetEntries()[0].DFIAccountNumber != "123456789" || iatBatch.GetEntries()[0].Addenda15.ReceiverIDNumber != "987465493213987" {
+		t.Error("original file was masked")
+	}
+	if masked.Batches[0] == file.Batches[0] || iatEntry.Addenda15 == iatBatch.GetEntries()[0].Addenda15 {
+		t.Error("masked file shares records with the original")
+	}
+
+	// a batch that can not be copied is an error rather than missing from the copy
+	file.Batches[0].GetHeader().StandardEntryClassCode = "XYZ"
+	if _, err := file.Masked(); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// TestFileMasked tests masking the account, card and identification numbers of a file
+func TestFileMasked(t *testing.T) {
+	testFileMasked(t)
+}
+
+// BenchmarkFileMasked benchmarks masking the account, card and identification numbers of a file
+func BenchmarkFileMasked(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testFileMasked(b)
+	}
+}
+
+// testMaskAddendum validates masking corrected account numbers of NOC addenda
+func testMaskAddendum(t testing.TB) {
+	addenda98 := mockAddenda98()
+	addenda98.ChangeCode = "C01"
+	addenda98.CorrectedData = "1918171614"
+	addenda05 := mockAddenda05()
+	masked := maskAddendum([]Addendumer{addenda98, addenda05})
+	if a := masked[0].(*Addenda98); a.CorrectedData != "******1614" || a == addenda98 {
+		t.Errorf("Addenda98 CorrectedData %q", a.CorrectedData)
+	}
+	if masked[1].(*Addenda05) == addenda05 {
+		t.Error("Addenda05 was not copied")
+	}
+
+	addenda98.ChangeCode = "C05"
+	addenda98.CorrectedData = "22"
+	if a := maskAddendum([]Addendumer{addenda98})[0].(*Addenda98); a.CorrectedData != "22" {
+		t.Errorf("Addenda98 CorrectedData %q", a.CorrectedData)
+	}
+	if maskAddendum(nil) != nil {
+		t.Error("expected nil addendum")
+	}
+}
+
+// TestMaskAddendum tests masking corrected account numbers of NOC addenda
+func TestMaskAddendum(t *testing.T) {
+	testMaskAddendum(t)
+}
+
+// BenchmarkMaskAddendum benchmarks masking corrected account numbers of NOC addenda
+func BenchmarkMaskAddendum(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testMaskAddendum(b)
+	}
+}
@@ -0,0 +1,424 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// DefaultScreeningThreshold is the Jaro-Winkler similarity of names at or above which an SDNList
+// reports a match
+const DefaultScreeningThreshold = 0.92
+
+// OFAC screening indicators of an IATEntryDetail
+const (
+	// OFACNoMatch indicates the parties of the entry were screened without a match
+	OFACNoMatch = "0"
+	// OFACMatch indicates a party of the entry was screened and is a potential match
+	OFACMatch = "1"
+)
+
+// Screening roles of the parties of an IAT entry
+const (
+	ScreeningReceiver      = "Receiver"
+	ScreeningOriginator    = "Originator"
+	ScreeningODFI          = "ODFI"
+	ScreeningRDFI          = "RDFI"
+	ScreeningCorrespondent = "Correspondent"
+)
+
+// ScreeningParty is a name and address of an IAT entry screened against sanctions lists
+type ScreeningParty struct {
+	// Role is the part the party plays in the entry, such as ScreeningReceiver
+	Role string `json:"role"`
+	// Name is the name of the party
+	Name string `json:"name"`
+	// Address is the street address of the party
+	Address string `json:"address,omitempty"`
+	// City is the city and state or province of the party
+	City string `json:"city,omitempty"`
+	// Country is the country and postal code, or the branch country code, of the party
+	Country string `json:"country,omitempty"`
+}
+
+// ScreeningMatch is a potential match of a party on a sanctions list
+type ScreeningMatch struct {
+	// TraceNumber is the trace number of the entry of the party
+	TraceNumber int `json:"traceNumber"`
+	// Party is the screened party
+	Party ScreeningParty `json:"party"`
+	// EntityID is the identifier of the listed entity
+	EntityID string `json:"entityID"`
+	// EntityName is the listed name or alias the party matched
+	EntityName string `json:"entityName"`
+	// Score is the similarity of the names from 0 to 1
+	Score float64 `json:"score"`
+}
+
+// Screener screens the parties of IAT entries against sanctions lists. An IATBatch with a Screener
+// screens every entry on Validate and sets its OFAC screening indicators.
+type Screener interface {
+	// Screen returns the potential matches of party, if any
+	Screen(party ScreeningParty) ([]ScreeningMatch, error)
+}
+
+// screeningParties returns the originator, receiver and correspondent names and addresses of
+// Addenda10-16 and Addenda18 as primary parties and secondary (correspondent) parties
+func screeningParties(entry *IATEntryDetail) (primary, secondary []ScreeningParty) {
+	receiver := ScreeningParty{Role: ScreeningReceiver}
+	originator := ScreeningParty{Role: ScreeningOriginator}
+	if entry.Addenda10 != nil {
+		receiver.Name = entry.Addenda10.Name
+	}
+	if entry.Addenda11 != nil {
+		originator.Name = entry.Addenda11.OriginatorName
+		originator.Address = entry.Addenda11.OriginatorStreetAddress
+	}
+	if entry.Addenda12 != nil {
+		originator.City = entry.Addenda12.OriginatorCityStateProvince
+		originator.Country = entry.Addenda12.OriginatorCountryPostalCode
+	}
+	if entry.Addenda15 != nil {
+		receiver.Address = entry.Addenda15.ReceiverStreetAddress
+	}
+	if entry.Addenda16 != nil {
+		receiver.City = entry.Addenda16.ReceiverCityStateProvince
+		receiver.Country = entry.Addenda16.ReceiverCountryPostalCode
+	}
+	primary = append(primary, originator, receiver)
+	if entry.Addenda13 != nil {
+		primary = append(primary, ScreeningParty{Role: ScreeningODFI, Name: entry.Addenda13.ODFIName, Country: entry.Addenda13.ODFIBranchCountryCode})
+	}
+	if entry.Addenda14 != nil {
+		primary = append(primary, ScreeningParty{Role: ScreeningRDFI, Name: entry.Addenda14.RDFIName, Country: entry.Addenda14.RDFIBranchCountryCode})
+	}
+	for _, addenda := range entry.Addendum {
+		if addenda18, ok := addenda.(*Addenda18); ok {
+			secondary = append(secondary, ScreeningParty{
+				Role:    ScreeningCorrespondent,
+				Name:    addenda18.ForeignCorrespondentBankName,
+				Country: addenda18.ForeignCorrespondentBankBranchCountryCode,
+			})
+		}
+	}
+	return primary, secondary
+}
+
+// screenEntry screens the parties of entry and sets OFACSreeningIndicator from the originator,
+// receiver and institutions, and SecondaryOFACSreeningIndicator from the correspondent banks.
+func screenEntry(s Screener, entry *IATEntryDetail) ([]ScreeningMatch, error) {
+	primary, secondary := screeningParties(entry)
+	var matches []ScreeningMatch
+	screen := func(parties []ScreeningParty) (string, error) {
+		indicator := OFACNoMatch
+		for _, party := range parties {
+			if strings.TrimSpace(party.Name) == "" {
+				continue
+			}
+			found, err := s.Screen(party)
+			if err != nil {
+				return indicator, err
+			}
+			for _, m := range found {
+				m.TraceNumber = entry.TraceNumber
+				matches = append(matches, m)
+				indicator = OFACMatch
+			}
+		}
+		return indicator, nil
+	}
+	indicator, err := screen(primary)
+	if err != nil {
+		return nil, err
+	}
+	secondaryIndicator, err := screen(secondary)
+	if err != nil {
+		return nil, err
+	}
+	entry.OFACSreeningIndicator = indicator
+	entry.SecondaryOFACSreeningIndicator = secondaryIndicator
+	return matches, nil
+}
+
+// SDN is an entity of the OFAC Specially Designated Nationals list
+type SDN struct {
+	// EntityID is the unique identifier of the entity (ent_num or uid)
+	EntityID string `json:"entityID"`
+	// Name is the listed name, "LAST, First" for individuals
+	Name string `json:"name"`
+	// Type is the entity type, such as individual or vessel
+	Type string `json:"type,omitempty"`
+	// Programs are the sanctions programs of the entity
+	Programs []string `json:"programs,omitempty"`
+	// Aliases are the alternate names of the entity
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// sdnName is a normalized name or alias of an SDN
+type sdnName struct {
+	sdn        *SDN
+	name       string
+	normalized string
+}
+
+// SDNList is a Screener of the OFAC Specially Designated Nationals list. Names are compared
+// with the Jaro-Winkler similarity after folding case and punctuation, and an individual
+// listed as "LAST, First" is also compared as "First LAST".
+type SDNList struct {
+	// Threshold is the similarity from 0 to 1 at or above which a name is a match
+	Threshold float64
+	entries   map[string]*SDN
+	names     []sdnName
+}
+
+// NewSDNList returns an empty SDNList with the DefaultScreeningThreshold
+func NewSDNList() *SDNList {
+	return &SDNList{
+		Threshold: DefaultScreeningThreshold,
+		entries:   make(map[string]*SDN),
+	}
+}
+
+// Add adds sdn and its aliases to the list
+func (l *SDNList) Add(sdn *SDN) {
+	l.entries[sdn.EntityID] = sdn
+	l.addName(sdn, sdn.Name)
+	for _, alias := range sdn.Aliases {
+		l.addName(sdn, alias)
+	}
+}
+
+// addName indexes a name of sdn, including the "First LAST" order of a "LAST, First" name
+func (l *SDNList) addName(sdn *SDN, name string) {
+	l.names = append(l.names, sdnName{sdn: sdn, name: name, normalized: normalizeName(name)})
+	if i := strings.Index(name, ","); i > 0 {
+		reordered := strings.TrimSpace(name[i+1:]) + " " + strings.TrimSpace(name[:i])
+		l.names = append(l.names, sdnName{sdn: sdn, name: name, normalized: normalizeName(reordered)})
+	}
+}
+
+// Len returns the number of entities of the list
+func (l *SDNList) Len() int {
+	return len(l.entries)
+}
+
+// Lookup returns the entity of entityID or nil
+func (l *SDNList) Lookup(entityID string) *SDN {
+	return l.entries[entityID]
+}
+
+// Screen returns the entities whose name or alias is similar to the name of party, with the best
+// score of each entity
+func (l *SDNList) Screen(party ScreeningParty) ([]ScreeningMatch, error) {
+	name := normalizeName(party.Name)
+	if name == "" {
+		return nil, nil
+	}
+	var matches []ScreeningMatch
+	index := make(map[string]int)
+	for _, n := range l.names {
+		score := jaroWinkler(name, n.normalized)
+		if score < l.Threshold {
+			continue
+		}
+		if i, ok := index[n.sdn.EntityID]; ok {
+			if score > matches[i].Score {
+				matches[i].Score = score
+				matches[i].EntityName = n.name
+			}
+			continue
+		}
+		index[n.sdn.EntityID] = len(matches)
+		matches = append(matches, ScreeningMatch{Party: party, EntityID: n.sdn.EntityID, EntityName: n.name, Score: score})
+	}
+	return matches, nil
+}
+
+// ReadSDNCSV reads the sdn.csv file of the OFAC SDN list: ent_num, SDN_Name, SDN_Type, Program,
+// followed by columns that are not used. Null values are "-0-".
+func ReadSDNCSV(r io.Reader) (*SDNList, error) {
+	l := NewSDNList()
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return l, nil
+		}
+		if err != nil {
+			return l, err
+		}
+		if len(record) < 2 || sdnValue(record[0]) == "" || sdnValue(record[1]) == "" {
+			continue
+		}
+		sdn := &SDN{EntityID: sdnValue(record[0]), Name: sdnValue(record[1])}
+		if len(record) > 2 {
+			sdn.Type = sdnValue(record[2])
+		}
+		if len(record) > 3 && sdnValue(record[3]) != "" {
+			for _, program := range strings.Split(sdnValue(record[3]), "] [") {
+				sdn.Programs = append(sdn.Programs, strings.Trim(program, "[] "))
+			}
+		}
+		l.Add(sdn)
+	}
+}
+
+// ReadAltCSV reads the alt.csv file of aliases of the OFAC SDN list: ent_num, alt_num, alt_type,
+// alt_name. Aliases of entities not in the list are skipped.
+func (l *SDNList) ReadAltCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) < 4 {
+			continue
+		}
+		sdn := l.entries[sdnValue(record[0])]
+		alias := sdnValue(record[3])
+		if sdn == nil || alias == "" {
+			continue
+		}
+		sdn.Aliases = append(sdn.Aliases, alias)
+		l.addName(sdn, alias)
+	}
+}
+
+// sdnXML is the sdn.xml document of the OFAC SDN list
+type sdnXML struct {
+	Entries []struct {
+		UID       string   `xml:"uid"`
+		FirstName string   `xml:"firstName"`
+		LastName  string   `xml:"lastName"`
+		Type      string   `xml:"sdnType"`
+		Programs  []string `xml:"programList>program"`
+		Aliases   []struct {
+			FirstName string `xml:"firstName"`
+			LastName  string `xml:"lastName"`
+		} `xml:"akaList>aka"`
+	} `xml:"sdnEntry"`
+}
+
+// ReadSDNXML reads the sdn.xml file of the OFAC SDN list, including the aliases of each entity
+func ReadSDNXML(r io.Reader) (*SDNList, error) {
+	l := NewSDNList()
+	doc := sdnXML{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return l, err
+	}
+	for _, e := range doc.Entries {
+		sdn := &SDN{EntityID: e.UID, Name: sdnXMLName(e.LastName, e.FirstName), Type: e.Type, Programs: e.Programs}
+		for _, aka := range e.Aliases {
+			sdn.Aliases = append(sdn.Aliases, sdnXMLName(aka.LastName, aka.FirstName))
+		}
+		l.Add(sdn)
+	}
+	return l, nil
+}
+
+// sdnXMLName returns "LAST, First" or the last name of an entity without a first name
+func sdnXMLName(last, first string) string {
+	last, first = strings.TrimSpace(last), strings.TrimSpace(first)
+	if first == "" {
+		return last
+	}
+	return last + ", " + first
+}
+
+// sdnValue trims a CSV value and returns "" for the null value -0-
+func sdnValue(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "-0-" {
+		return ""
+	}
+	return s
+}
+
+// normalizeName upper cases name, replaces punctuation with spaces and collapses spaces
+func normalizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return ' '
+	}, name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b from 0 to 1
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 || len(s2) == 0 {
+		return 0
+	}
+	window := len(s1)
+	if len(s2) > window {
+		window = len(s2)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+	matches := 0
+	for i := range s1 {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(s2) {
+			hi = len(s2)
+		}
+		for j := lo; j < hi; j++ {
+			if !matched2[j] && s1[i] == s2[j] {
+				matched1[i], matched2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if s1[i] != s2[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	jaro := (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < 4 && prefix < len(s1) && prefix < len(s2) && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,258 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+)
+
+// mockSDNList reads the SDN list and aliases of test data
+func mockSDNList(t testing.TB) *SDNList {
+	f, err := os.Open("./test/data/sdn.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	l, err := ReadSDNCSV(f)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	alt, err := os.Open("./test/data/alt.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer alt.Close()
+	if err := l.ReadAltCSV(alt); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return l
+}
+
+// errScreener is a Screener that always fails
+type errScreener struct{}
+
+func (errScreener) Screen(party ScreeningParty) ([]ScreeningMatch, error) {
+	return nil, errors.New("screening service unavailable")
+}
+
+// testReadSDNCSV validates reading the SDN list and aliases from CSV
+func testReadSDNCSV(t testing.TB) {
+	l := mockSDNList(t)
+	if l.Len() != 4 {
+		t.Fatalf("expected 4 entities got %d", l.Len())
+	}
+	sdn := l.Lookup("2674")
+	if sdn == nil || sdn.Type != "" || len(sdn.Programs) != 2 || sdn.Programs[1] != "IRAN" {
+		t.Errorf("unexpected entity %#v", sdn)
+	}
+	if sdn := l.Lookup("9999"); sdn == nil || len(sdn.Aliases) != 1 || sdn.Aliases[0] != "DOE, Jon" {
+		t.Errorf("unexpected aliases %#v", sdn)
+	}
+
+	tests := map[string]string{
+		"Johnathan Doe":          "9999",
+		"Jon Doe":                "9999",
+		"Aero Caribbean":         "36",
+		"Anglo Caribbean Co Ltd": "173",
+	}
+	for name, id := range tests {
+		matches, err := l.Screen(ScreeningParty{Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) != 1 || matches[0].EntityID != id {
+			t.Errorf("%s: unexpected matches %#v", name, matches)
+		}
+	}
+	for _, name := range []string{"Wade Arnold", "BEK Solutions", ""} {
+		if matches, _ := l.Screen(ScreeningParty{Name: name}); len(matches) != 0 {
+			t.Errorf("%s: unexpected matches %#v", name, matches)
+		}
+	}
+}
+
+// TestReadSDNCSV tests reading the SDN list and aliases from CSV
+func TestReadSDNCSV(t *testing.T) {
+	testReadSDNCSV(t)
+}
+
+// BenchmarkReadSDNCSV benchmarks reading the SDN list and aliases from CSV
+func BenchmarkReadSDNCSV(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReadSDNCSV(b)
+	}
+}
+
+// testReadSDNXML validates reading the SDN list from XML
+func testReadSDNXML(t testing.TB) {
+	f, err := os.Open("./test/data/sdn.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	l, err := ReadSDNXML(f)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if l.Len() != 2 {
+		t.Fatalf("expected 2 entities got %d", l.Len())
+	}
+	sdn := l.Lookup("9999")
+	if sdn.Name != "DOE, Johnathan" || sdn.Type != "Individual" || sdn.Programs[0] != "SDNTK" {
+		t.Errorf("unexpected entity %#v", sdn)
+	}
+	if sdn := l.Lookup("36"); len(sdn.Aliases) != 1 || sdn.Aliases[0] != "AERO-CARIBBEAN" {
+		t.Errorf("unexpected aliases %#v", sdn)
+	}
+	if matches, _ := l.Screen(ScreeningParty{Name: "Aero-Caribean"}); len(matches) != 1 {
+		t.Errorf("unexpected matches %#v", matches)
+	}
+}
+
+// TestReadSDNXML tests reading the SDN list from XML
+func TestReadSDNXML(t *testing.T) {
+	testReadSDNXML(t)
+}
+
+// BenchmarkReadSDNXML benchmarks reading the SDN list from XML
+func BenchmarkReadSDNXML(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReadSDNXML(b)
+	}
+}
+
+// testJaroWinkler validates the Jaro-Winkler similarity of names
+func testJaroWinkler(t testing.TB) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"MARTHA", "MARHTA", 0.961},
+		{"DWAYNE", "DUANE", 0.840},
+		{"DIXON", "DICKSONX", 0.813},
+		{"ABC", "ABC", 1},
+		{"ABC", "XYZ", 0},
+		{"", "ABC", 0},
+	}
+	for _, test := range tests {
+		if got := jaroWinkler(test.a, test.b); math.Abs(got-test.want) > 0.001 {
+			t.Errorf("%s %s: got %.3f want %.3f", test.a, test.b, got, test.want)
+		}
+	}
+	if got := normalizeName(" Anglo-Caribbean  Co., Ltd. "); got != "ANGLO CARIBBEAN CO LTD" {
+		t.Errorf("normalizeName %q", got)
+	}
+}
+
+// TestJaroWinkler tests the Jaro-Winkler similarity of names
+func TestJaroWinkler(t *testing.T) {
+	testJaroWinkler(t)
+}
+
+// BenchmarkJaroWinkler benchmarks the Jaro-Winkler similarity of names
+func BenchmarkJaroWinkler(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testJaroWinkler(b)
+	}
+}
+
+// testIATBatchScreener validates screening IAT entries sets the OFAC screening indicators
+func testIATBatchScreener(t testing.TB) {
+	mockBatch := IATBatch{}
+	mockBatch.SetHeader(mockIATBatchHeaderFF())
+	mockBatch.AddEntry(mockIATEntryDetail())
+	addenda10 := mockAddenda10()
+	addenda10.Name = "Wade Arnold"
+	mockBatch.Entries[0].Addenda10 = addenda10
+	mockBatch.Entries[0].Addenda11 = mockAddenda11()
+	mockBatch.Entries[0].Addenda12 = mockAddenda12()
+	mockBatch.Entries[0].Addenda13 = mockAddenda13()
+	mockBatch.Entries[0].Addenda14 = mockAddenda14()
+	mockBatch.Entries[0].Addenda15 = mockAddenda15()
+	mockBatch.Entries[0].Addenda16 = mockAddenda16()
+	addenda18 := mockAddenda18()
+	addenda18.ForeignCorrespondentBankName = "Aerocaribbean Airlines"
+	mockBatch.Entries[0].AddIATAddenda(addenda18)
+	mockBatch.SetScreener(mockSDNList(t))
+	if err := mockBatch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	entry := mockBatch.GetEntries()[0]
+	if entry.OFACSreeningIndicator != OFACNoMatch || entry.SecondaryOFACSreeningIndicator != OFACMatch {
+		t.Errorf("indicators %q %q", entry.OFACSreeningIndicator, entry.SecondaryOFACSreeningIndicator)
+	}
+
+	// the receiver BEK Enterprises matches BEK ENTERPRISE
+	entry.Addenda10.Name = "BEK Enterprises"
+	matches, err := mockBatch.Screen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Party.Role != ScreeningReceiver || matches[0].TraceNumber != entry.TraceNumber {
+		t.Errorf("unexpected matches %#v", matches)
+	}
+	if entry.OFACSreeningIndicator != OFACMatch {
+		t.Errorf("OFACSreeningIndicator %q", entry.OFACSreeningIndicator)
+	}
+
+	mockBatch.SetScreener(errScreener{})
+	if err := mockBatch.Validate(); err == nil {
+		t.Error("expected a screening error")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "Screener" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestIATBatchScreener tests screening IAT entries sets the OFAC screening indicators
+func TestIATBatchScreener(t *testing.T) {
+	testIATBatchScreener(t)
+}
+
+// BenchmarkIATBatchScreener benchmarks screening IAT entries sets the OFAC screening indicators
+func BenchmarkIATBatchScreener(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testIATBatchScreener(b)
+	}
+}
+
+// testReaderScreener validates screening the IAT entries of a file while reading
+func testReaderScreener(t testing.TB) {
+	f, err := os.Open("./test/data/20180716-IAT-A17-A18.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r := NewReader(f)
+	r.SetValidation(&ValidateOpts{Screener: mockSDNList(t)})
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	for _, entry := range file.IATBatches[0].GetEntries() {
+		if entry.OFACSreeningIndicator != OFACMatch || entry.SecondaryOFACSreeningIndicator != OFACNoMatch {
+			t.Errorf("indicators %q %q", entry.OFACSreeningIndicator, entry.SecondaryOFACSreeningIndicator)
+		}
+	}
+}
+
+// TestReaderScreener tests screening the IAT entries of a file while reading
+func TestReaderScreener(t *testing.T) {
+	testReaderScreener(t)
+}
+
+// BenchmarkReaderScreener benchmarks screening the IAT entries of a file while reading
+func BenchmarkReaderScreener(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderScreener(b)
+	}
+}
@@ -6,9 +6,39 @@ package ach
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLineLength is the longest line read, which allows about 350,000 records written as a
+// single fixed-width stream
+const maxLineLength = 32 * 1024 * 1024
+
+// scanBuffers pools the buffers lines are scanned into. Records keep a copy of their line,
+// so a buffer is reused once its Reader has scanned every line.
+var scanBuffers = sync.Pool{
+	New: func() interface{} {
+		return new([bufio.MaxScanTokenSize]byte)
+	},
+}
+
+// byteOrderMark is the UTF-8 encoding of U+FEFF written at the start of files by some editors
+const byteOrderMark = "\xef\xbb\xbf"
+
+// Warnings of the formatting fixed by a lenient Reader
+var (
+	msgLenientByteOrderMark  = "byte order mark removed"
+	msgLenientCarriageReturn = "carriage return treated as a line ending"
+	msgLenientBlankLine      = "blank line skipped"
+	msgLenientFillerLine     = "short filler line skipped"
+	msgLenientPadded         = "record of %d characters padded to 94"
+	msgLenientTrailingSpace  = "trailing whitespace removed from record of %d characters"
 )
 
 // ParseError is returned for parsing reader errors.
@@ -17,6 +47,8 @@ type ParseError struct {
 	Line   int    // Line number where the error occurred
 	Record string // Name of the record type being parsed
 	Err    error  // The actual error
+	Start  int    // First position of the field in error counted from 1, or zero if not known
+	End    int    // Last position of the field in error, or zero if not known
 }
 
 func (e *ParseError) Error() string {
@@ -30,6 +62,10 @@ func (e *ParseError) Error() string {
 type Reader struct {
 	// r handles the IO.Reader sent to be parser.
 	scanner *bufio.Scanner
+	// decoder decodes the IO.Reader when the file is not ASCII
+	decoder *decodingReader
+	// buffer is the pooled buffer of the scanner until the lines are read
+	buffer *[bufio.MaxScanTokenSize]byte
 	// file is ach.file model being built as r is parsed.
 	File File
 	// line is the current line being parsed from the input r
@@ -42,15 +78,101 @@ type Reader struct {
 	lineNum int
 	// recordName holds the current record name being parsed.
 	recordName string
+	// opts are the optional checks made when validating the file header and entries
+	opts *ValidateOpts
+	// LateReturns are the returns read after their deadline when opts.OriginalSettlementDate is set
+	LateReturns []LateReturn
+	// lenient normalizes the formatting of records before they are parsed
+	lenient bool
+	// Warnings are the formatting problems fixed by a lenient Reader
+	Warnings []ReaderWarning
+	// lossless keeps the line each record was read from
+	lossless bool
+	// records is the number of records read, including block padding
+	records int
+	// padding is the number of block padding records read
+	padding int
+	// lineEndingRead is set once the line ending of the first line is known
+	lineEndingRead bool
+
+	// LineEnding is the line ending of the file read: "\n", "\r\n" or empty for a single
+	// fixed-width stream of records.
+	LineEnding string
+	// BlockPadding is true if the records of the file read are a multiple of BlockingFactor
+	BlockPadding bool
+	// BlockingFactor is the blocking factor of the FileHeader read
+	BlockingFactor int
+
+	// MaxLines is the most records read, each record of a fixed-width file counted as a
+	// line. There is no limit when it is zero.
+	MaxLines int
+	// MaxBatches is the most batches read. There is no limit when it is zero.
+	MaxBatches int
+	// MaxAddenda is the most addenda records read after an entry. There is no limit when it
+	// is zero.
+	MaxAddenda int
+	// batches is the number of batch headers read
+	batches int
+	// addenda is the number of addenda records read since the last entry
+	addenda int
+
+	// ctx is the context of ReadContext, checked before each record is parsed
+	ctx context.Context
+}
+
+// ReaderWarning is a formatting problem of the input that a lenient Reader fixed
+type ReaderWarning struct {
+	// Line is the line number of the input, the first line is 1
+	Line int
+	// Msg describes the problem and how it was fixed
+	Msg string
+}
+
+// String writes the line number and message of the warning
+func (w ReaderWarning) String() string {
+	return fmt.Sprintf("line:%d %s", w.Line, w.Msg)
+}
+
+// LateReturn is a return entry received after the deadline of its return code, which the
+// ODFI may dishonor.
+type LateReturn struct {
+	// BatchNumber is the number of the batch of the return entry
+	BatchNumber int
+	// Entry is the return entry
+	Entry *EntryDetail
+	// Addenda99 is the return addenda of Entry
+	Addenda99 *Addenda99
+	// Deadline is the last date the return could settle
+	Deadline time.Time
 }
 
-// error creates a new ParseError based on err.
+// String writes the return code, original trace number and deadline of the late return
+func (lr LateReturn) String() string {
+	msg := fmt.Sprintf(msgAddenda99Late, lr.Deadline.Format("2006-01-02"))
+	return lr.Addenda99.ReturnCode + " " + lr.Addenda99.OriginalTraceField() + " " + msg
+}
+
+// error creates a new ParseError based on err and sets the line and field positions of the
+// FieldError, BatchError or FileError of err.
 func (r *Reader) error(err error) error {
-	return &ParseError{
+	pe := &ParseError{
 		Line:   r.lineNum,
 		Record: r.recordName,
 		Err:    err,
 	}
+	pe.Start, pe.End = fieldPositions(r.line, err)
+	var fieldErr *FieldError
+	var batchErr *BatchError
+	var fileErr *FileError
+	switch {
+	case errors.As(err, &fieldErr):
+		fieldErr.Line, fieldErr.Start, fieldErr.End = pe.Line, pe.Start, pe.End
+	case errors.As(err, &batchErr):
+		batchErr.Line, batchErr.Start, batchErr.End = pe.Line, pe.Start, pe.End
+	case errors.As(err, &fileErr):
+		fileErr.Line, fileErr.Start, fileErr.End = pe.Line, pe.Start, pe.End
+	}
+	return pe
 }
 
 // addCurrentBatch creates the current batch type for the file being read. A successful
@@ -67,8 +189,68 @@ func (r *Reader) addIATCurrentBatch(iatBatch IATBatch) {
 
 // NewReader returns a new ACH Reader that reads from r.
 func NewReader(r io.Reader) *Reader {
-	return &Reader{
-		scanner: bufio.NewScanner(r),
+	decoder := &decodingReader{r: r}
+	reader := &Reader{
+		scanner: bufio.NewScanner(decoder),
+		decoder: decoder,
+		buffer:  scanBuffers.Get().(*[bufio.MaxScanTokenSize]byte),
+	}
+	reader.scanner.Buffer(reader.buffer[:0], maxLineLength)
+	reader.scanner.Split(reader.scanLines)
+	return reader
+}
+
+// SetEncoding sets the character encoding of the file, ASCII by default. It must be set
+// before Read.
+func (r *Reader) SetEncoding(enc Encoding) {
+	r.decoder.enabled = enc == EBCDIC
+}
+
+// scanLines splits lines like bufio.ScanLines and keeps the line ending of the first line,
+// so the format of the file can be written back with the same Writer options.
+func (r *Reader) scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if token != nil && !r.lineEndingRead {
+		r.lineEndingRead = true
+		switch advance - len(token) {
+		case 2:
+			r.LineEnding = "\r\n"
+		case 1:
+			r.LineEnding = "\n"
+		default:
+			r.LineEnding = ""
+		}
+	}
+	return advance, token, err
+}
+
+// SetValidation sets the optional checks made on the file header and each entry as they
+// are read. A nil opts only applies the NACHA formatting rules.
+func (r *Reader) SetValidation(opts *ValidateOpts) {
+	r.opts = opts
+}
+
+// SetLenient sets whether records are normalized before they are parsed, for files from
+// systems that do not follow the NACHA formatting rules. A lenient Reader removes byte order
+// marks, treats carriage returns as line endings, skips blank and short filler lines, removes
+// trailing whitespace beyond 94 characters, right pads short records with spaces and accepts
+// fixed-width records on any line. Each fix is recorded in Warnings.
+func (r *Reader) SetLenient(lenient bool) {
+	r.lenient = lenient
+}
+
+// SetLossless sets whether each record keeps the line it was read from, including reserved
+// positions and content the record structs do not model. A Writer writes the original line
+// of every record that has not been modified since it was read, so a file read and written
+// with the LineEnding, BlockPadding and BlockingFactor of the Reader is unchanged.
+func (r *Reader) SetLossless(lossless bool) {
+	r.lossless = lossless
+}
+
+// keep records the current line as the original of record when the Reader is lossless
+func (r *Reader) keep(record originalRecord) {
+	if r.lossless {
+		record.keepOriginal(r.line, record.String())
 	}
 }
 
@@ -76,60 +258,208 @@ func NewReader(r io.Reader) *Reader {
 // on the first character of each line. It also enforces ACH formatting rules and returns
 // the appropriate error if issues are found.
 func (r *Reader) Read() (File, error) {
+	return r.ReadContext(context.Background())
+}
+
+// ReadContext performs Read and stops with a ParseError of ctx.Err() when ctx is done
+// before a record is parsed. A record blocked reading from the underlying io.Reader is
+// not interrupted.
+func (r *Reader) ReadContext(ctx context.Context) (File, error) {
+	r.ctx = ctx
 	r.lineNum = 0
+	r.records = 0
+	r.padding = 0
+	r.batches = 0
+	r.addenda = 0
+	r.LateReturns = nil
+	r.Warnings = nil
+	defer r.releaseBuffer()
 	// read through the entire file
 	for r.scanner.Scan() {
 		line := r.scanner.Text()
 		r.lineNum++
-		lineLength := len(line)
-
-		switch {
-		case r.lineNum == 1 && lineLength > RecordLength && lineLength%RecordLength == 0:
-			if err := r.processFixedWidthFile(&line); err != nil {
+		if !r.lenient {
+			if err := r.readLine(line); err != nil {
 				return r.File, err
 			}
-		case lineLength != RecordLength:
-			msg := fmt.Sprintf(msgRecordLength, lineLength)
-			err := &FileError{FieldName: "RecordLength", Value: strconv.Itoa(lineLength), Msg: msg}
-			return r.File, r.error(err)
-		default:
-			r.line = line
-			if err := r.parseLine(); err != nil {
+			continue
+		}
+		for _, record := range r.normalizeLine(line) {
+			if err := r.readLine(record); err != nil {
 				return r.File, err
 			}
 		}
 	}
+	if err := r.scanner.Err(); err != nil {
+		// the line that could not be scanned, such as a line longer than maxLineLength
+		r.lineNum++
+		r.line = ""
+		r.recordName = ""
+		return r.File, r.error(err)
+	}
 	if (FileHeader{}) == r.File.Header {
 		// There must be at least one File Header
 		r.recordName = "FileHeader"
-		return r.File, r.error(&FileError{Msg: msgFileHeader})
+		return r.File, r.error(&FileError{Msg: msgFileHeader, Code: ErrFileHeader.Code})
 	}
 	if (FileControl{}) == r.File.Control {
 		// There must be at least one File Control
 		r.recordName = "FileControl"
-		return r.File, r.error(&FileError{Msg: msgFileControl})
+		return r.File, r.error(&FileError{Msg: msgFileControl, Code: ErrFileControl.Code})
 	}
+	r.readBlocking()
 
 	return r.File, nil
 }
 
-func (r *Reader) processFixedWidthFile(line *string) error {
-	// it should be safe to parse this byte by byte since ACH files are ascii only
-	record := ""
-	for i, c := range *line {
-		record = record + string(c)
-		if i > 0 && (i+1)%RecordLength == 0 {
-			r.line = record
-			if err := r.parseLine(); err != nil {
-				return err
+// checkLimits counts the record about to be parsed and returns an error when the context
+// of the Reader is done or the record exceeds MaxLines, MaxBatches or MaxAddenda
+func (r *Reader) checkLimits() error {
+	if done := r.ctx.Done(); done != nil {
+		select {
+		case <-done:
+			return r.error(r.ctx.Err())
+		default:
+		}
+	}
+	r.records++
+	switch r.line[:1] {
+	case batchHeaderPos:
+		r.batches++
+	case entryDetailPos:
+		r.addenda = 0
+	case entryAddendaPos:
+		r.addenda++
+	}
+	switch {
+	case r.MaxLines > 0 && r.records > r.MaxLines:
+		return r.limitError("MaxLines", r.MaxLines)
+	case r.MaxBatches > 0 && r.batches > r.MaxBatches:
+		return r.limitError("MaxBatches", r.MaxBatches)
+	case r.MaxAddenda > 0 && r.addenda > r.MaxAddenda:
+		return r.limitError("MaxAddenda", r.MaxAddenda)
+	}
+	return nil
+}
+
+// limitError returns the error of a record that exceeds the limit of the Reader field name
+func (r *Reader) limitError(name string, limit int) error {
+	msg := fmt.Sprintf(msgReaderLimit, limit)
+	return r.error(&FileError{FieldName: name, Value: strconv.Itoa(limit), Msg: msg, Code: ErrReaderLimit.Code})
+}
+
+// releaseBuffer returns the buffer of the scanner to the pool once Read returns. The scanner
+// is replaced by an empty one so the released buffer is not used by another Read.
+func (r *Reader) releaseBuffer() {
+	if r.buffer != nil {
+		scanBuffers.Put(r.buffer)
+		r.buffer = nil
+		r.scanner = bufio.NewScanner(strings.NewReader(""))
+	}
+}
+
+// readBlocking sets the BlockPadding and BlockingFactor that a Writer pads the records read
+// with. It is the blocking factor of the FileHeader, unless the file is padded to another
+// length, in which case the BlockingFactor is the smallest that pads the records to the same
+// length.
+func (r *Reader) readBlocking() {
+	r.BlockingFactor, _ = strconv.Atoi(r.File.Header.blockingFactor)
+	if r.padding == 0 {
+		r.BlockPadding = r.BlockingFactor > 0 && r.records%r.BlockingFactor == 0
+		return
+	}
+	r.BlockPadding = true
+	if r.padding < r.BlockingFactor && r.records%r.BlockingFactor == 0 {
+		return
+	}
+	for factor := r.padding + 1; factor <= r.records; factor++ {
+		if r.records%factor == 0 {
+			r.BlockingFactor = factor
+			return
+		}
+	}
+}
+
+// readLine parses a line of one record, or of several fixed-width records on the first line
+func (r *Reader) readLine(line string) error {
+	lineLength := len(line)
+	switch {
+	case (r.lineNum == 1 || r.lenient) && lineLength > RecordLength && lineLength%RecordLength == 0:
+		if r.lineNum == 1 {
+			// records are a single fixed-width stream
+			r.LineEnding = ""
+		}
+		return r.processFixedWidthFile(&line)
+	case lineLength != RecordLength:
+		msg := fmt.Sprintf(msgRecordLength, lineLength)
+		err := &FileError{FieldName: "RecordLength", Value: strconv.Itoa(lineLength), Msg: msg, Code: ErrRecordLength.Code}
+		return r.error(err)
+	default:
+		r.line = line
+		return r.parseLine()
+	}
+}
+
+// normalizeLine returns the records of a line read by a lenient Reader and records a warning
+// for each formatting problem fixed
+func (r *Reader) normalizeLine(line string) []string {
+	if r.lineNum == 1 && strings.HasPrefix(line, byteOrderMark) {
+		line = strings.TrimPrefix(line, byteOrderMark)
+		r.warn(msgLenientByteOrderMark)
+	}
+	pieces := []string{line}
+	if strings.Contains(line, "\r") {
+		pieces = strings.Split(line, "\r")
+		r.warn(msgLenientCarriageReturn)
+	}
+	var records []string
+	for _, record := range pieces {
+		if strings.TrimSpace(record) == "" {
+			if len(pieces) == 1 {
+				r.warn(msgLenientBlankLine)
+			}
+			continue
+		}
+		if len(record) > RecordLength && len(record)%RecordLength != 0 {
+			trimmed := strings.TrimRight(record, " \t")
+			if len(trimmed) <= RecordLength || len(trimmed)%RecordLength == 0 {
+				r.warn(fmt.Sprintf(msgLenientTrailingSpace, len(record)))
+				record = trimmed
+			}
+		}
+		if len(record) < RecordLength {
+			if strings.Trim(record, "9") == "" {
+				r.warn(msgLenientFillerLine)
+				continue
 			}
-			record = ""
+			r.warn(fmt.Sprintf(msgLenientPadded, len(record)))
+			record = record + strings.Repeat(" ", RecordLength-len(record))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// warn records a ReaderWarning of the current line
+func (r *Reader) warn(msg string) {
+	r.Warnings = append(r.Warnings, ReaderWarning{Line: r.lineNum, Msg: msg})
+}
+
+func (r *Reader) processFixedWidthFile(line *string) error {
+	// records are sliced from the line, which is safe since ACH files are ascii only
+	for i := 0; i+RecordLength <= len(*line); i += RecordLength {
+		r.line = (*line)[i : i+RecordLength]
+		if err := r.parseLine(); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 func (r *Reader) parseLine() error {
+	if err := r.checkLimits(); err != nil {
+		return err
+	}
 	switch r.line[:1] {
 	case fileHeaderPos:
 		if err := r.parseFileHeader(); err != nil {
@@ -169,6 +499,7 @@ func (r *Reader) parseLine() error {
 	case fileControlPos:
 		if r.line[:2] == "99" {
 			// final blocking padding
+			r.padding++
 			break
 		}
 		if err := r.parseFileControl(); err != nil {
@@ -176,7 +507,7 @@ func (r *Reader) parseLine() error {
 		}
 	default:
 		msg := fmt.Sprintf(msgUnknownRecordType, r.line[:1])
-		return r.error(&FileError{FieldName: "recordType", Value: r.line[:1], Msg: msg})
+		return r.error(&FileError{FieldName: "recordType", Value: r.line[:1], Msg: msg, Code: ErrUnknownRecordType.Code})
 	}
 	return nil
 }
@@ -231,11 +562,12 @@ func (r *Reader) parseFileHeader() error {
 	r.recordName = "FileHeader"
 	if (FileHeader{}) != r.File.Header {
 		// There can only be one File Header per File exit
-		r.error(&FileError{Msg: msgFileHeader})
+		r.error(&FileError{Msg: msgFileHeader, Code: ErrFileHeader.Code})
 	}
 	r.File.Header.Parse(r.line)
+	r.keep(&r.File.Header)
 
-	if err := r.File.Header.Validate(); err != nil {
+	if err := r.File.Header.ValidateWith(r.opts); err != nil {
 		return r.error(err)
 	}
 	return nil
@@ -246,13 +578,15 @@ func (r *Reader) parseBatchHeader() error {
 	r.recordName = "BatchHeader"
 	if r.currentBatch != nil {
 		// batch header inside of current batch
-		return r.error(&FileError{Msg: msgFileBatchInside})
+		return r.error(&FileError{Msg: msgFileBatchInside, Code: ErrFileBatchInside.Code})
 	}
 
 	// Ensure we have a valid batch header before building a batch.
 	bh := NewBatchHeader()
 	bh.Parse(r.line)
-	if err := bh.Validate(); err != nil {
+	bh.resolveSettlementDate(r.File.Header.FileCreationDate)
+	r.keep(bh)
+	if err := bh.ValidateWith(r.opts); err != nil {
 		return r.error(err)
 	}
 
@@ -271,11 +605,12 @@ func (r *Reader) parseEntryDetail() error {
 	r.recordName = "EntryDetail"
 
 	if r.currentBatch == nil {
-		return r.error(&FileError{Msg: msgFileBatchOutside})
+		return r.error(&FileError{Msg: msgFileBatchOutside, Code: ErrFileBatchOutside.Code})
 	}
 	ed := new(EntryDetail)
 	ed.Parse(r.line)
-	if err := ed.Validate(); err != nil {
+	r.keep(ed)
+	if err := ed.ValidateWith(r.opts); err != nil {
 		return r.error(err)
 	}
 	r.currentBatch.AddEntry(ed)
@@ -288,10 +623,10 @@ func (r *Reader) parseAddenda() error {
 
 	if r.currentBatch == nil {
 		msg := fmt.Sprint(msgFileBatchOutside)
-		return r.error(&FileError{FieldName: "Addenda", Msg: msg})
+		return r.error(&FileError{FieldName: "Addenda", Msg: msg, Code: ErrFileBatchOutside.Code})
 	}
 	if len(r.currentBatch.GetEntries()) == 0 {
-		return r.error(&FileError{FieldName: "Addenda", Msg: msgFileBatchOutside})
+		return r.error(&FileError{FieldName: "Addenda", Msg: msgFileBatchOutside, Code: ErrFileBatchOutside.Code})
 	}
 	entryIndex := len(r.currentBatch.GetEntries()) - 1
 	entry := r.currentBatch.GetEntries()[entryIndex]
@@ -302,6 +637,7 @@ func (r *Reader) parseAddenda() error {
 		case "02":
 			addenda02 := NewAddenda02()
 			addenda02.Parse(r.line)
+			r.keep(addenda02)
 			if err := addenda02.Validate(); err != nil {
 				return r.error(err)
 			}
@@ -309,6 +645,7 @@ func (r *Reader) parseAddenda() error {
 		case "05":
 			addenda05 := NewAddenda05()
 			addenda05.Parse(r.line)
+			r.keep(addenda05)
 			if err := addenda05.Validate(); err != nil {
 				return r.error(err)
 			}
@@ -316,6 +653,7 @@ func (r *Reader) parseAddenda() error {
 		case "98":
 			addenda98 := NewAddenda98()
 			addenda98.Parse(r.line)
+			r.keep(addenda98)
 			if err := addenda98.Validate(); err != nil {
 				return r.error(err)
 			}
@@ -323,33 +661,63 @@ func (r *Reader) parseAddenda() error {
 		case "99":
 			addenda99 := NewAddenda99()
 			addenda99.Parse(r.line)
+			r.keep(addenda99)
 			if err := addenda99.Validate(); err != nil {
 				return r.error(err)
 			}
 			r.currentBatch.GetEntries()[entryIndex].AddAddenda(addenda99)
+			r.checkReturnDeadline(entry, addenda99)
 		}
 	} else {
 		msg := fmt.Sprint(msgBatchAddendaIndicator)
-		return r.error(&FileError{FieldName: "AddendaRecordIndicator", Msg: msg})
+		return r.error(&FileError{FieldName: "AddendaRecordIndicator", Msg: msg, Code: ErrBatchAddendaIndicator.Code})
 	}
 	return nil
 }
 
+// checkReturnDeadline records a LateReturn when the return settles after the deadline of its return
+// code. The return settles on the SettlementDate of its batch inserted by the ACH Operator, else the
+// EffectiveEntryDate of its batch, or the FileCreationDate when both are blank.
+func (r *Reader) checkReturnDeadline(entry *EntryDetail, addenda99 *Addenda99) {
+	if r.opts == nil || r.opts.OriginalSettlementDate == nil {
+		return
+	}
+	settlement, ok := r.opts.OriginalSettlementDate(addenda99)
+	if !ok {
+		return
+	}
+	bh := r.currentBatch.GetHeader()
+	returned := bh.SettlementDate
+	if returned.IsZero() {
+		returned = bh.EffectiveEntryDate
+	}
+	if returned.IsZero() {
+		returned = r.File.Header.FileCreationDate
+	}
+	if addenda99.IsTimely(settlement, returned, r.opts.Calendar) {
+		return
+	}
+	deadline, _ := addenda99.ReturnDeadline(settlement, r.opts.Calendar)
+	r.LateReturns = append(r.LateReturns, LateReturn{BatchNumber: bh.BatchNumber, Entry: entry, Addenda99: addenda99, Deadline: deadline})
+}
+
 // parseBatchControl takes the input record string and parses the BatchControlRecord values
 func (r *Reader) parseBatchControl() error {
 	r.recordName = "BatchControl"
 	if r.currentBatch == nil && r.IATCurrentBatch.GetEntries() == nil {
 		// batch Control without a current batch
-		return r.error(&FileError{Msg: msgFileBatchOutside})
+		return r.error(&FileError{Msg: msgFileBatchOutside, Code: ErrFileBatchOutside.Code})
 	}
 
 	if r.currentBatch != nil {
 		r.currentBatch.GetControl().Parse(r.line)
+		r.keep(r.currentBatch.GetControl())
 		if err := r.currentBatch.GetControl().Validate(); err != nil {
 			return r.error(err)
 		}
 	} else {
 		r.IATCurrentBatch.GetControl().Parse(r.line)
+		r.keep(r.IATCurrentBatch.GetControl())
 		if err := r.IATCurrentBatch.GetControl().Validate(); err != nil {
 			return r.error(err)
 		}
@@ -363,9 +731,10 @@ func (r *Reader) parseFileControl() error {
 	r.recordName = "FileControl"
 	if (FileControl{}) != r.File.Control {
 		// Can be only one file control per file
-		return r.error(&FileError{Msg: msgFileControl})
+		return r.error(&FileError{Msg: msgFileControl, Code: ErrFileControl.Code})
 	}
 	r.File.Control.Parse(r.line)
+	r.keep(&r.File.Control)
 	if err := r.File.Control.Validate(); err != nil {
 		return r.error(err)
 	}
@@ -379,18 +748,23 @@ func (r *Reader) parseIATBatchHeader() error {
 	r.recordName = "BatchHeader"
 	if r.IATCurrentBatch.Header != nil {
 		// batch header inside of current batch
-		return r.error(&FileError{Msg: msgFileBatchInside})
+		return r.error(&FileError{Msg: msgFileBatchInside, Code: ErrFileBatchInside.Code})
 	}
 
 	// Ensure we have a valid IAT BatchHeader before building a batch.
 	bh := NewIATBatchHeader()
 	bh.Parse(r.line)
-	if err := bh.Validate(); err != nil {
+	bh.resolveSettlementDate(r.File.Header.FileCreationDate)
+	r.keep(bh)
+	if err := bh.ValidateWith(r.opts); err != nil {
 		return r.error(err)
 	}
 
 	// Passing BatchHeader into NewBatchIAT creates a Batcher of IAT SEC code type.
 	iatBatch := NewIATBatch(bh)
+	if r.opts != nil && r.opts.Screener != nil {
+		iatBatch.SetScreener(r.opts.Screener)
+	}
 	r.addIATCurrentBatch(iatBatch)
 
 	return nil
@@ -401,12 +775,13 @@ func (r *Reader) parseIATEntryDetail() error {
 	r.recordName = "EntryDetail"
 
 	if r.IATCurrentBatch.Header == nil {
-		return r.error(&FileError{Msg: msgFileBatchOutside})
+		return r.error(&FileError{Msg: msgFileBatchOutside, Code: ErrFileBatchOutside.Code})
 	}
 
 	ed := new(IATEntryDetail)
 	ed.Parse(r.line)
-	if err := ed.Validate(); err != nil {
+	r.keep(ed)
+	if err := ed.ValidateWith(r.opts); err != nil {
 		return r.error(err)
 	}
 	r.IATCurrentBatch.AddEntry(ed)
@@ -419,10 +794,10 @@ func (r *Reader) parseIATAddenda() error {
 
 	if r.IATCurrentBatch.GetEntries() == nil {
 		msg := fmt.Sprint(msgFileBatchOutside)
-		return r.error(&FileError{FieldName: "Addenda", Msg: msg})
+		return r.error(&FileError{FieldName: "Addenda", Msg: msg, Code: ErrFileBatchOutside.Code})
 	}
 	if len(r.IATCurrentBatch.GetEntries()) == 0 {
-		return r.error(&FileError{FieldName: "Addenda", Msg: msgFileBatchOutside})
+		return r.error(&FileError{FieldName: "Addenda", Msg: msgFileBatchOutside, Code: ErrFileBatchOutside.Code})
 	}
 	entryIndex := len(r.IATCurrentBatch.GetEntries()) - 1
 	entry := r.IATCurrentBatch.GetEntries()[entryIndex]
@@ -434,7 +809,7 @@ func (r *Reader) parseIATAddenda() error {
 		}
 	} else {
 		msg := fmt.Sprint(msgIATBatchAddendaIndicator)
-		return r.error(&FileError{FieldName: "AddendaRecordIndicator", Msg: msg})
+		return r.error(&FileError{FieldName: "AddendaRecordIndicator", Msg: msg, Code: ErrBatchAddendaIndicator.Code})
 	}
 	return nil
 }
@@ -466,6 +841,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "10":
 		addenda10 := NewAddenda10()
 		addenda10.Parse(r.line)
+		r.keep(addenda10)
 		if err := addenda10.Validate(); err != nil {
 			return err
 		}
@@ -473,6 +849,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "11":
 		addenda11 := NewAddenda11()
 		addenda11.Parse(r.line)
+		r.keep(addenda11)
 		if err := addenda11.Validate(); err != nil {
 			return err
 		}
@@ -480,6 +857,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "12":
 		addenda12 := NewAddenda12()
 		addenda12.Parse(r.line)
+		r.keep(addenda12)
 		if err := addenda12.Validate(); err != nil {
 			return err
 		}
@@ -487,6 +865,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "13":
 		addenda13 := NewAddenda13()
 		addenda13.Parse(r.line)
+		r.keep(addenda13)
 		if err := addenda13.Validate(); err != nil {
 			return err
 		}
@@ -494,6 +873,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "14":
 		addenda14 := NewAddenda14()
 		addenda14.Parse(r.line)
+		r.keep(addenda14)
 		if err := addenda14.Validate(); err != nil {
 			return err
 		}
@@ -501,6 +881,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "15":
 		addenda15 := NewAddenda15()
 		addenda15.Parse(r.line)
+		r.keep(addenda15)
 		if err := addenda15.Validate(); err != nil {
 			return err
 		}
@@ -508,6 +889,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "16":
 		addenda16 := NewAddenda16()
 		addenda16.Parse(r.line)
+		r.keep(addenda16)
 		if err := addenda16.Validate(); err != nil {
 			return err
 		}
@@ -515,6 +897,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "17":
 		addenda17 := NewAddenda17()
 		addenda17.Parse(r.line)
+		r.keep(addenda17)
 		if err := addenda17.Validate(); err != nil {
 			return err
 		}
@@ -522,6 +905,7 @@ func (r *Reader) mandatoryOptionalIATAddenda(entryIndex int) error {
 	case "18":
 		addenda18 := NewAddenda18()
 		addenda18.Parse(r.line)
+		r.keep(addenda18)
 		if err := addenda18.Validate(); err != nil {
 			return err
 		}
@@ -535,6 +919,7 @@ func (r *Reader) returnIATAddenda(entryIndex int) error {
 
 	addenda99 := NewAddenda99()
 	addenda99.Parse(r.line)
+	r.keep(addenda99)
 	if err := addenda99.Validate(); err != nil {
 		return err
 	}
@@ -5,9 +5,16 @@
 package ach
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
 	"os"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 // testParseError validates a a parsing error
@@ -1448,3 +1455,388 @@ func BenchmarkACHFileIATBH(b *testing.B) {
 		testACHFileIATBH(b)
 	}
 }
+
+// testReaderLateReturns validates late returns are reported while reading
+func testReaderLateReturns(t testing.TB) {
+	bh := mockBatchPPDHeader()
+	bh.EffectiveEntryDate = mockDate(t, "2018-10-11")
+	timely := mockPPDEntryDetail()
+	timely.AddAddenda(mockAddenda99())
+	late := mockPPDEntryDetail()
+	addenda99 := mockAddenda99()
+	addenda99.ReturnCode = "R01"
+	addenda99.OriginalTrace = 99912340000016
+	late.AddAddenda(addenda99)
+	fh := mockFileHeader()
+	line := fh.String() + "\n" + bh.String() + "\n" + timely.String() + "\n" + timely.Addendum[0].String() +
+		"\n" + late.String() + "\n" + late.Addendum[0].String()
+
+	r := NewReader(strings.NewReader(line))
+	r.SetValidation(&ValidateOpts{
+		OriginalSettlementDate: func(addenda99 *Addenda99) (time.Time, bool) {
+			// both original entries settled on the Friday before Columbus Day
+			return mockDate(t, "2018-10-05"), true
+		},
+	})
+	// the file is incomplete and only read up to the missing batch control
+	r.Read()
+	if len(r.LateReturns) != 1 {
+		t.Fatalf("expected 1 late return got %d", len(r.LateReturns))
+	}
+	lr := r.LateReturns[0]
+	if lr.Addenda99.OriginalTrace != 99912340000016 || lr.Entry == nil {
+		t.Errorf("unexpected late return %#v", lr)
+	}
+	if lr.String() != "R01 099912340000016 was returned after the deadline of 2018-10-10" {
+		t.Errorf("String %s", lr.String())
+	}
+}
+
+// TestReaderLateReturns tests late returns are reported while reading
+func TestReaderLateReturns(t *testing.T) {
+	testReaderLateReturns(t)
+}
+
+// BenchmarkReaderLateReturns benchmarks late returns are reported while reading
+func BenchmarkReaderLateReturns(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderLateReturns(b)
+	}
+}
+
+// testReaderLenient validates a lenient Reader fixes the formatting of legacy files
+func testReaderLenient(t testing.TB) {
+	data, err := ioutil.ReadFile("./test/data/ppd-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	// byte order mark, blank lines, CRLF line endings and short filler lines
+	quirky := "\xef\xbb\xbf" + lines[0] + "\r\n\r\n" + strings.Join(lines[1:], "\r\n") + "\r\n" + "9999999999\r\n"
+
+	if _, err := NewReader(strings.NewReader(quirky)).Read(); err == nil {
+		t.Fatal("expected a strict Reader to reject the file")
+	}
+
+	r := NewReader(strings.NewReader(quirky))
+	r.SetLenient(true)
+	file, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if file.Batches[0].GetEntries()[0].IndividualName != "Bachman Eric          " {
+		t.Errorf("IndividualName %q", file.Batches[0].GetEntries()[0].IndividualName)
+	}
+	want := []string{
+		"line:1 " + msgLenientByteOrderMark,
+		"line:1 record of 74 characters padded to 94",
+		"line:2 " + msgLenientBlankLine,
+		"line:6 record of 55 characters padded to 94",
+		"line:7 " + msgLenientFillerLine,
+	}
+	var got []string
+	for _, w := range r.Warnings {
+		got = append(got, w.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// records separated by carriage returns only
+	r = NewReader(strings.NewReader(strings.Join(lines, "\r") + "\r"))
+	r.SetLenient(true)
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(r.Warnings) == 0 || r.Warnings[0].Msg != msgLenientCarriageReturn {
+		t.Errorf("warnings %v", r.Warnings)
+	}
+
+	// trailing whitespace beyond 94 characters
+	r = NewReader(strings.NewReader(lines[2] + "   \n"))
+	r.SetLenient(true)
+	r.Read()
+	if len(r.Warnings) != 1 || r.Warnings[0].Msg != "trailing whitespace removed from record of 97 characters" {
+		t.Errorf("warnings %v", r.Warnings)
+	}
+}
+
+// TestReaderLenient tests a lenient Reader fixes the formatting of legacy files
+func TestReaderLenient(t *testing.T) {
+	testReaderLenient(t)
+}
+
+// BenchmarkReaderLenient benchmarks a lenient Reader fixes the formatting of legacy files
+func BenchmarkReaderLenient(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderLenient(b)
+	}
+}
+
+// readFixtures are the test/data files that read without error
+var readFixtures = []string{
+	"20180716-IAT-A17-A18.ach",
+	"20180716-IAT-A17.ach",
+	"ppd-debit-fixedLength.ach",
+	"ppd-debit.ach",
+	"rck.ach",
+	"web-debit.ach",
+}
+
+// mockLargeFile returns a PPD file of entries in a single batch
+func mockLargeFile(t testing.TB, entries int) []byte {
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	for i := 1; i <= entries; i++ {
+		entry := mockPPDEntryDetail()
+		entry.Amount = i
+		entry.SetTraceNumber(batch.GetHeader().ODFIIdentification, i)
+		batch.AddEntry(entry)
+	}
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	b := &bytes.Buffer{}
+	if err := NewWriter(b).Write(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return b.Bytes()
+}
+
+// testReadFixtures validates reading each of the test/data fixtures from memory
+func testReadFixtures(t testing.TB, fixtures [][]byte) {
+	for _, bs := range fixtures {
+		if _, err := NewReader(bytes.NewReader(bs)).Read(); err != nil {
+			t.Errorf("%T: %s", err, err)
+		}
+	}
+}
+
+// loadReadFixtures returns the contents of the test/data fixtures
+func loadReadFixtures(t testing.TB) [][]byte {
+	fixtures := make([][]byte, 0, len(readFixtures))
+	for _, name := range readFixtures {
+		bs, err := ioutil.ReadFile("./test/data/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixtures = append(fixtures, bs)
+	}
+	return fixtures
+}
+
+// TestReadFixtures tests reading each of the test/data fixtures from memory
+func TestReadFixtures(t *testing.T) {
+	testReadFixtures(t, loadReadFixtures(t))
+}
+
+// BenchmarkReadFixtures benchmarks reading each of the test/data fixtures from memory
+func BenchmarkReadFixtures(b *testing.B) {
+	fixtures := loadReadFixtures(b)
+	for i, name := range readFixtures {
+		bs := fixtures[i : i+1]
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bs[0])))
+			for i := 0; i < b.N; i++ {
+				testReadFixtures(b, bs)
+			}
+		})
+	}
+}
+
+// testReadLargeFile validates reading a file of many entries
+func testReadLargeFile(t testing.TB, bs []byte, entries int) {
+	file, err := NewReader(bytes.NewReader(bs)).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if n := len(file.Batches[0].GetEntries()); n != entries {
+		t.Errorf("read %d entries want %d", n, entries)
+	}
+}
+
+// TestReadLargeFile tests reading a file of many entries
+func TestReadLargeFile(t *testing.T) {
+	testReadLargeFile(t, mockLargeFile(t, 1000), 1000)
+}
+
+// BenchmarkReadLargeFile benchmarks reading a file of many entries and reports the
+// allocations per entry
+func BenchmarkReadLargeFile(b *testing.B) {
+	const entries = 10000
+	bs := mockLargeFile(b, entries)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bs)))
+	b.ResetTimer()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	mallocs := stats.Mallocs
+	for i := 0; i < b.N; i++ {
+		testReadLargeFile(b, bs, entries)
+	}
+	runtime.ReadMemStats(&stats)
+	b.ReportMetric(float64(stats.Mallocs-mallocs)/float64(b.N*entries), "allocs/entry")
+}
+
+// testReaderLimits validates a Reader stops at the first record that exceeds its limits
+func testReaderLimits(t testing.TB) {
+	bs, err := ioutil.ReadFile("./test/data/20180716-IAT-A17-A18.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	read := func(limits func(r *Reader)) error {
+		r := NewReader(bytes.NewReader(bs))
+		limits(r)
+		_, err := r.Read()
+		return err
+	}
+	if err := read(func(r *Reader) { r.MaxLines, r.MaxBatches, r.MaxAddenda = 100, 2, 14 }); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	// line of the second batch header
+	secondBatch := 0
+	for i, line := range strings.Split(string(bs), "\n") {
+		if strings.HasPrefix(line, batchHeaderPos) {
+			secondBatch = i + 1
+		}
+	}
+	tests := []struct {
+		limits    func(r *Reader)
+		fieldName string
+		line      int
+	}{
+		{func(r *Reader) { r.MaxLines = 5 }, "MaxLines", 6},
+		{func(r *Reader) { r.MaxBatches = 1 }, "MaxBatches", secondBatch},
+		{func(r *Reader) { r.MaxAddenda = 8 }, "MaxAddenda", 12},
+	}
+	for _, test := range tests {
+		err := read(test.limits)
+		var parseErr *ParseError
+		var fileErr *FileError
+		if !errors.As(err, &parseErr) || !errors.As(err, &fileErr) || !errors.Is(err, ErrReaderLimit) {
+			t.Fatalf("%s: %T: %s", test.fieldName, err, err)
+		}
+		if fileErr.FieldName != test.fieldName || parseErr.Line != test.line {
+			t.Errorf("%s: line %d: %s", test.fieldName, parseErr.Line, err)
+		}
+	}
+}
+
+// TestReaderLimits tests a Reader stops at the first record that exceeds its limits
+func TestReaderLimits(t *testing.T) {
+	testReaderLimits(t)
+}
+
+// BenchmarkReaderLimits benchmarks a Reader stops at the first record that exceeds its limits
+func BenchmarkReaderLimits(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReaderLimits(b)
+	}
+}
+
+// cancelingReader cancels a context once it has been read from
+type cancelingReader struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (c *cancelingReader) Read(p []byte) (int, error) {
+	defer c.cancel()
+	return c.r.Read(p)
+}
+
+// testReadContext validates a Reader stops reading once its context is done
+func testReadContext(t testing.TB) {
+	bs := mockLargeFile(t, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewReader(bytes.NewReader(bs)).ReadContext(ctx)
+	var parseErr *ParseError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &parseErr) || parseErr.Line != 1 {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// canceled while the records already read are parsed
+	ctx, cancel = context.WithCancel(context.Background())
+	r := NewReader(&cancelingReader{r: bytes.NewReader(bs), cancel: cancel})
+	if _, err := r.ReadContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if len(r.File.Batches) != 0 {
+		t.Errorf("read %d batches", len(r.File.Batches))
+	}
+
+	if _, err := NewReader(bytes.NewReader(bs)).ReadContext(context.Background()); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestReadContext tests a Reader stops reading once its context is done
+func TestReadContext(t *testing.T) {
+	testReadContext(t)
+}
+
+// BenchmarkReadContext benchmarks a Reader stops reading once its context is done
+func BenchmarkReadContext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReadContext(b)
+	}
+}
+
+// failingReader returns err once r is read
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, f.err
+	}
+	return n, err
+}
+
+// testReadScannerError validates a Reader returns the error of the underlying io.Reader
+// rather than the file read until then
+func testReadScannerError(t testing.TB) {
+	bs := mockLargeFile(t, 10)
+	readErr := errors.New("connection reset")
+	r := NewReader(&failingReader{r: bytes.NewReader(bs), err: readErr})
+	_, err := r.Read()
+	var parseErr *ParseError
+	if !errors.Is(err, readErr) || !errors.As(err, &parseErr) {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if lines := bytes.Count(bs, []byte("\n")); parseErr.Line != lines+1 || parseErr.Record != "" {
+		t.Errorf("got line %d record %q", parseErr.Line, parseErr.Record)
+	}
+}
+
+// TestReadScannerError tests a Reader returns the error of the underlying io.Reader
+func TestReadScannerError(t *testing.T) {
+	testReadScannerError(t)
+}
+
+// BenchmarkReadScannerError benchmarks a Reader returns the error of the underlying io.Reader
+func BenchmarkReadScannerError(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReadScannerError(b)
+	}
+}
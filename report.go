@@ -0,0 +1,317 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var msgReportFormat = "%v is not a supported report format"
+
+// ReportFormat is the output format of WriteReport
+type ReportFormat int
+
+const (
+	// ReportText writes plain-text tables aligned with spaces
+	ReportText ReportFormat = iota
+	// ReportMarkdown writes Markdown tables
+	ReportMarkdown
+	// ReportHTML writes an HTML fragment of tables
+	ReportHTML
+)
+
+// ParseReportFormat returns the ReportFormat named s: "text", "markdown" or "html"
+func ParseReportFormat(s string) (ReportFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "text", "txt", "":
+		return ReportText, nil
+	case "markdown", "md":
+		return ReportMarkdown, nil
+	case "html":
+		return ReportHTML, nil
+	}
+	return ReportText, fmt.Errorf(msgReportFormat, s)
+}
+
+// reportTable is a titled table of a report
+type reportTable struct {
+	title  string
+	header []string
+	rows   [][]string
+}
+
+// WriteReport writes a human-readable report of file to w: a file header summary, each
+// batch with its header, entries and totals, and the file totals. Account numbers are
+// masked to their last four characters.
+func WriteReport(w io.Writer, file *File, format ReportFormat) error {
+	tables := reportTables(file)
+	switch format {
+	case ReportText:
+		return writeTextReport(w, tables)
+	case ReportMarkdown:
+		return writeMarkdownReport(w, tables)
+	case ReportHTML:
+		return writeHTMLReport(w, tables)
+	}
+	return fmt.Errorf(msgReportFormat, format)
+}
+
+// reportTables builds the tables of a report on file
+func reportTables(file *File) []reportTable {
+	fh := file.Header
+	tables := []reportTable{{
+		title:  "File",
+		header: []string{"Destination", "Destination Name", "Origin", "Origin Name", "Creation Date", "Creation Time", "ID Modifier"},
+		rows: [][]string{{
+			strings.TrimSpace(fh.ImmediateDestination),
+			fh.ImmediateDestinationName,
+			strings.TrimSpace(fh.ImmediateOrigin),
+			fh.ImmediateOriginName,
+			reportDate(fh.FileCreationDateField()),
+			fh.FileCreationTimeField(),
+			fh.FileIDModifier,
+		}},
+	}}
+
+	entryHeader := []string{"Trace Number", "Code", "D/C", "RDFI", "Account", "Name", "Amount", "Addenda"}
+	for _, batch := range file.Batches {
+		bh := batch.GetHeader()
+		title := "Batch " + strconv.Itoa(bh.BatchNumber)
+		tables = append(tables, reportTable{
+			title:  title,
+			header: []string{"Company", "Company ID", "SEC", "Description", "Descriptive Date", "Effective Date", "ODFI"},
+			rows: [][]string{{
+				bh.CompanyName,
+				bh.CompanyIdentification,
+				bh.StandardEntryClassCode,
+				bh.CompanyEntryDescription,
+				bh.CompanyDescriptiveDate,
+				reportDate(bh.EffectiveEntryDateField()),
+				bh.ODFIIdentification,
+			}},
+		})
+		entries := reportTable{title: title + " Entries", header: entryHeader}
+		for _, entry := range batch.GetEntries() {
+			name := strings.TrimSpace(entry.IndividualName)
+			if bh.StandardEntryClassCode == "CTX" && len(entry.IndividualName) >= 20 {
+				name = entry.CTXReceivingCompanyField()
+			}
+			entries.rows = append(entries.rows, []string{
+				entry.TraceNumberField(),
+				strconv.Itoa(entry.TransactionCode),
+				entry.CreditOrDebit(),
+				entry.RDFIIdentification + entry.CheckDigit,
+				maskNumber(entry.DFIAccountNumber),
+				name,
+				formatDollars(entry.Amount),
+				reportAddenda(entry.Addendum),
+			})
+		}
+		tables = append(tables, entries, reportTotals(title+" Totals", batch.GetControl()))
+	}
+
+	for _, iatBatch := range file.IATBatches {
+		bh := iatBatch.GetHeader()
+		title := "IAT Batch " + strconv.Itoa(bh.BatchNumber)
+		tables = append(tables, reportTable{
+			title:  title,
+			header: []string{"Originator ID", "SEC", "Description", "Destination Country", "Currencies", "Effective Date", "ODFI"},
+			rows: [][]string{{
+				bh.OriginatorIdentification,
+				bh.StandardEntryClassCode,
+				bh.CompanyEntryDescription,
+				bh.ISODestinationCountryCode,
+				bh.ISOOriginatingCurrencyCode + "/" + bh.ISODestinationCurrencyCode,
+				reportDate(bh.EffectiveEntryDateField()),
+				bh.ODFIIdentification,
+			}},
+		})
+		entries := reportTable{title: title + " Entries", header: entryHeader}
+		for _, entry := range iatBatch.GetEntries() {
+			name := ""
+			if entry.Addenda10 != nil {
+				name = strings.TrimSpace(entry.Addenda10.Name)
+			}
+			addenda := "10-16"
+			if s := reportAddenda(entry.Addendum); s != "" {
+				addenda += "; " + s
+			}
+			entries.rows = append(entries.rows, []string{
+				entry.TraceNumberField(),
+				strconv.Itoa(entry.TransactionCode),
+				(&EntryDetail{TransactionCode: entry.TransactionCode}).CreditOrDebit(),
+				entry.RDFIIdentification + entry.CheckDigit,
+				maskNumber(entry.DFIAccountNumber),
+				name,
+				formatDollars(entry.Amount),
+				addenda,
+			})
+		}
+		tables = append(tables, entries, reportTotals(title+" Totals", iatBatch.GetControl()))
+	}
+
+	fc := file.Control
+	tables = append(tables, reportTable{
+		title:  "File Totals",
+		header: []string{"Batches", "Entries and Addenda", "Debit", "Credit"},
+		rows: [][]string{{
+			strconv.Itoa(fc.BatchCount),
+			strconv.Itoa(fc.EntryAddendaCount),
+			formatDollars(fc.TotalDebitEntryDollarAmountInFile),
+			formatDollars(fc.TotalCreditEntryDollarAmountInFile),
+		}},
+	})
+	return tables
+}
+
+// reportTotals returns the totals table of a batch control
+func reportTotals(title string, bc *BatchControl) reportTable {
+	table := reportTable{title: title, header: []string{"Entries and Addenda", "Debit", "Credit"}}
+	if bc != nil {
+		table.rows = [][]string{{
+			strconv.Itoa(bc.EntryAddendaCount),
+			formatDollars(bc.TotalDebitEntryDollarAmount),
+			formatDollars(bc.TotalCreditEntryDollarAmount),
+		}}
+	}
+	return table
+}
+
+// reportAddenda summarizes the addenda records of an entry
+func reportAddenda(addendum []Addendumer) string {
+	var info []string
+	for _, addenda := range addendum {
+		switch a := addenda.(type) {
+		case *Addenda02:
+			info = append(info, fmt.Sprintf("02 %s %s %s", a.TerminalIdentificationCode, a.TerminalCity, a.TerminalState))
+		case *Addenda05:
+			info = append(info, "05 "+a.PaymentRelatedInformation)
+		case *Addenda17:
+			info = append(info, "17 "+a.PaymentRelatedInformation)
+		case *Addenda18:
+			info = append(info, fmt.Sprintf("18 %s %s", a.ForeignCorrespondentBankName, a.ForeignCorrespondentBankBranchCountryCode))
+		case *Addenda98:
+			info = append(info, fmt.Sprintf("98 %s %s", a.ChangeCode, strings.TrimSpace(a.CorrectedData)))
+		case *Addenda99:
+			info = append(info, fmt.Sprintf("99 %s %s", a.ReturnCode, a.AddendaInformation))
+		default:
+			info = append(info, addenda.TypeCode())
+		}
+	}
+	return strings.TrimSpace(strings.Join(info, "; "))
+}
+
+// reportDate formats a YYMMDD date as YYYY-MM-DD with the century of parseSimpleDate,
+// leaving blank or invalid dates as they are
+func reportDate(s string) string {
+	if strings.Trim(s, "0") == "" {
+		return strings.TrimSpace(s)
+	}
+	t, err := time.Parse("060102", s)
+	if err != nil {
+		return strings.TrimSpace(s)
+	}
+	return t.Format("2006-01-02")
+}
+
+// maskNumber replaces all but the last four characters of a trimmed account, card
+// or identification number with "*"
+func maskNumber(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+// writeTextReport writes tables as space aligned columns
+func writeTextReport(w io.Writer, tables []reportTable) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, table := range tables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(tw); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(tw, table.title); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(table.header, "\t")); err != nil {
+			return err
+		}
+		for _, row := range table.rows {
+			if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		// flush each table so columns are aligned per table
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdownReport writes tables as Markdown tables under a heading each
+func writeMarkdownReport(w io.Writer, tables []reportTable) error {
+	cell := strings.NewReplacer("|", "\\|", "\n", " ")
+	for i, table := range tables {
+		var buf strings.Builder
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("### " + table.title + "\n\n")
+		buf.WriteString("|")
+		for _, h := range table.header {
+			buf.WriteString(" " + cell.Replace(h) + " |")
+		}
+		buf.WriteString("\n|")
+		for range table.header {
+			buf.WriteString(" --- |")
+		}
+		buf.WriteString("\n")
+		for _, row := range table.rows {
+			buf.WriteString("|")
+			for _, v := range row {
+				buf.WriteString(" " + cell.Replace(v) + " |")
+			}
+			buf.WriteString("\n")
+		}
+		if _, err := io.WriteString(w, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHTMLReport writes tables as an HTML fragment with a heading each
+func writeHTMLReport(w io.Writer, tables []reportTable) error {
+	for _, table := range tables {
+		var buf strings.Builder
+		buf.WriteString("<h3>" + html.EscapeString(table.title) + "</h3>\n<table>\n<thead>\n<tr>")
+		for _, h := range table.header {
+			buf.WriteString("<th>" + html.EscapeString(h) + "</th>")
+		}
+		buf.WriteString("</tr>\n</thead>\n<tbody>\n")
+		for _, row := range table.rows {
+			buf.WriteString("<tr>")
+			for _, v := range row {
+				buf.WriteString("<td>" + html.EscapeString(v) + "</td>")
+			}
+			buf.WriteString("</tr>\n")
+		}
+		buf.WriteString("</tbody>\n</table>\n")
+		if _, err := io.WriteString(w, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// mockReportFile reads a PPD debit file for reports
+func mockReportFile(t testing.TB) *File {
+	f, err := os.Open("./test/data/ppd-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	file, err := NewReader(f).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	return &file
+}
+
+// testWriteReportText validates the plain-text report of a file
+func testWriteReportText(t testing.TB) {
+	file := mockReportFile(t)
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, file, ReportText); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	report := buf.String()
+	for _, s := range []string{"Batch 1 Entries", "Bachman Eric", "CHECKPAYMT", "*2345", "105.00", "File Totals"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("%q not found in report:\n%s", s, report)
+		}
+	}
+	if strings.Contains(report, "12345 ") {
+		t.Errorf("account number was not masked:\n%s", report)
+	}
+}
+
+// TestWriteReportText tests the plain-text report of a file
+func TestWriteReportText(t *testing.T) {
+	testWriteReportText(t)
+}
+
+// BenchmarkWriteReportText benchmarks the plain-text report of a file
+func BenchmarkWriteReportText(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriteReportText(b)
+	}
+}
+
+// testWriteReportMarkdown validates the Markdown report of a file
+func testWriteReportMarkdown(t testing.TB) {
+	file := mockReportFile(t)
+	file.Batches[0].GetEntries()[0].IndividualName = "Name | Pipe"
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, file, ReportMarkdown); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	report := buf.String()
+	for _, s := range []string{"### File Totals", "| --- |", "Name \\| Pipe"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("%q not found in report:\n%s", s, report)
+		}
+	}
+}
+
+// TestWriteReportMarkdown tests the Markdown report of a file
+func TestWriteReportMarkdown(t *testing.T) {
+	testWriteReportMarkdown(t)
+}
+
+// BenchmarkWriteReportMarkdown benchmarks the Markdown report of a file
+func BenchmarkWriteReportMarkdown(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriteReportMarkdown(b)
+	}
+}
+
+// testWriteReportHTML validates the HTML report of a file escapes its values
+func testWriteReportHTML(t testing.TB) {
+	file := mockReportFile(t)
+	file.Batches[0].GetEntries()[0].IndividualName = "<b>Name</b>"
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, file, ReportHTML); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	report := buf.String()
+	if !strings.Contains(report, "&lt;b&gt;Name&lt;/b&gt;") || strings.Contains(report, "<b>") {
+		t.Errorf("values were not escaped:\n%s", report)
+	}
+	if strings.Count(report, "<table>") != strings.Count(report, "</table>") {
+		t.Errorf("unbalanced tables:\n%s", report)
+	}
+}
+
+// TestWriteReportHTML tests the HTML report of a file escapes its values
+func TestWriteReportHTML(t *testing.T) {
+	testWriteReportHTML(t)
+}
+
+// BenchmarkWriteReportHTML benchmarks the HTML report of a file escapes its values
+func BenchmarkWriteReportHTML(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriteReportHTML(b)
+	}
+}
+
+// testWriteReportIAT validates IAT batches and addenda are included in a report
+func testWriteReportIAT(t testing.TB) {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddIATBatch(mockIATBatchManyEntries())
+	batch := mockBatchPPD()
+	batch.GetEntries()[0].AddAddenda(mockAddenda99())
+	file.AddBatch(batch)
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, file, ReportText); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	report := buf.String()
+	for _, s := range []string{"IAT Batch 1 Entries", "BEK Enterprises", "17 This is an international payment", "99 R07 Authorization Revoked"} {
+		if !strings.Contains(report, s) {
+			t.Errorf("%q not found in report:\n%s", s, report)
+		}
+	}
+}
+
+// TestWriteReportIAT tests IAT batches and addenda are included in a report
+func TestWriteReportIAT(t *testing.T) {
+	testWriteReportIAT(t)
+}
+
+// BenchmarkWriteReportIAT benchmarks IAT batches and addenda are included in a report
+func BenchmarkWriteReportIAT(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriteReportIAT(b)
+	}
+}
+
+// testParseReportFormat validates parsing report format names
+func testParseReportFormat(t testing.TB) {
+	tests := map[string]ReportFormat{"text": ReportText, "Markdown": ReportMarkdown, "md": ReportMarkdown, "html": ReportHTML}
+	for s, want := range tests {
+		if got, err := ParseReportFormat(s); err != nil || got != want {
+			t.Errorf("%s: got %v %v", s, got, err)
+		}
+	}
+	if _, err := ParseReportFormat("pdf"); err == nil {
+		t.Error("expected an error for pdf")
+	}
+	if err := WriteReport(&bytes.Buffer{}, NewFile(), ReportFormat(9)); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+// TestParseReportFormat tests parsing report format names
+func TestParseReportFormat(t *testing.T) {
+	testParseReportFormat(t)
+}
+
+// BenchmarkParseReportFormat benchmarks parsing report format names
+func BenchmarkParseReportFormat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testParseReportFormat(b)
+	}
+}
+
+// testMaskNumber validates masking all but the last four characters
+func testMaskNumber(t testing.TB) {
+	tests := map[string]string{"123456789": "*****6789", "  12345 ": "*2345", "123": "***", "": ""}
+	for s, want := range tests {
+		if got := maskNumber(s); got != want {
+			t.Errorf("%q: got %q want %q", s, got, want)
+		}
+	}
+}
+
+// TestMaskNumber tests masking all but the last four characters
+func TestMaskNumber(t *testing.T) {
+	testMaskNumber(t)
+}
+
+// BenchmarkMaskNumber benchmarks masking all but the last four characters
+func BenchmarkMaskNumber(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testMaskNumber(b)
+	}
+}
+
+// testReportDate validates report dates have the century of the parsed date
+func testReportDate(t testing.TB) {
+	tests := map[string]string{
+		"180716": "2018-07-16",
+		"680716": "2068-07-16",
+		"690716": "1969-07-16",
+		"991231": "1999-12-31",
+		"      ": "",
+		"000000": "000000",
+		"181340": "181340",
+	}
+	for s, want := range tests {
+		if got := reportDate(s); got != want {
+			t.Errorf("reportDate(%q) %q want %q", s, got, want)
+		}
+		if len(want) == 10 {
+			if parsed := (&converters{}).parseSimpleDate(s).Format("2006-01-02"); parsed != want {
+				t.Errorf("parseSimpleDate(%q) %q want %q", s, parsed, want)
+			}
+		}
+	}
+}
+
+// TestReportDate tests report dates have the century of the parsed date
+func TestReportDate(t *testing.T) {
+	testReportDate(t)
+}
+
+// BenchmarkReportDate benchmarks report dates have the century of the parsed date
+func BenchmarkReportDate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testReportDate(b)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SameDayEntryLimit is the largest amount in cents of a Same Day ACH entry ($1,000,000.00)
+const SameDayEntryLimit = 100000000
+
+// Errors specific to Same Day ACH
+var (
+	msgSameDayDescriptiveDate = "is not a Same Day settlement time SDHHMM"
+	msgSameDayAmount          = "exceeds the Same Day ACH entry limit of %s"
+	msgSameDayIAT             = "IAT entries are not eligible for Same Day ACH"
+)
+
+// SettlementWindow is a FedACH Same Day processing window. Files submitted by Deadline
+// settle at Settlement on the same banking day. Times are HHMM Eastern Time.
+type SettlementWindow struct {
+	// Number is the window of the day, starting at 1
+	Number int `json:"number"`
+	// Deadline is the HHMM submission deadline of the window
+	Deadline string `json:"deadline"`
+	// Settlement is the HHMM settlement time of the window
+	Settlement string `json:"settlement"`
+}
+
+// SameDayWindows are the FedACH Same Day settlement windows in order of the day
+var SameDayWindows = []SettlementWindow{
+	{Number: 1, Deadline: "1030", Settlement: "1300"},
+	{Number: 2, Deadline: "1445", Settlement: "1700"},
+	{Number: 3, Deadline: "1645", Settlement: "1800"},
+}
+
+// easternTime is the location of the FedACH processing schedule. Eastern Standard Time is
+// used when the time zone database is not available.
+var easternTime = loadEasternTime()
+
+func loadEasternTime() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
+
+// SameDayWindow returns the first Same Day window whose deadline has not passed at submission.
+// It returns false once the last deadline of the day has passed, or when the day of submission
+// in Eastern Time is not a banking day of cal. A nil cal has only the Federal Reserve holidays.
+func SameDayWindow(submission time.Time, cal *Calendar) (SettlementWindow, bool) {
+	if !isSameDayBankingDay(submission, cal) {
+		return SettlementWindow{}, false
+	}
+	hhmm := submission.In(easternTime).Format("1504")
+	for _, window := range SameDayWindows {
+		if hhmm <= window.Deadline {
+			return window, true
+		}
+	}
+	return SettlementWindow{}, false
+}
+
+// IsSameDay returns true if the CompanyDescriptiveDate of the batch header follows the Same Day
+// convention of "SD" and the HHMM settlement time, such as SD1300.
+func (bh *BatchHeader) IsSameDay() bool {
+	return strings.HasPrefix(bh.CompanyDescriptiveDate, "SD")
+}
+
+// TagSameDay marks batch as Same Day when its EffectiveEntryDate is the date of submission in
+// Eastern Time and a Same Day window of cal is open at submission. The CompanyDescriptiveDate is
+// set to "SD" and the settlement time of the window. It returns false and leaves the batch
+// unchanged otherwise.
+func TagSameDay(batch Batcher, submission time.Time, cal *Calendar) bool {
+	bh := batch.GetHeader()
+	if !isSameDate(bh.EffectiveEntryDate, submission.In(easternTime)) {
+		return false
+	}
+	window, ok := SameDayWindow(submission, cal)
+	if !ok {
+		return false
+	}
+	bh.CompanyDescriptiveDate = "SD" + window.Settlement
+	return true
+}
+
+// SettlementWindow returns the Same Day window the file settles in when submitted at submission.
+// It returns false when the file has no Same Day batches, every window has closed or the day of
+// submission is not a banking day of cal, in which case the file settles on a later banking day.
+func (f *File) SettlementWindow(submission time.Time, cal *Calendar) (SettlementWindow, bool) {
+	sameDay := false
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		if bh.IsSameDay() || isSameDate(bh.EffectiveEntryDate, submission.In(easternTime)) {
+			sameDay = true
+			break
+		}
+	}
+	if !sameDay {
+		return SettlementWindow{}, false
+	}
+	return SameDayWindow(submission, cal)
+}
+
+// isSameDayBankingDay returns true if the day of submission in Eastern Time is a banking day
+// of cal, or of the Federal Reserve holidays when cal is nil
+func isSameDayBankingDay(submission time.Time, cal *Calendar) bool {
+	if cal == nil {
+		cal = NewCalendar()
+	}
+	return cal.IsBankingDay(submission.In(easternTime))
+}
+
+// validateSameDay checks the Same Day ACH rules of a batch. A batch is Same Day when its header
+// is tagged or its EffectiveEntryDate is the creation date of the file.
+func validateSameDay(batch Batcher, created time.Time) error {
+	bh := batch.GetHeader()
+	if !bh.IsSameDay() && !isSameDate(bh.EffectiveEntryDate, created) {
+		return nil
+	}
+	if bh.IsSameDay() && !isSameDaySettlement(bh.CompanyDescriptiveDate[2:]) {
+		return &BatchError{BatchNumber: bh.BatchNumber, FieldName: "CompanyDescriptiveDate", Msg: bh.CompanyDescriptiveDate + " " + msgSameDayDescriptiveDate, Code: ErrSameDayDescriptiveDate.Code}
+	}
+	for _, entry := range batch.GetEntries() {
+		if entry.Amount > SameDayEntryLimit {
+			msg := fmt.Sprintf(msgSameDayAmount, formatDollars(SameDayEntryLimit))
+			return &BatchError{BatchNumber: bh.BatchNumber, FieldName: "Amount", Msg: strconv.Itoa(entry.Amount) + " " + msg, Code: ErrSameDayAmount.Code}
+		}
+	}
+	return nil
+}
+
+// validateSameDayIAT rejects an IAT batch that settles on the creation date of the file
+func validateSameDayIAT(iatBatch IATBatch, created time.Time) error {
+	bh := iatBatch.GetHeader()
+	if isSameDate(bh.EffectiveEntryDate, created) {
+		return &BatchError{BatchNumber: bh.BatchNumber, FieldName: "EffectiveEntryDate", Msg: msgSameDayIAT, Code: ErrSameDayIAT.Code}
+	}
+	return nil
+}
+
+// isSameDaySettlement returns true if hhmm is the settlement time of a Same Day window
+func isSameDaySettlement(hhmm string) bool {
+	for _, window := range SameDayWindows {
+		if hhmm == window.Settlement {
+			return true
+		}
+	}
+	return false
+}
+
+// isSameDate returns true if a and b are the same calendar date, each in its own location
+func isSameDate(a, b time.Time) bool {
+	if a.IsZero() || b.IsZero() {
+		return false
+	}
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"testing"
+	"time"
+)
+
+// mockSubmission returns a submission time on 2018-10-22 at hour:min Eastern Time
+func mockSubmission(hour, min int) time.Time {
+	return time.Date(2018, time.October, 22, hour, min, 0, 0, easternTime)
+}
+
+// testSameDayWindow validates the Same Day window of a submission time
+func testSameDayWindow(t testing.TB) {
+	tests := []struct {
+		hour, min int
+		number    int
+	}{
+		{8, 0, 1},
+		{10, 30, 1},
+		{10, 31, 2},
+		{14, 45, 2},
+		{16, 45, 3},
+		{16, 46, 0},
+	}
+	for _, test := range tests {
+		window, ok := SameDayWindow(mockSubmission(test.hour, test.min), nil)
+		if ok != (test.number != 0) || window.Number != test.number {
+			t.Errorf("%02d:%02d: got window %d %v want %d", test.hour, test.min, window.Number, ok, test.number)
+		}
+	}
+	// 15:00 UTC is 11:00 Eastern Daylight Time
+	if window, _ := SameDayWindow(time.Date(2018, time.October, 22, 15, 0, 0, 0, time.UTC), nil); window.Number != 2 {
+		t.Errorf("UTC submission got window %d", window.Number)
+	}
+
+	// Same Day ACH only settles on banking days
+	closed := []time.Time{
+		time.Date(2026, time.October, 17, 9, 0, 0, 0, easternTime),  // Saturday
+		time.Date(2026, time.October, 18, 9, 0, 0, 0, easternTime),  // Sunday
+		time.Date(2026, time.December, 25, 9, 0, 0, 0, easternTime), // Christmas Day
+		time.Date(2026, time.October, 17, 14, 0, 0, 0, time.UTC),    // Saturday 10:00 Eastern Time
+	}
+	for _, submission := range closed {
+		if window, ok := SameDayWindow(submission, nil); ok {
+			t.Errorf("%s: got window %d", submission, window.Number)
+		}
+	}
+	cal := NewCalendar()
+	cal.AddClosure(mockSubmission(0, 0))
+	if window, ok := SameDayWindow(mockSubmission(9, 0), cal); ok {
+		t.Errorf("closure got window %d", window.Number)
+	}
+}
+
+// TestSameDayWindow tests the Same Day window of a submission time
+func TestSameDayWindow(t *testing.T) {
+	testSameDayWindow(t)
+}
+
+// BenchmarkSameDayWindow benchmarks the Same Day window of a submission time
+func BenchmarkSameDayWindow(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testSameDayWindow(b)
+	}
+}
+
+// testTagSameDay validates tagging batches and the settlement window of a file
+func testTagSameDay(t testing.TB) {
+	batch := mockBatchPPD()
+	batch.GetHeader().EffectiveEntryDate = time.Date(2018, time.October, 22, 0, 0, 0, 0, time.UTC)
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+
+	// next day settlement is not tagged
+	if TagSameDay(batch, mockSubmission(9, 0).AddDate(0, 0, -1), nil) || batch.GetHeader().IsSameDay() {
+		t.Error("batch settling the next day was tagged")
+	}
+	if _, ok := file.SettlementWindow(mockSubmission(9, 0).AddDate(0, 0, -1), nil); ok {
+		t.Error("next day file has a Same Day window")
+	}
+	// every window has closed
+	if TagSameDay(batch, mockSubmission(17, 0), nil) {
+		t.Error("batch was tagged after the last deadline")
+	}
+
+	// the effective date is a closure of the calendar
+	cal := NewCalendar()
+	cal.AddClosure(mockSubmission(0, 0))
+	if TagSameDay(batch, mockSubmission(11, 15), cal) || batch.GetHeader().IsSameDay() {
+		t.Error("batch was tagged on a closure")
+	}
+
+	if !TagSameDay(batch, mockSubmission(11, 15), nil) {
+		t.Fatal("batch was not tagged")
+	}
+	if batch.GetHeader().CompanyDescriptiveDate != "SD1700" {
+		t.Errorf("CompanyDescriptiveDate %s", batch.GetHeader().CompanyDescriptiveDate)
+	}
+	window, ok := file.SettlementWindow(mockSubmission(11, 15), nil)
+	if !ok || window.Settlement != "1700" {
+		t.Errorf("SettlementWindow %#v %v", window, ok)
+	}
+	if _, ok := file.SettlementWindow(mockSubmission(11, 15), cal); ok {
+		t.Error("file settles in a window on a closure")
+	}
+
+	// weekend and holiday submissions are not tagged
+	for _, submission := range []time.Time{
+		time.Date(2026, time.October, 17, 9, 0, 0, 0, easternTime),
+		time.Date(2026, time.December, 25, 9, 0, 0, 0, easternTime),
+	} {
+		weekend := mockBatchPPD()
+		weekend.GetHeader().EffectiveEntryDate = submission
+		if TagSameDay(weekend, submission, nil) || weekend.GetHeader().IsSameDay() {
+			t.Errorf("%s: batch was tagged", submission)
+		}
+	}
+}
+
+// TestTagSameDay tests tagging batches and the settlement window of a file
+func TestTagSameDay(t *testing.T) {
+	testTagSameDay(t)
+}
+
+// BenchmarkTagSameDay benchmarks tagging batches and the settlement window of a file
+func BenchmarkTagSameDay(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testTagSameDay(b)
+	}
+}
+
+// testValidateSameDay validates the Same Day rules of a file
+func testValidateSameDay(t testing.TB) {
+	opts := &ValidateOpts{SameDay: true}
+
+	// mock batches settle on the creation date of the file
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	entry := mockPPDEntryDetail()
+	entry.Amount = SameDayEntryLimit
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.ValidateWith(opts); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	batch.GetHeader().CompanyDescriptiveDate = "SD1200"
+	if err := file.ValidateWith(opts); err == nil {
+		t.Error("expected an error for SD1200")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "CompanyDescriptiveDate" {
+		t.Errorf("%T: %s", err, err)
+	}
+	if err := batch.GetHeader().ValidateWith(opts); err == nil {
+		t.Error("expected a FieldError for SD1200")
+	}
+	if err := file.ValidateWith(nil); err != nil {
+		t.Errorf("Same Day rules applied without opts: %s", err)
+	}
+
+	batch.GetHeader().CompanyDescriptiveDate = "SD1300"
+	entry.Amount = SameDayEntryLimit + 1
+	if err := batch.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.ValidateWith(opts); err == nil {
+		t.Error("expected an error above the entry limit")
+	} else if e, ok := err.(*BatchError); !ok || e.FieldName != "Amount" {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	iatFile := NewFile().SetHeader(mockFileHeader())
+	iatBatch := mockIATBatch()
+	iatBatch.GetHeader().EffectiveEntryDate = iatFile.Header.FileCreationDate
+	iatFile.AddIATBatch(iatBatch)
+	if err := iatFile.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if err := iatFile.ValidateWith(opts); err == nil {
+		t.Error("expected an error for a Same Day IAT batch")
+	} else if e, ok := err.(*BatchError); !ok || e.Msg != msgSameDayIAT {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestValidateSameDay tests the Same Day rules of a file
+func TestValidateSameDay(t *testing.T) {
+	testValidateSameDay(t)
+}
+
+// BenchmarkValidateSameDay benchmarks the Same Day rules of a file
+func BenchmarkValidateSameDay(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testValidateSameDay(b)
+	}
+}
@@ -68,15 +68,30 @@ type createFileResponse struct {
 func (r createFileResponse) error() error { return r.Err }
 
 func MakeGetFilesEndpoint(s Service) endpoint.Endpoint {
-	return func(_ context.Context, _ interface{}) (interface{}, error) {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		files := s.GetFiles()
+		if req, ok := request.(getFilesRequest); ok && req.Mask {
+			masked := make([]*ach.File, len(files))
+			for i := range files {
+				f, err := files[i].Masked()
+				if err != nil {
+					return getFilesResponse{Err: err}, nil
+				}
+				masked[i] = f
+			}
+			files = masked
+		}
 		return getFilesResponse{
-			Files: s.GetFiles(),
+			Files: files,
 			Err:   nil,
 		}, nil
 	}
 }
 
-type getFilesRequest struct{}
+type getFilesRequest struct {
+	// Mask redacts account, card and identification numbers of the files
+	Mask bool
+}
 
 type getFilesResponse struct {
 	Files []*ach.File `json:"files"`
@@ -91,6 +106,9 @@ func MakeGetFileEndpoint(s Service) endpoint.Endpoint {
 	return func(_ context.Context, request interface{}) (interface{}, error) {
 		req := request.(getFileRequest)
 		f, e := s.GetFile(req.ID)
+		if f != nil && req.Mask {
+			f, e = f.Masked()
+		}
 		return getFileResponse{
 			File: f,
 			Err:  e,
@@ -100,6 +118,8 @@ func MakeGetFileEndpoint(s Service) endpoint.Endpoint {
 
 type getFileRequest struct {
 	ID string
+	// Mask redacts account, card and identification numbers of the file
+	Mask bool
 }
 
 type getFileResponse struct {
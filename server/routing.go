@@ -1,13 +1,11 @@
 package server
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
@@ -27,6 +25,11 @@ var (
 	ErrFoundABug  = fmt.Errorf("Snuck into encodeError with err == nil, %s", bugReportHelp)
 
 	MaxContentLength = 1 * 1024 * 1024 // bytes
+
+	// MaxLines, MaxBatches and MaxAddenda are the limits of the ach.Reader of uploaded files
+	MaxLines   = 100000
+	MaxBatches = 10000
+	MaxAddenda = 9999 // most Addenda05 records of an entry
 )
 
 func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler {
@@ -106,13 +109,12 @@ func MakeHTTPHandler(s Service, repo Repository, logger log.Logger) http.Handler
 }
 
 //** FILES ** //
-func decodeCreateFileRequest(_ context.Context, request *http.Request) (interface{}, error) {
+func decodeCreateFileRequest(ctx context.Context, request *http.Request) (interface{}, error) {
 	// Make sure content-length is small enough
 	if !acceptableContentLength(request.Header) {
 		return nil, errors.New("request body is too large")
 	}
 
-	var r io.Reader
 	var req createFileRequest
 
 	// Sets default values
@@ -120,22 +122,16 @@ func decodeCreateFileRequest(_ context.Context, request *http.Request) (interfac
 		Header: ach.NewFileHeader(),
 	}
 
-	bs, err := ioutil.ReadAll(request.Body)
-	if err != nil {
-		return nil, err
-	}
-
+	body := io.LimitReader(request.Body, int64(MaxContentLength))
 	h := request.Header.Get("Content-Type")
 	if strings.Contains(h, "application/json") {
 		// Attempt to read file as json
-		r = bytes.NewReader(bs)
-		if err := json.NewDecoder(r).Decode(&req.File.Header); err == nil {
+		if err := json.NewDecoder(body).Decode(&req.File.Header); err == nil {
 			return req, nil
 		}
 	} else {
 		// Attempt parsing body as an ACH File
-		r = bytes.NewReader(bs)
-		f, err := ach.NewReader(r).Read()
+		f, err := newReader(body).ReadContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -144,13 +140,22 @@ func decodeCreateFileRequest(_ context.Context, request *http.Request) (interfac
 	return req, nil
 }
 
+// newReader returns an ach.Reader of an uploaded file with MaxLines, MaxBatches and MaxAddenda
+func newReader(r io.Reader) *ach.Reader {
+	reader := ach.NewReader(r)
+	reader.MaxLines = MaxLines
+	reader.MaxBatches = MaxBatches
+	reader.MaxAddenda = MaxAddenda
+	return reader
+}
+
 func decodeGetFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	vars := mux.Vars(r)
 	id, ok := vars["id"]
 	if !ok {
 		return nil, ErrBadRouting
 	}
-	return getFileRequest{ID: id}, nil
+	return getFileRequest{ID: id, Mask: maskRequested(r)}, nil
 }
 
 func decodeDeleteFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -163,7 +168,14 @@ func decodeDeleteFileRequest(_ context.Context, r *http.Request) (interface{}, e
 }
 
 func decodeGetFilesRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	return getFilesRequest{}, nil
+	return getFilesRequest{Mask: maskRequested(r)}, nil
+}
+
+// maskRequested returns true if the ?mask query parameter asks for account, card and
+// identification numbers to be masked in the response
+func maskRequested(r *http.Request) bool {
+	mask, _ := strconv.ParseBool(r.URL.Query().Get("mask"))
+	return mask
 }
 
 func decodeGetFileContentsRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -285,9 +297,21 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(codeFrom(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	body := map[string]interface{}{
 		"error": err.Error(),
-	})
+	}
+	if code := ach.ErrorCodeOf(err); code != "" {
+		body["code"] = code
+	}
+	var parseErr *ach.ParseError
+	if errors.As(err, &parseErr) {
+		body["line"] = parseErr.Line
+		if parseErr.Start > 0 {
+			body["start"] = parseErr.Start
+			body["end"] = parseErr.End
+		}
+	}
+	json.NewEncoder(w).Encode(body)
 }
 
 func codeFrom(err error) int {
@@ -1,8 +1,17 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
+
+	"github.com/moov-io/ach"
 )
 
 func TestAcceptableContentLength(t *testing.T) {
@@ -22,3 +31,88 @@ func TestAcceptableContentLength(t *testing.T) {
 		t.Error("should have rejected")
 	}
 }
+
+func TestDecodeCreateFileRequestLimits(t *testing.T) {
+	bs, err := ioutil.ReadFile("../test/data/web-debit.ach")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/files/create", bytes.NewReader(bs))
+		r.Header.Set("Content-Length", strconv.Itoa(len(bs)))
+		return r
+	}
+	if _, err := decodeCreateFileRequest(context.Background(), newRequest()); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(n int) { MaxBatches = n }(MaxBatches)
+	MaxBatches = 2
+	_, err = decodeCreateFileRequest(context.Background(), newRequest())
+	var fileErr *ach.FileError
+	if !errors.As(err, &fileErr) || fileErr.FieldName != "MaxBatches" {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+func TestMaskRequested(t *testing.T) {
+	r := httptest.NewRequest("GET", "/files/98765?mask=true", nil)
+	if !maskRequested(r) {
+		t.Error("expected mask")
+	}
+	r = httptest.NewRequest("GET", "/files/98765", nil)
+	if maskRequested(r) {
+		t.Error("unexpected mask")
+	}
+}
+
+func TestGetFileEndpointMasked(t *testing.T) {
+	s := mockServiceInMemory()
+	resp, err := MakeGetFileEndpoint(s)(context.Background(), getFileRequest{ID: "98765", Mask: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := resp.(getFileResponse).File.Batches[0].GetEntries()[0]
+	if entry.DFIAccountNumber != "*****6789" {
+		t.Errorf("DFIAccountNumber %q", entry.DFIAccountNumber)
+	}
+
+	// the stored file is not masked
+	f, _ := s.GetFile("98765")
+	if f.Batches[0].GetEntries()[0].DFIAccountNumber != "123456789" {
+		t.Errorf("stored DFIAccountNumber %q", f.Batches[0].GetEntries()[0].DFIAccountNumber)
+	}
+
+	resp, _ = MakeGetFilesEndpoint(s)(context.Background(), getFilesRequest{Mask: true})
+	for _, f := range resp.(getFilesResponse).Files {
+		for _, batch := range f.Batches {
+			if batch.GetEntries()[0].DFIAccountNumber != "*****6789" {
+				t.Errorf("DFIAccountNumber %q", batch.GetEntries()[0].DFIAccountNumber)
+			}
+		}
+	}
+}
+
+func TestEncodeErrorCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := &ach.ParseError{Line: 3, Err: &ach.FieldError{FieldName: "Amount", Msg: "is a required field", Code: ach.ErrFieldRequired.Code}, Start: 30, End: 39}
+	encodeError(context.Background(), err, w)
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body["code"] != "field_required" || body["line"] != float64(3) || body["start"] != float64(30) || body["end"] != float64(39) {
+		t.Errorf("unexpected body %#v", body)
+	}
+
+	w = httptest.NewRecorder()
+	encodeError(context.Background(), ErrNotFound, w)
+	body = nil
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["code"]; ok {
+		t.Errorf("unexpected body %#v", body)
+	}
+}
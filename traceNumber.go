@@ -0,0 +1,199 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"strconv"
+	"sync"
+)
+
+// maxTraceSequence is the largest 7 digit sequence number of a TraceNumber
+const maxTraceSequence = 9999999
+
+// Errors specific to trace number allocation
+var (
+	msgTraceNumberODFI      = "is not an 8 digit ODFI routing number"
+	msgTraceNumberExhausted = "has no unused trace number sequences"
+)
+
+// TraceNumberStore keeps the last sequence number allocated for each ODFI so allocation
+// continues from the high-water mark after a restart.
+type TraceNumberStore interface {
+	// Load returns the last sequence allocated for odfi, or zero if none has been allocated
+	Load(odfi string) (int, error)
+	// Save records seq as the last sequence allocated for odfi
+	Save(odfi string, seq int) error
+}
+
+// MemoryTraceNumberStore is a TraceNumberStore of the sequences held in memory
+type MemoryTraceNumberStore struct {
+	mu   sync.Mutex
+	last map[string]int
+}
+
+// NewMemoryTraceNumberStore returns an empty MemoryTraceNumberStore
+func NewMemoryTraceNumberStore() *MemoryTraceNumberStore {
+	return &MemoryTraceNumberStore{last: make(map[string]int)}
+}
+
+// Load returns the last sequence allocated for odfi
+func (s *MemoryTraceNumberStore) Load(odfi string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last[odfi], nil
+}
+
+// Save records seq as the last sequence allocated for odfi
+func (s *MemoryTraceNumberStore) Save(odfi string, seq int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last[odfi] = seq
+	return nil
+}
+
+// TraceNumberAllocator hands out increasing 7 digit TraceNumber sequences for each ODFI.
+// It is safe for concurrent use, so batches built by several goroutines never share a
+// TraceNumber.
+type TraceNumberAllocator struct {
+	mu    sync.Mutex
+	store TraceNumberStore
+	// converters is composed for ACH to golang Converters
+	converters
+}
+
+// NewTraceNumberAllocator returns a TraceNumberAllocator that keeps its high-water marks in
+// store. A MemoryTraceNumberStore is used when store is nil.
+func NewTraceNumberAllocator(store TraceNumberStore) *TraceNumberAllocator {
+	if store == nil {
+		store = NewMemoryTraceNumberStore()
+	}
+	return &TraceNumberAllocator{store: store}
+}
+
+// Next returns the next sequence number of odfi
+func (a *TraceNumberAllocator) Next(odfi string) (int, error) {
+	return a.allocate(odfi, 1)
+}
+
+// allocate reserves n consecutive sequence numbers of odfi and returns the first of them
+func (a *TraceNumberAllocator) allocate(odfi string, n int) (int, error) {
+	key := a.stringField(odfi, 8)
+	if !isDigits(key) {
+		return 0, &FieldError{FieldName: "ODFIIdentification", Value: odfi, Msg: msgTraceNumberODFI, Code: ErrInvalidRoutingNumber.Code}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	last, err := a.store.Load(key)
+	if err != nil {
+		return 0, err
+	}
+	if last+n > maxTraceSequence {
+		return 0, &FieldError{FieldName: "TraceNumber", Value: key, Msg: msgTraceNumberExhausted, Code: ErrTraceNumberExhausted.Code}
+	}
+	if err := a.store.Save(key, last+n); err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
+
+// Renumber assigns new ascending TraceNumbers to every entry of file from the ODFI of its
+// batch, and updates the TraceNumber and EntryDetailSequenceNumber of their addenda to
+// match. Sequences are reserved a batch at a time.
+func (a *TraceNumberAllocator) Renumber(file *File) error {
+	for _, batch := range file.Batches {
+		if err := a.renumberBatch(batch); err != nil {
+			return err
+		}
+	}
+	for i := range file.IATBatches {
+		if err := a.renumberIATBatch(&file.IATBatches[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renumberBatch assigns new TraceNumbers to the entries and addenda of batch
+func (a *TraceNumberAllocator) renumberBatch(batch Batcher) error {
+	entries := batch.GetEntries()
+	if len(entries) == 0 {
+		return nil
+	}
+	odfi := batch.GetHeader().ODFIIdentification
+	seq, err := a.allocate(odfi, len(entries))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.SetTraceNumber(odfi, seq)
+		for _, addenda := range entry.Addendum {
+			a.setAddendaTraceNumber(addenda, entry.TraceNumber)
+		}
+		seq++
+	}
+	return nil
+}
+
+// renumberIATBatch assigns new TraceNumbers to the entries and addenda of an IAT batch
+func (a *TraceNumberAllocator) renumberIATBatch(batch *IATBatch) error {
+	entries := batch.GetEntries()
+	if len(entries) == 0 {
+		return nil
+	}
+	odfi := batch.GetHeader().ODFIIdentification
+	seq, err := a.allocate(odfi, len(entries))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		entry.SetTraceNumber(odfi, seq)
+		if entry.Addenda10 != nil {
+			entry.Addenda10.EntryDetailSequenceNumber = seq
+		}
+		if entry.Addenda11 != nil {
+			entry.Addenda11.EntryDetailSequenceNumber = seq
+		}
+		if entry.Addenda12 != nil {
+			entry.Addenda12.EntryDetailSequenceNumber = seq
+		}
+		if entry.Addenda13 != nil {
+			entry.Addenda13.EntryDetailSequenceNumber = seq
+		}
+		if entry.Addenda14 != nil {
+			entry.Addenda14.EntryDetailSequenceNumber = seq
+		}
+		if entry.Addenda15 != nil {
+			entry.Addenda15.EntryDetailSequenceNumber = seq
+		}
+		if entry.Addenda16 != nil {
+			entry.Addenda16.EntryDetailSequenceNumber = seq
+		}
+		for _, addenda := range entry.Addendum {
+			a.setAddendaTraceNumber(addenda, entry.TraceNumber)
+		}
+		seq++
+	}
+	return nil
+}
+
+// setAddendaTraceNumber sets the TraceNumber or EntryDetailSequenceNumber of addenda to those
+// of the entry TraceNumber trace. The OriginalTrace of return and NOC addenda is kept.
+func (a *TraceNumberAllocator) setAddendaTraceNumber(addenda Addendumer, trace int) {
+	seq, _ := strconv.Atoi(a.numericField(trace, 15)[8:])
+	switch addenda := addenda.(type) {
+	case *Addenda02:
+		addenda.TraceNumber = trace
+	case *Addenda05:
+		addenda.EntryDetailSequenceNumber = seq
+	case *Addenda17:
+		addenda.EntryDetailSequenceNumber = seq
+	case *Addenda18:
+		addenda.EntryDetailSequenceNumber = seq
+	case *Addenda98:
+		addenda.TraceNumber = trace
+	case *Addenda99:
+		addenda.TraceNumber = trace
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// errTraceNumberStore is a TraceNumberStore that always fails
+type errTraceNumberStore struct{}
+
+func (errTraceNumberStore) Load(odfi string) (int, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func (errTraceNumberStore) Save(odfi string, seq int) error {
+	return errors.New("store unavailable")
+}
+
+// testTraceNumberAllocatorNext validates sequences are unique and increasing across goroutines
+func testTraceNumberAllocatorNext(t testing.TB) {
+	store := NewMemoryTraceNumberStore()
+	a := NewTraceNumberAllocator(store)
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := 0
+			for i := 0; i < 50; i++ {
+				seq, err := a.Next("12104288")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if seq <= last {
+					t.Errorf("sequence %d after %d", seq, last)
+				}
+				last = seq
+				mu.Lock()
+				seen[seq] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 400 {
+		t.Errorf("expected 400 unique sequences got %d", len(seen))
+	}
+
+	// a new allocator continues from the high-water mark of the store
+	if seq, _ := NewTraceNumberAllocator(store).Next("12104288"); seq != 401 {
+		t.Errorf("sequence %d expected 401", seq)
+	}
+	if seq, _ := a.Next("23138010"); seq != 1 {
+		t.Errorf("sequence %d of a new ODFI expected 1", seq)
+	}
+
+	store.Save("23138010", maxTraceSequence)
+	if _, err := a.Next("23138010"); err == nil {
+		t.Error("expected an error when sequences are exhausted")
+	} else if e, ok := err.(*FieldError); !ok || e.Msg != msgTraceNumberExhausted {
+		t.Errorf("%T: %s", err, err)
+	}
+	if _, err := a.Next("ODFI"); err == nil {
+		t.Error("expected an error for an invalid ODFI")
+	}
+	if _, err := NewTraceNumberAllocator(errTraceNumberStore{}).Next("12104288"); err == nil {
+		t.Error("expected a store error")
+	}
+}
+
+// TestTraceNumberAllocatorNext tests sequences are unique and increasing across goroutines
+func TestTraceNumberAllocatorNext(t *testing.T) {
+	testTraceNumberAllocatorNext(t)
+}
+
+// BenchmarkTraceNumberAllocatorNext benchmarks sequences are unique and increasing across goroutines
+func BenchmarkTraceNumberAllocatorNext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testTraceNumberAllocatorNext(b)
+	}
+}
+
+// testTraceNumberAllocatorRenumber validates renumbering the entries and addenda of a file
+func testTraceNumberAllocatorRenumber(t testing.TB) {
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	for i := 0; i < 2; i++ {
+		entry := mockPPDEntryDetail()
+		entry.SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, i+1)
+		entry.AddendaRecordIndicator = 1
+		entry.AddAddenda(mockAddenda05())
+		batch.AddEntry(entry)
+	}
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	iatBatch := mockIATBatch()
+	iatBatch.Entries[0].AddIATAddenda(mockAddenda17())
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	file.AddIATBatch(iatBatch)
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	store := NewMemoryTraceNumberStore()
+	store.Save("12104288", 41)
+	store.Save("23138010", 7)
+	if err := NewTraceNumberAllocator(store).Renumber(file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	entries := file.Batches[0].GetEntries()
+	if entries[0].TraceNumber != 121042880000042 || entries[1].TraceNumber != 121042880000043 {
+		t.Errorf("TraceNumbers %d %d", entries[0].TraceNumber, entries[1].TraceNumber)
+	}
+	if a := entries[1].Addendum[0].(*Addenda05); a.EntryDetailSequenceNumber != 43 {
+		t.Errorf("Addenda05 EntryDetailSequenceNumber %d", a.EntryDetailSequenceNumber)
+	}
+	iatEntry := file.IATBatches[0].GetEntries()[0]
+	if iatEntry.TraceNumber != 231380100000008 || iatEntry.Addenda16.EntryDetailSequenceNumber != 8 {
+		t.Errorf("IAT TraceNumber %d Addenda16 %d", iatEntry.TraceNumber, iatEntry.Addenda16.EntryDetailSequenceNumber)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if last, _ := store.Load("12104288"); last != 43 {
+		t.Errorf("high-water mark %d", last)
+	}
+
+	// returns keep the OriginalTrace of their addenda
+	entry := mockPPDEntryDetail()
+	addenda99 := mockAddenda99()
+	original := addenda99.OriginalTrace
+	entry.AddAddenda(addenda99)
+	returns := NewBatchPPD(mockBatchPPDHeader())
+	returns.AddEntry(entry)
+	file = NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(returns)
+	if err := NewTraceNumberAllocator(store).Renumber(file); err != nil {
+		t.Fatal(err)
+	}
+	if addenda99.TraceNumber != 121042880000044 || addenda99.OriginalTrace != original {
+		t.Errorf("Addenda99 TraceNumber %d OriginalTrace %d", addenda99.TraceNumber, addenda99.OriginalTrace)
+	}
+}
+
+// TestTraceNumberAllocatorRenumber tests renumbering the entries and addenda of a file
+func TestTraceNumberAllocatorRenumber(t *testing.T) {
+	testTraceNumberAllocatorRenumber(t)
+}
+
+// BenchmarkTraceNumberAllocatorRenumber benchmarks renumbering the entries and addenda of a file
+func BenchmarkTraceNumberAllocatorRenumber(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testTraceNumberAllocatorRenumber(b)
+	}
+}
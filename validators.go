@@ -5,16 +5,17 @@
 package ach
 
 import (
-	"errors"
 	"fmt"
 	"math"
-	"regexp"
-	"strconv"
+	"strings"
+	"time"
 )
 
 var (
-	upperAlphanumericRegex = regexp.MustCompile(`[^ A-Z0-9!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
-	alphanumericRegex      = regexp.MustCompile(`[^ \w!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
+	// upperAlphanumericChars and alphanumericChars are the bytes allowed in upper case
+	// alphanumeric and alphanumeric fields
+	upperAlphanumericChars = newCharSet(` ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!"#$%&'()*+,-./\:;<>=?@[]^_{}|~`)
+	alphanumericChars      = newCharSet(` ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!"#$%&'()*+,-./\:;<>=?@[]^_{}|~`)
 	// Errors specific to validation
 	msgAlphanumeric        = "has non alphanumeric characters"
 	msgUpperAlpha          = "is not uppercase A-Z or 0-9"
@@ -36,19 +37,64 @@ var (
 	msgForeignExchangeReferenceIndicator = "is an invalid Foreign Exchange Reference Indicator"
 	msgTransactionTypeCode               = "is an invalid Addenda10 Transaction Type Code"
 	msgIDNumberQualifier                 = "is an invalid Identification Number Qualifier"
+	msgValidCountryCode                  = "is not a valid ISO 3166-1 alpha-2 country code"
+	msgValidCurrencyCode                 = "is not a valid ISO 4217 currency code"
+	msgCityStateProvince                 = "must be City*State or Province\\ separated by * and ending with \\"
+	msgCountryPostalCode                 = "must be Country*Postal Code\\ separated by * and ending with \\"
 )
 
+// ValidateOpts are optional checks made in addition to the NACHA formatting rules
+// of a record. A nil *ValidateOpts only applies the formatting rules.
+type ValidateOpts struct {
+	// RoutingDirectory, when set, requires the RDFI of entries and the ImmediateDestination
+	// of the file header to be eligible participants of the FedACH directory.
+	RoutingDirectory *RoutingDirectory
+	// Calendar, when set, requires the EffectiveEntryDate of batch headers to be a banking day
+	Calendar *Calendar
+	// RollForwardEffectiveDate moves an EffectiveEntryDate that is not a banking day of Calendar
+	// to the next banking day instead of returning an error.
+	RollForwardEffectiveDate bool
+	// SameDay enforces the Same Day ACH rules on batches tagged as Same Day or whose
+	// EffectiveEntryDate is the creation date of the file: the SDHHMM descriptive date, the
+	// entry limit and the exclusion of IAT entries.
+	SameDay bool
+	// OriginalSettlementDate, when set, looks up the settlement date of the original entry of a
+	// return so the Reader can report returns received after their deadline as LateReturns.
+	OriginalSettlementDate func(addenda99 *Addenda99) (time.Time, bool)
+	// Screener, when set, is given to the IAT batches read by the Reader to screen their entries
+	Screener Screener
+	// Workers is the number of goroutines File.ValidateWithContext validates batches with, one
+	// when it is not greater than one
+	Workers int
+}
+
+// validateEffectiveEntryDate checks date is a banking day of opts.Calendar and returns the
+// date rolled forward to a banking day when opts.RollForwardEffectiveDate is set.
+func (opts *ValidateOpts) validateEffectiveEntryDate(date time.Time) (time.Time, error) {
+	if opts.Calendar == nil || date.IsZero() || opts.Calendar.IsBankingDay(date) {
+		return date, nil
+	}
+	if opts.RollForwardEffectiveDate {
+		return opts.Calendar.rollForward(date), nil
+	}
+	return date, &FieldError{FieldName: "EffectiveEntryDate", Value: date.Format("060102"), Msg: msgBankingDay, Code: ErrBankingDay.Code}
+}
+
 // validator is common validation and formatting of golang types to ach type strings
 type validator struct{}
 
 // FieldError is returned for errors at a field level in a record
 type FieldError struct {
-	FieldName string // field name where error happened
-	Value     string // value that cause error
-	Msg       string // context of the error.
+	FieldName string    // field name where error happened
+	Value     string    // value that cause error
+	Msg       string    // context of the error.
+	Code      ErrorCode // code of the error, ErrInvalidField when empty
+	Line      int       // line of the record read by a Reader, 0 otherwise
+	Start     int       // first position of the field in the record, 0 when unknown
+	End       int       // last position of the field in the record, 0 when unknown
 }
 
-// ToDo:  Add state and country look-up or use a 3rd party look up -verify with Wade
+// ToDo:  Add state look-up or use a 3rd party look up -verify with Wade
 
 // Error message is constructed
 // FieldName Msg Value
@@ -58,6 +104,54 @@ func (e *FieldError) Error() string {
 	return fmt.Sprintf("%s %s %s", e.FieldName, e.Value, e.Msg)
 }
 
+// isCountryCode ensures code is an ISO 3166-1 alpha-2 country code
+func (v *validator) isCountryCode(code string) error {
+	if _, ok := countryCodeDict[code]; !ok {
+		return errorWithCode(ErrInvalidCountryCode, msgValidCountryCode)
+	}
+	return nil
+}
+
+// isCurrencyCode ensures code is an ISO 4217 alphabetic currency code
+func (v *validator) isCurrencyCode(code string) error {
+	if _, ok := currencyCodeDict[code]; !ok {
+		return errorWithCode(ErrInvalidCurrencyCode, msgValidCurrencyCode)
+	}
+	return nil
+}
+
+// isCityStateProvince ensures an IAT city and state or province is formatted as City*State\
+func (v *validator) isCityStateProvince(s string) error {
+	if _, ok := splitIATDelimited(s); !ok {
+		return errorWithCode(ErrInvalidCityStateProvince, msgCityStateProvince)
+	}
+	return nil
+}
+
+// isCountryPostalCode ensures an IAT country and postal code is formatted as Country*Postal Code\
+// with an ISO 3166-1 alpha-2 country code
+func (v *validator) isCountryPostalCode(s string) error {
+	elements, ok := splitIATDelimited(s)
+	if !ok {
+		return errorWithCode(ErrInvalidCountryPostalCode, msgCountryPostalCode)
+	}
+	return v.isCountryCode(elements[0])
+}
+
+// splitIATDelimited returns the two elements of an IAT address field separated by "*" and
+// terminated by "\", and false if the field is not delimited that way
+func splitIATDelimited(s string) ([]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasSuffix(s, "\\") {
+		return nil, false
+	}
+	elements := strings.Split(strings.TrimSuffix(s, "\\"), "*")
+	if len(elements) != 2 || elements[0] == "" || strings.Contains(elements[1], "\\") {
+		return nil, false
+	}
+	return elements, true
+}
+
 // isCardTransactionType ensures card transaction type of a batchPOS is valid
 func (v *validator) isCardTransactionType(code string) error {
 	switch code {
@@ -80,7 +174,7 @@ func (v *validator) isCardTransactionType(code string) error {
 		"99":
 		return nil
 	}
-	return errors.New(msgCardTransactionType)
+	return errorWithCode(ErrInvalidCardTransactionType, msgCardTransactionType)
 }
 
 // isYear validates a 2 digit year 18-50 (2018 - 2050)
@@ -95,7 +189,7 @@ func (v *validator) isYear(s string) error {
 		"50":
 		return nil
 	}
-	return errors.New(msgValidYear)
+	return errorWithCode(ErrInvalidDate, msgValidYear)
 }
 
 // isMonth validates a 2 digit month 01-12
@@ -106,7 +200,7 @@ func (v *validator) isMonth(s string) error {
 		"07", "08", "09", "10", "11", "12":
 		return nil
 	}
-	return errors.New(msgValidMonth)
+	return errorWithCode(ErrInvalidDate, msgValidMonth)
 }
 
 // isDay validates a 2 digit day based on a 2 digit month
@@ -148,7 +242,7 @@ func (v *validator) isDay(m string, d string) error {
 			return nil
 		}
 	}
-	return errors.New(msgValidDay)
+	return errorWithCode(ErrInvalidDate, msgValidDay)
 }
 
 // isForeignExchangeIndicator ensures foreign exchange indicators of an
@@ -159,7 +253,7 @@ func (v *validator) isForeignExchangeIndicator(code string) error {
 		"FV", "VF", "FF":
 		return nil
 	}
-	return errors.New(msgForeignExchangeIndicator)
+	return errorWithCode(ErrInvalidForeignExchangeIndicator, msgForeignExchangeIndicator)
 }
 
 // isForeignExchangeReferenceIndicator ensures foreign exchange reference
@@ -170,7 +264,7 @@ func (v *validator) isForeignExchangeReferenceIndicator(code int) error {
 		1, 2, 3:
 		return nil
 	}
-	return errors.New(msgForeignExchangeReferenceIndicator)
+	return errorWithCode(ErrInvalidForeignExchangeReferenceIndicator, msgForeignExchangeReferenceIndicator)
 }
 
 // isIDNumberQualifier ensures ODFI Identification Number Qualifier is valid
@@ -186,7 +280,7 @@ func (v *validator) isIDNumberQualifier(s string) error {
 		"01", "02", "03":
 		return nil
 	}
-	return errors.New(msgIDNumberQualifier)
+	return errorWithCode(ErrInvalidIDNumberQualifier, msgIDNumberQualifier)
 }
 
 // isOriginatorStatusCode ensures status code of a batch is valid
@@ -201,7 +295,7 @@ func (v *validator) isOriginatorStatusCode(code int) error {
 		2:
 		return nil
 	}
-	return errors.New(msgOrigStatusCode)
+	return errorWithCode(ErrInvalidOriginatorStatusCode, msgOrigStatusCode)
 }
 
 // isSECCode returns true if a SEC Code of a Batch is found
@@ -212,7 +306,7 @@ func (v *validator) isSECCode(code string) error {
 		"IAT", "MTE", "POS", "PPD", "POP", "RCK", "SHR", "TEL", "TRC", "TRX", "WEB", "XCK":
 		return nil
 	}
-	return errors.New(msgSECCode)
+	return errorWithCode(ErrInvalidSECCode, msgSECCode)
 }
 
 // iServiceClass returns true if a valid service class code of a batch is found
@@ -229,7 +323,7 @@ func (v *validator) isServiceClass(code int) error {
 		280:
 		return nil
 	}
-	return errors.New(msgServiceClass)
+	return errorWithCode(ErrInvalidServiceClassCode, msgServiceClass)
 }
 
 // isTypeCode returns true if a valid type code of an Addendum is found
@@ -252,7 +346,7 @@ func (v *validator) isTypeCode(code string) error {
 		"05":
 		return nil
 	}
-	return errors.New(msgAddendaTypeCode)
+	return errorWithCode(ErrInvalidAddendaTypeCode, msgAddendaTypeCode)
 }
 
 // isTransactionCode ensures TransactionCode of an Entry is valid
@@ -381,7 +475,7 @@ func (v *validator) isTransactionCode(code int) error {
 		88:
 		return nil
 	}
-	return errors.New(msgTransactionCode)
+	return errorWithCode(ErrInvalidTransactionCode, msgTransactionCode)
 }
 
 // isTransactionTypeCode verifies Addenda10 TransactionTypeCode is a valid value
@@ -396,22 +490,44 @@ func (v *validator) isTransactionTypeCode(s string) error {
 		"ARC", "BOC", "POP", "RCK":
 		return nil
 	}
-	return errors.New(msgTransactionTypeCode)
+	return errorWithCode(ErrInvalidTransactionTypeCode, msgTransactionTypeCode)
+}
+
+// charSet is a set of ASCII bytes
+type charSet [256]bool
+
+// newCharSet returns the set of the bytes of chars
+func newCharSet(chars string) *charSet {
+	set := new(charSet)
+	for i := 0; i < len(chars); i++ {
+		set[chars[i]] = true
+	}
+	return set
+}
+
+// contains reports whether every byte of s is in the set
+func (set *charSet) contains(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !set[s[i]] {
+			return false
+		}
+	}
+	return true
 }
 
 // isUpperAlphanumeric checks if string only contains ASCII alphanumeric upper case characters
 func (v *validator) isUpperAlphanumeric(s string) error {
-	if upperAlphanumericRegex.MatchString(s) {
-		return errors.New(msgUpperAlpha)
+	if !upperAlphanumericChars.contains(s) {
+		return errorWithCode(ErrNonUpperAlphanumeric, msgUpperAlpha)
 	}
 	return nil
 }
 
 // isAlphanumeric checks if a string only contains ASCII alphanumeric characters
 func (v *validator) isAlphanumeric(s string) error {
-	if alphanumericRegex.MatchString(s) {
+	if !alphanumericChars.contains(s) {
 		// ^[ A-Za-z0-9_@./#&+-]*$/
-		return errors.New(msgAlphanumeric)
+		return errorWithCode(ErrNonAlphanumeric, msgAlphanumeric)
 	}
 	return nil
 }
@@ -424,27 +540,14 @@ func (v *validator) isAlphanumeric(s string) error {
 // Subtract the sum from the next highest multiple of 10.
 // The result is the Check Digit
 func (v *validator) CalculateCheckDigit(routingNumber string) int {
-	var routeIndex [8]string
-	for i := 0; i < 8; i++ {
-		routeIndex[i] = string(routingNumber[i])
+	weights := [8]int{3, 7, 1, 3, 7, 1, 3, 7}
+	sum := 0
+	for i, weight := range weights {
+		// characters that are not digits count as zero
+		if c := routingNumber[i]; c >= '0' && c <= '9' {
+			sum += int(c-'0') * weight
+		}
 	}
-	n, _ := strconv.Atoi(routeIndex[0])
-	sum := n * 3
-	n, _ = strconv.Atoi(routeIndex[1])
-	sum = sum + (n * 7)
-	n, _ = strconv.Atoi(routeIndex[2])
-	sum = sum + n // multiply by 1
-	n, _ = strconv.Atoi(routeIndex[3])
-	sum = sum + (n * 3)
-	n, _ = strconv.Atoi(routeIndex[4])
-	sum = sum + (n * 7)
-	n, _ = strconv.Atoi(routeIndex[5])
-	sum = sum + n // multiply by 1
-	n, _ = strconv.Atoi(routeIndex[6])
-	sum = sum + (n * 3)
-	n, _ = strconv.Atoi(routeIndex[7])
-	sum = sum + (n * 7)
-
 	return v.roundUp10(sum) - sum
 }
 
@@ -0,0 +1,74 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"regexp"
+	"testing"
+)
+
+// testCharSets validates the alphanumeric character sets allow the characters of the
+// regular expressions they replaced
+func testCharSets(t testing.TB) {
+	upper := regexp.MustCompile(`[^ A-Z0-9!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
+	alpha := regexp.MustCompile(`[^ \w!"#$%&'()*+,-.\\/:;<>=?@\[\]^_{}|~]+`)
+	v := &validator{}
+	for c := 0; c < 256; c++ {
+		s := string([]byte{'A', byte(c), '0'})
+		if got, want := v.isUpperAlphanumeric(s) == nil, !upper.MatchString(s); got != want {
+			t.Errorf("isUpperAlphanumeric(%q) %v", s, got)
+		}
+		if got, want := v.isAlphanumeric(s) == nil, !alpha.MatchString(s); got != want {
+			t.Errorf("isAlphanumeric(%q) %v", s, got)
+		}
+	}
+	if v.isAlphanumeric("Wade Arnold ñ") == nil {
+		t.Error("non ASCII characters are alphanumeric")
+	}
+}
+
+// TestCharSets tests the alphanumeric character sets allow the characters of the regular
+// expressions they replaced
+func TestCharSets(t *testing.T) {
+	testCharSets(t)
+}
+
+// BenchmarkCharSets benchmarks the alphanumeric character sets allow the characters of the
+// regular expressions they replaced
+func BenchmarkCharSets(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCharSets(b)
+	}
+}
+
+// testCalculateCheckDigit validates the check digit of routing numbers
+func testCalculateCheckDigit(t testing.TB) {
+	v := &validator{}
+	tests := map[string]int{
+		"23138010": 4,
+		"12104288": 2,
+		"00000000": 0,
+		"9100000A": 6,
+	}
+	for routingNumber, want := range tests {
+		if got := v.CalculateCheckDigit(routingNumber); got != want {
+			t.Errorf("CalculateCheckDigit(%q) %d want %d", routingNumber, got, want)
+		}
+	}
+}
+
+// TestCalculateCheckDigit tests the check digit of routing numbers
+func TestCalculateCheckDigit(t *testing.T) {
+	testCalculateCheckDigit(t)
+}
+
+// BenchmarkCalculateCheckDigit benchmarks the check digit of routing numbers
+func BenchmarkCalculateCheckDigit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testCalculateCheckDigit(b)
+	}
+}
@@ -6,10 +6,16 @@ package ach
 
 import (
 	"bufio"
+	"context"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
 )
 
+// fillerRecord is the record of "9" the last block is padded with
+var fillerRecord = strings.Repeat("9", RecordLength)
+
 // A Writer writes an ach.file to a NACHA encoded file.
 //
 // As returned by NewWriter, a Writer writes ach.file structs into
@@ -18,27 +24,55 @@ import (
 type Writer struct {
 	w       *bufio.Writer
 	lineNum int //current line being written
+	buf     []byte
+	ctx     context.Context
+
+	// LineEnding is written after each record. It is "\n" by default, "\r\n" for CRLF line
+	// endings or empty for a single fixed-width stream of records.
+	LineEnding string
+	// BlockPadding pads the file with records of "9" to a multiple of BlockingFactor records.
+	// It is true by default.
+	BlockPadding bool
+	// BlockingFactor is the number of records in a block from 1 to 99, 10 by default. It is
+	// written as the blocking factor of the FileHeader and the FileControl BlockCount is the
+	// number of blocks of BlockingFactor records. The FileHeader and FileControl are written
+	// unchanged when it is zero.
+	BlockingFactor int
+	// Encoding is the character encoding of the file written, ASCII by default
+	Encoding Encoding
 }
 
 // NewWriter returns a new Writer that writes to w.
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{
-		w: bufio.NewWriter(w),
+		w:              bufio.NewWriter(w),
+		LineEnding:     "\n",
+		BlockPadding:   true,
+		BlockingFactor: 10,
 	}
 }
 
 // Writer writes a single ach.file record to w
 func (w *Writer) Write(file *File) error {
+	return w.WriteContext(context.Background(), file)
+}
+
+// WriteContext performs Write and stops with ctx.Err() when ctx is done before a record is
+// written.
+func (w *Writer) WriteContext(ctx context.Context, file *File) error {
 	if err := file.Validate(); err != nil {
 		return err
 	}
+	if w.BlockingFactor < 0 || w.BlockingFactor > 99 {
+		return &FileError{FieldName: "BlockingFactor", Value: strconv.Itoa(w.BlockingFactor), Msg: msgBlockingFactor, Code: ErrFileHeaderFormat.Code}
+	}
+	w.ctx = ctx
 
 	w.lineNum = 0
 	// Iterate over all records in the file
-	if _, err := w.w.WriteString(file.Header.String() + "\n"); err != nil {
+	if err := w.writeRecord(w.blockedHeader(&file.Header)); err != nil {
 		return err
 	}
-	w.lineNum++
 
 	if err := w.writeBatch(file); err != nil {
 		return err
@@ -48,103 +82,139 @@ func (w *Writer) Write(file *File) error {
 		return err
 	}
 
-	if _, err := w.w.WriteString(file.Control.String() + "\n"); err != nil {
+	if err := w.writeRecord(w.blockedControl(&file.Control)); err != nil {
 		return err
 	}
-	w.lineNum++
 
 	// pad the final block
-	for i := 0; i < (10-(w.lineNum%10)) && w.lineNum%10 != 0; i++ {
-		if _, err := w.w.WriteString(strings.Repeat("9", 94) + "\n"); err != nil {
-			return err
+	if w.BlockPadding && w.BlockingFactor > 0 {
+		for w.lineNum%w.BlockingFactor != 0 {
+			w.buf = append(w.buf[:0], fillerRecord...)
+			if err := w.writeLine(); err != nil {
+				return err
+			}
 		}
 	}
 
 	return w.w.Flush()
 }
 
+// blockedHeader returns fh, or a copy of fh with the BlockingFactor of the Writer
+func (w *Writer) blockedHeader(fh *FileHeader) *FileHeader {
+	if factor, _ := strconv.Atoi(fh.blockingFactor); w.BlockingFactor == 0 || factor == w.BlockingFactor {
+		return fh
+	}
+	header := *fh
+	header.blockingFactor = fmt.Sprintf("%02d", w.BlockingFactor)
+	return &header
+}
+
+// blockedControl returns fc, or a copy of fc with the BlockCount of the records written and
+// fc in blocks of the BlockingFactor of the Writer
+func (w *Writer) blockedControl(fc *FileControl) *FileControl {
+	if w.BlockingFactor == 0 {
+		return fc
+	}
+	blocks := (w.lineNum + w.BlockingFactor) / w.BlockingFactor
+	if fc.BlockCount == blocks {
+		return fc
+	}
+	control := *fc
+	control.BlockCount = blocks
+	return &control
+}
+
 // Flush writes any buffered data to the underlying io.Writer.
 func (w *Writer) Flush() error {
 	return w.w.Flush()
 }
 
+// writeRecord writes a record followed by the LineEnding
+func (w *Writer) writeRecord(record interface{ String() string }) error {
+	w.buf = appendRecord(w.buf[:0], record)
+	return w.writeLine()
+}
+
+// writeLine writes the record in buf followed by the LineEnding
+func (w *Writer) writeLine() error {
+	if done := w.ctx.Done(); done != nil {
+		select {
+		case <-done:
+			return w.ctx.Err()
+		default:
+		}
+	}
+	w.lineNum++
+	w.buf = append(w.buf, w.LineEnding...)
+	if w.Encoding == EBCDIC {
+		transcode(w.buf, &latin1ToEBCDIC)
+	}
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
 func (w *Writer) writeBatch(file *File) error {
 	for _, batch := range file.Batches {
-		if _, err := w.w.WriteString(batch.GetHeader().String() + "\n"); err != nil {
+		if err := w.writeRecord(batch.GetHeader()); err != nil {
 			return err
 		}
-		w.lineNum++
 		for _, entry := range batch.GetEntries() {
-			if _, err := w.w.WriteString(entry.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry); err != nil {
 				return err
 			}
-			w.lineNum++
 			for _, addenda := range entry.Addendum {
-				if _, err := w.w.WriteString(addenda.String() + "\n"); err != nil {
+				if err := w.writeRecord(addenda); err != nil {
 					return err
 				}
-				w.lineNum++
 			}
 		}
-		if _, err := w.w.WriteString(batch.GetControl().String() + "\n"); err != nil {
+		if err := w.writeRecord(batch.GetControl()); err != nil {
 			return err
 		}
-		w.lineNum++
 	}
 	return nil
 }
 
 func (w *Writer) writeIATBatch(file *File) error {
 	for _, iatBatch := range file.IATBatches {
-		if _, err := w.w.WriteString(iatBatch.GetHeader().String() + "\n"); err != nil {
+		if err := w.writeRecord(iatBatch.GetHeader()); err != nil {
 			return err
 		}
-		w.lineNum++
 		for _, entry := range iatBatch.GetEntries() {
-			if _, err := w.w.WriteString(entry.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda10.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda10); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda11.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda11); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda12.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda12); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda13.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda13); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda14.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda14); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda15.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda15); err != nil {
 				return err
 			}
-			w.lineNum++
-			if _, err := w.w.WriteString(entry.Addenda16.String() + "\n"); err != nil {
+			if err := w.writeRecord(entry.Addenda16); err != nil {
 				return err
 			}
-			w.lineNum++
 			// IAT Addenda17 and IAT Addenda18 records
 			for _, IATaddenda := range entry.Addendum {
-				if _, err := w.w.WriteString(IATaddenda.String() + "\n"); err != nil {
+				if err := w.writeRecord(IATaddenda); err != nil {
 					return err
 				}
-				w.lineNum++
 			}
 		}
-		if _, err := w.w.WriteString(iatBatch.GetControl().String() + "\n"); err != nil {
+		if err := w.writeRecord(iatBatch.GetControl()); err != nil {
 			return err
 		}
-		w.lineNum++
 	}
 	return nil
 }
@@ -6,8 +6,15 @@ package ach
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 // testPPDWrite writes a PPD ACH file
@@ -352,3 +359,302 @@ func BenchmarkIATReturn(b *testing.B) {
 		testIATReturn(b)
 	}
 }
+
+// testWriterFormat validates writing line endings and block padding and reading them back
+func testWriterFormat(t testing.TB) {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchPPD())
+	if err := file.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	tests := []struct {
+		lineEnding     string
+		blockPadding   bool
+		blockingFactor int
+		length         int
+	}{
+		{"\n", true, 10, 10 * 95},
+		{"\r\n", true, 10, 10 * 96},
+		{"", true, 10, 10 * 94},
+		{"\n", false, 10, 5 * 95},
+		{"\r\n", true, 3, 6 * 96},
+	}
+	for _, test := range tests {
+		b := &bytes.Buffer{}
+		w := NewWriter(b)
+		w.LineEnding = test.lineEnding
+		w.BlockPadding = test.blockPadding
+		w.BlockingFactor = test.blockingFactor
+		if err := w.Write(file); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+		if b.Len() != test.length {
+			t.Errorf("%q %v %d: length %d want %d", test.lineEnding, test.blockPadding, test.blockingFactor, b.Len(), test.length)
+		}
+
+		r := NewReader(bytes.NewReader(b.Bytes()))
+		read, err := r.Read()
+		if err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+		if r.LineEnding != test.lineEnding {
+			t.Errorf("read LineEnding %q want %q", r.LineEnding, test.lineEnding)
+		}
+
+		// writing with the format read gives the same file
+		out := &bytes.Buffer{}
+		w = NewWriter(out)
+		w.LineEnding = r.LineEnding
+		w.BlockPadding = r.BlockPadding
+		w.BlockingFactor = r.BlockingFactor
+		if err := w.Write(&read); err != nil {
+			t.Fatalf("%T: %s", err, err)
+		}
+		if out.String() != b.String() {
+			t.Errorf("%q %v %d: round trip\n%q\nwant\n%q", test.lineEnding, test.blockPadding, test.blockingFactor, out.String(), b.String())
+		}
+	}
+}
+
+// TestWriterFormat tests writing line endings and block padding and reading them back
+func TestWriterFormat(t *testing.T) {
+	testWriterFormat(t)
+}
+
+// BenchmarkWriterFormat benchmarks writing line endings and block padding and reading them back
+func BenchmarkWriterFormat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriterFormat(b)
+	}
+}
+
+// testWriterBlockingFactor validates the FileHeader and BlockCount of a file written with a
+// BlockingFactor and reading them back
+func testWriterBlockingFactor(t testing.TB) {
+	// 12 records in blocks of 5
+	file, err := NewReader(bytes.NewReader(mockLargeFile(t, 8))).Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	b := &bytes.Buffer{}
+	w := NewWriter(b)
+	w.BlockingFactor = 5
+	if err := w.Write(&file); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 15 || lines[14] != fillerRecord {
+		t.Fatalf("%d lines\n%s", len(lines), b.String())
+	}
+	if factor := lines[0][37:39]; factor != "05" {
+		t.Errorf("blocking factor %s", factor)
+	}
+	if blocks := lines[11][7:13]; blocks != "000003" {
+		t.Errorf("BlockCount %s", blocks)
+	}
+	if file.Header.blockingFactor != "10" || file.Control.BlockCount != 2 {
+		t.Error("Write modified the file")
+	}
+
+	r := NewReader(bytes.NewReader(b.Bytes()))
+	read, err := r.Read()
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if r.BlockingFactor != 5 || !r.BlockPadding || read.Control.BlockCount != 3 {
+		t.Errorf("BlockingFactor %d BlockPadding %v BlockCount %d", r.BlockingFactor, r.BlockPadding, read.Control.BlockCount)
+	}
+	out := &bytes.Buffer{}
+	w = NewWriter(out)
+	w.BlockingFactor = r.BlockingFactor
+	if err := w.Write(&read); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if out.String() != b.String() {
+		t.Errorf("round trip\n%s\nwant\n%s", out.String(), b.String())
+	}
+
+	// the control of a file created with the header read is in blocks of 5
+	if err := read.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if read.Control.BlockCount != 3 {
+		t.Errorf("created BlockCount %d", read.Control.BlockCount)
+	}
+
+	w.BlockingFactor = 100
+	if err := w.Write(&read); !errors.Is(err, ErrFileHeaderFormat) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
+// TestWriterBlockingFactor tests the FileHeader and BlockCount of a file written with a
+// BlockingFactor and reading them back
+func TestWriterBlockingFactor(t *testing.T) {
+	testWriterBlockingFactor(t)
+}
+
+// BenchmarkWriterBlockingFactor benchmarks the FileHeader and BlockCount of a file written
+// with a BlockingFactor and reading them back
+func BenchmarkWriterBlockingFactor(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriterBlockingFactor(b)
+	}
+}
+
+// appendRecords returns the records of file in the order they are written
+func appendRecords(file *File) []interface{ String() string } {
+	records := []interface{ String() string }{&file.Header}
+	for _, batch := range file.Batches {
+		records = append(records, batch.GetHeader())
+		for _, entry := range batch.GetEntries() {
+			records = append(records, entry)
+			for _, addenda := range entry.Addendum {
+				records = append(records, addenda)
+			}
+		}
+		records = append(records, batch.GetControl())
+	}
+	for _, iatBatch := range file.IATBatches {
+		records = append(records, iatBatch.GetHeader())
+		for _, entry := range iatBatch.GetEntries() {
+			records = append(records, entry, entry.Addenda10, entry.Addenda11, entry.Addenda12,
+				entry.Addenda13, entry.Addenda14, entry.Addenda15, entry.Addenda16)
+			for _, addenda := range entry.Addendum {
+				records = append(records, addenda)
+			}
+		}
+		records = append(records, iatBatch.GetControl())
+	}
+	return append(records, &file.Control)
+}
+
+// testRecordAppendTo validates AppendTo and WriteTo of every record type write the String of the record
+func testRecordAppendTo(t testing.TB) {
+	var records []interface{ String() string }
+	for _, name := range readFixtures {
+		f, err := os.Open("./test/data/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := NewReader(f).Read()
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %T: %s", name, err, err)
+		}
+		records = append(records, appendRecords(&file)...)
+	}
+
+	// fields longer than their width, negative numbers and dates that are set or zero
+	fh := mockFileHeader()
+	fh.ImmediateDestinationName = strings.Repeat("A", 30)
+	fc := mockFileControl()
+	fc.EntryHash = -12
+	ed := mockEntryDetail()
+	ed.IndividualName = strings.Repeat("B", 30)
+	ed.TraceNumber = 1234567890123456789
+	bh := mockBatchHeader()
+	bh.SettlementDate = time.Date(2018, time.July, 16, 0, 0, 0, 0, time.UTC)
+	iatBh := mockIATBatchHeaderFF()
+	iatBh.ForeignExchangeReferenceIndicator = 3
+	addenda99 := mockAddenda99()
+	addenda99.DateOfDeath = time.Date(2018, time.July, 16, 0, 0, 0, 0, time.UTC)
+	records = append(records, &fh, &fc, ed, bh, iatBh, addenda99, NewBatchHeader(), NewEntryDetail(),
+		mockBatchControl(), mockAddenda02(), mockAddenda05(), mockAddenda10(), mockAddenda11(),
+		mockAddenda12(), mockAddenda13(), mockAddenda14(), mockAddenda15(), mockAddenda16(),
+		mockAddenda17(), mockAddenda18(), mockAddenda98(), mockAddenda99(), mockIATEntryDetail())
+
+	buf := make([]byte, 0, RecordLength)
+	for _, record := range records {
+		want := record.String()
+		r, ok := record.(interface {
+			AppendTo([]byte) []byte
+			io.WriterTo
+		})
+		if !ok {
+			t.Fatalf("%T does not have AppendTo and WriteTo", record)
+		}
+		if got := string(r.AppendTo(buf[:0])); got != want {
+			t.Errorf("%T AppendTo\n%q\n%q", record, got, want)
+		}
+		if got := string(r.AppendTo([]byte("prefix"))); got != "prefix"+want {
+			t.Errorf("%T AppendTo does not append %q", record, got)
+		}
+		var w bytes.Buffer
+		if n, err := r.WriteTo(&w); err != nil || n != int64(len(want)) || w.String() != want {
+			t.Errorf("%T WriteTo %d %v\n%q\n%q", record, n, err, w.String(), want)
+		}
+	}
+}
+
+// TestRecordAppendTo tests AppendTo and WriteTo of every record type write the String of the record
+func TestRecordAppendTo(t *testing.T) {
+	testRecordAppendTo(t)
+}
+
+// BenchmarkRecordAppendTo benchmarks AppendTo and WriteTo of every record type write the String of the record
+func BenchmarkRecordAppendTo(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testRecordAppendTo(b)
+	}
+}
+
+// BenchmarkWriteLargeFile benchmarks writing a file of many entries and reports the
+// allocations per entry
+func BenchmarkWriteLargeFile(b *testing.B) {
+	const entries = 10000
+	file, err := NewReader(bytes.NewReader(mockLargeFile(b, entries))).Read()
+	if err != nil {
+		b.Fatalf("%T: %s", err, err)
+	}
+	w := NewWriter(ioutil.Discard)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	mallocs := stats.Mallocs
+	for i := 0; i < b.N; i++ {
+		if err := w.Write(&file); err != nil {
+			b.Fatalf("%T: %s", err, err)
+		}
+	}
+	runtime.ReadMemStats(&stats)
+	b.ReportMetric(float64(stats.Mallocs-mallocs)/float64(b.N*entries), "allocs/entry")
+}
+
+// testWriteContext validates a Writer stops writing once its context is done
+func testWriteContext(t testing.TB) {
+	file := mockFilePPD()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var buf bytes.Buffer
+	if err := NewWriter(&buf).WriteContext(ctx, file); err != context.Canceled {
+		t.Errorf("%T: %s", err, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %q", buf.String())
+	}
+	if err := NewWriter(&buf).WriteContext(context.Background(), file); err != nil {
+		t.Errorf("%T: %s", err, err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 10 {
+		t.Errorf("wrote %d lines", n)
+	}
+}
+
+// TestWriteContext tests a Writer stops writing once its context is done
+func TestWriteContext(t *testing.T) {
+	testWriteContext(t)
+}
+
+// BenchmarkWriteContext benchmarks a Writer stops writing once its context is done
+func BenchmarkWriteContext(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testWriteContext(b)
+	}
+}
@@ -0,0 +1,588 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// X12 delimiters used in the payment related information of CTX addenda
+const (
+	// X12ElementSeparator separates the elements of a segment
+	X12ElementSeparator = "*"
+	// X12SegmentTerminator ends each segment
+	X12SegmentTerminator = "\\"
+	// x12ISALength is the length of the fixed-width ISA segment including its terminator
+	x12ISALength = 106
+)
+
+// Errors specific to X12 remittance
+var (
+	msgX12Segment        = "is not a valid %s segment"
+	msgX12SegmentMissing = "segment is missing"
+	msgX12Transaction    = "is not an 820 Payment Order/Remittance Advice"
+	msgX12ControlNumber  = "does not match the control number %s"
+	msgX12SegmentCount   = "does not match the %d segments of the transaction set"
+	msgX12Amount         = "is not a decimal amount"
+	msgX12Date           = "is not a date in %s format"
+	msgX12NoAddenda      = "has no Addenda05 payment related information"
+)
+
+// X12Segment is a segment of an ANSI X12 transaction such as BPR*C*1250.00*C*ACH*CTX
+type X12Segment struct {
+	// ID is the segment identifier such as BPR
+	ID string
+	// Elements are the values of the segment after the ID, the first is element 01
+	Elements []string
+}
+
+// Element returns element n of the segment, starting at 1, or an empty string when the
+// segment has fewer elements
+func (s X12Segment) Element(n int) string {
+	if n < 1 || n > len(s.Elements) {
+		return ""
+	}
+	return s.Elements[n-1]
+}
+
+// String writes the segment with X12ElementSeparator and without a terminator. Trailing empty
+// elements are omitted.
+func (s X12Segment) String() string {
+	elements := s.Elements
+	for len(elements) > 0 && elements[len(elements)-1] == "" {
+		elements = elements[:len(elements)-1]
+	}
+	return strings.Join(append([]string{s.ID}, elements...), X12ElementSeparator)
+}
+
+// ParseX12 splits an X12 payload into segments. The delimiters are read from an ISA segment
+// at the start of the payload, otherwise X12ElementSeparator and X12SegmentTerminator are used.
+// Whitespace between segments and around element values is removed.
+func ParseX12(payload string) ([]X12Segment, error) {
+	payload = strings.TrimSpace(payload)
+	separator, terminator := X12ElementSeparator, X12SegmentTerminator
+	if strings.HasPrefix(payload, "ISA") && len(payload) >= x12ISALength {
+		separator, terminator = payload[3:4], payload[x12ISALength-1:x12ISALength]
+	}
+	var segments []X12Segment
+	for _, raw := range strings.Split(payload, terminator) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		elements := strings.Split(raw, separator)
+		for i := range elements {
+			elements[i] = strings.TrimSpace(elements[i])
+		}
+		if elements[0] == "" {
+			return nil, &FieldError{FieldName: "PaymentRelatedInformation", Value: raw, Msg: fmt.Sprintf(msgX12Segment, "X12"), Code: ErrInvalidX12.Code}
+		}
+		segments = append(segments, X12Segment{ID: elements[0], Elements: elements[1:]})
+	}
+	return segments, nil
+}
+
+// X12Interchange is the ISA interchange control header of an X12 payload
+type X12Interchange struct {
+	// AuthorizationQualifier is ISA01
+	AuthorizationQualifier string `json:"authorizationQualifier"`
+	// Authorization is ISA02
+	Authorization string `json:"authorization"`
+	// SecurityQualifier is ISA03
+	SecurityQualifier string `json:"securityQualifier"`
+	// Security is ISA04
+	Security string `json:"security"`
+	// SenderQualifier is ISA05
+	SenderQualifier string `json:"senderQualifier"`
+	// SenderID is ISA06
+	SenderID string `json:"senderID"`
+	// ReceiverQualifier is ISA07
+	ReceiverQualifier string `json:"receiverQualifier"`
+	// ReceiverID is ISA08
+	ReceiverID string `json:"receiverID"`
+	// Date is the date and time of ISA09 and ISA10
+	Date time.Time `json:"date"`
+	// StandardsID is ISA11, U for US EDI standards
+	StandardsID string `json:"standardsID"`
+	// Version is ISA12, such as 00401
+	Version string `json:"version"`
+	// ControlNumber is ISA13 and IEA02
+	ControlNumber string `json:"controlNumber"`
+	// AcknowledgmentRequested is ISA14, 0 or 1
+	AcknowledgmentRequested string `json:"acknowledgmentRequested"`
+	// UsageIndicator is ISA15, P for production or T for test
+	UsageIndicator string `json:"usageIndicator"`
+	// ComponentSeparator is ISA16
+	ComponentSeparator string `json:"componentSeparator"`
+}
+
+// X12FunctionalGroup is the GS functional group header of an X12 payload
+type X12FunctionalGroup struct {
+	// FunctionalID is GS01, RA for remittance advice
+	FunctionalID string `json:"functionalID"`
+	// SenderCode is GS02
+	SenderCode string `json:"senderCode"`
+	// ReceiverCode is GS03
+	ReceiverCode string `json:"receiverCode"`
+	// Date is the date and time of GS04 and GS05
+	Date time.Time `json:"date"`
+	// ControlNumber is GS06 and GE02
+	ControlNumber string `json:"controlNumber"`
+	// Agency is GS07, X for ASC X12
+	Agency string `json:"agency"`
+	// Version is GS08, such as 004010
+	Version string `json:"version"`
+}
+
+// X12Payment is the BPR beginning segment of an 820 transaction set
+type X12Payment struct {
+	// TransactionHandlingCode is BPR01, such as C for payment accompanies remittance advice
+	TransactionHandlingCode string `json:"transactionHandlingCode"`
+	// Amount is BPR02 in cents
+	Amount int `json:"amount"`
+	// CreditDebitFlag is BPR03, C or D
+	CreditDebitFlag string `json:"creditDebitFlag"`
+	// PaymentMethod is BPR04, such as ACH
+	PaymentMethod string `json:"paymentMethod"`
+	// PaymentFormat is BPR05, such as CTX
+	PaymentFormat string `json:"paymentFormat"`
+	// EffectiveDate is BPR16
+	EffectiveDate time.Time `json:"effectiveDate"`
+}
+
+// X12Trace is the TRN trace segment of an 820 transaction set
+type X12Trace struct {
+	// TraceType is TRN01, 1 for current transaction trace numbers
+	TraceType string `json:"traceType"`
+	// ReferenceID is TRN02, the reassociation number of the payment
+	ReferenceID string `json:"referenceID"`
+	// OriginatingCompanyID is TRN03
+	OriginatingCompanyID string `json:"originatingCompanyID"`
+}
+
+// X12Reference is a REF reference identification segment
+type X12Reference struct {
+	// Qualifier is REF01, such as PO for purchase order number
+	Qualifier string `json:"qualifier"`
+	// ID is REF02
+	ID string `json:"id"`
+	// Description is REF03
+	Description string `json:"description,omitempty"`
+}
+
+// X12Date is a DTM date segment
+type X12Date struct {
+	// Qualifier is DTM01, such as 003 for the invoice date
+	Qualifier string `json:"qualifier"`
+	// Date is DTM02
+	Date time.Time `json:"date"`
+}
+
+// X12RemittanceDetail is an RMR remittance advice segment with the REF and DTM segments that
+// follow it
+type X12RemittanceDetail struct {
+	// Qualifier is RMR01, such as IV for invoice number
+	Qualifier string `json:"qualifier"`
+	// ReferenceID is RMR02, such as the invoice number
+	ReferenceID string `json:"referenceID"`
+	// PaymentActionCode is RMR03
+	PaymentActionCode string `json:"paymentActionCode,omitempty"`
+	// AmountPaid is RMR04 in cents
+	AmountPaid int `json:"amountPaid"`
+	// AmountInvoiced is RMR05 in cents
+	AmountInvoiced int `json:"amountInvoiced,omitempty"`
+	// DiscountAmount is RMR06 in cents
+	DiscountAmount int `json:"discountAmount,omitempty"`
+	// References are the REF segments of the detail
+	References []X12Reference `json:"references,omitempty"`
+	// Dates are the DTM segments of the detail
+	Dates []X12Date `json:"dates,omitempty"`
+}
+
+// Remittance is an ANSI X12 820 Payment Order/Remittance Advice carried in the payment
+// related information of the Addenda05 records of a CTX entry. The ISA interchange and GS
+// functional group envelopes are optional. Segments of the 820 that are not modeled, such as
+// N1 name loops, are skipped when parsing.
+type Remittance struct {
+	// Interchange is the ISA/IEA envelope
+	Interchange *X12Interchange `json:"interchange,omitempty"`
+	// Group is the GS/GE envelope
+	Group *X12FunctionalGroup `json:"group,omitempty"`
+	// ControlNumber is ST02 and SE02 of the transaction set
+	ControlNumber string `json:"controlNumber"`
+	// Payment is the BPR segment
+	Payment X12Payment `json:"payment"`
+	// Trace is the TRN segment
+	Trace X12Trace `json:"trace"`
+	// References are the REF segments before the first RMR
+	References []X12Reference `json:"references,omitempty"`
+	// Dates are the DTM segments before the first RMR
+	Dates []X12Date `json:"dates,omitempty"`
+	// Details are the RMR segments
+	Details []X12RemittanceDetail `json:"details,omitempty"`
+}
+
+// CTXPayload returns the payment related information of the Addenda05 records of entry
+// joined in order. Addenda05 records hold 80 characters each and segments continue from one
+// record to the next.
+func CTXPayload(entry *EntryDetail) string {
+	var buf strings.Builder
+	for _, addenda := range entry.Addendum {
+		if addenda05, ok := addenda.(*Addenda05); ok {
+			buf.WriteString(addenda05.PaymentRelatedInformationField())
+		}
+	}
+	return strings.TrimRight(buf.String(), " ")
+}
+
+// ParseRemittance parses the 820 remittance in the Addenda05 records of a CTX entry
+func ParseRemittance(entry *EntryDetail) (*Remittance, error) {
+	payload := CTXPayload(entry)
+	if payload == "" {
+		return nil, &FieldError{FieldName: "Addendum", Value: entry.CTXAddendaRecordsField(), Msg: msgX12NoAddenda, Code: ErrInvalidX12.Code}
+	}
+	segments, err := ParseX12(payload)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemittanceSegments(segments)
+}
+
+// ParseRemittanceSegments parses an 820 remittance from X12 segments
+func ParseRemittanceSegments(segments []X12Segment) (*Remittance, error) {
+	rem := &Remittance{}
+	var detail *X12RemittanceDetail
+	var groupControl string
+	stCount := 0
+	seen := make(map[string]bool)
+	for _, s := range segments {
+		seen[s.ID] = true
+		if stCount > 0 {
+			stCount++
+		}
+		var err error
+		switch s.ID {
+		case "ISA":
+			rem.Interchange, err = parseX12Interchange(s)
+		case "IEA":
+			if rem.Interchange != nil && s.Element(2) != rem.Interchange.ControlNumber {
+				err = x12Error("IEA02", s.Element(2), fmt.Sprintf(msgX12ControlNumber, rem.Interchange.ControlNumber))
+			}
+		case "GS":
+			rem.Group, err = parseX12FunctionalGroup(s)
+			if rem.Group != nil {
+				groupControl = rem.Group.ControlNumber
+			}
+		case "GE":
+			if s.Element(2) != groupControl {
+				err = x12Error("GE02", s.Element(2), fmt.Sprintf(msgX12ControlNumber, groupControl))
+			}
+		case "ST":
+			if s.Element(1) != "820" {
+				err = x12Error("ST01", s.Element(1), msgX12Transaction)
+			}
+			rem.ControlNumber = s.Element(2)
+			stCount = 1
+		case "SE":
+			if s.Element(2) != rem.ControlNumber {
+				err = x12Error("SE02", s.Element(2), fmt.Sprintf(msgX12ControlNumber, rem.ControlNumber))
+			} else if count, _ := strconv.Atoi(s.Element(1)); count != stCount {
+				err = x12Error("SE01", s.Element(1), fmt.Sprintf(msgX12SegmentCount, stCount))
+			}
+			stCount = 0
+		case "BPR":
+			rem.Payment, err = parseX12Payment(s)
+		case "TRN":
+			rem.Trace = X12Trace{TraceType: s.Element(1), ReferenceID: s.Element(2), OriginatingCompanyID: s.Element(3)}
+		case "REF":
+			ref := X12Reference{Qualifier: s.Element(1), ID: s.Element(2), Description: s.Element(3)}
+			if detail != nil {
+				detail.References = append(detail.References, ref)
+			} else {
+				rem.References = append(rem.References, ref)
+			}
+		case "DTM":
+			var date X12Date
+			date, err = parseX12Date(s)
+			if detail != nil {
+				detail.Dates = append(detail.Dates, date)
+			} else {
+				rem.Dates = append(rem.Dates, date)
+			}
+		case "RMR":
+			var d X12RemittanceDetail
+			d, err = parseX12RemittanceDetail(s)
+			rem.Details = append(rem.Details, d)
+			detail = &rem.Details[len(rem.Details)-1]
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range []string{"ST", "BPR", "SE"} {
+		if !seen[id] {
+			return nil, x12Error(id, "", msgX12SegmentMissing)
+		}
+	}
+	return rem, nil
+}
+
+// Segments returns the X12 segments of the remittance with the SE segment count and the
+// envelope control numbers filled in
+func (rem *Remittance) Segments() []X12Segment {
+	var segments []X12Segment
+	if isa := rem.Interchange; isa != nil {
+		segments = append(segments, X12Segment{ID: "ISA", Elements: []string{
+			padX12(isa.AuthorizationQualifier, 2), padX12(isa.Authorization, 10),
+			padX12(isa.SecurityQualifier, 2), padX12(isa.Security, 10),
+			padX12(isa.SenderQualifier, 2), padX12(isa.SenderID, 15),
+			padX12(isa.ReceiverQualifier, 2), padX12(isa.ReceiverID, 15),
+			isa.Date.Format("060102"), isa.Date.Format("1504"),
+			padX12(isa.StandardsID, 1), padX12(isa.Version, 5),
+			fmt.Sprintf("%09s", isa.ControlNumber), padX12(isa.AcknowledgmentRequested, 1),
+			padX12(isa.UsageIndicator, 1), padX12(isa.ComponentSeparator, 1),
+		}})
+	}
+	if gs := rem.Group; gs != nil {
+		segments = append(segments, X12Segment{ID: "GS", Elements: []string{
+			gs.FunctionalID, gs.SenderCode, gs.ReceiverCode, gs.Date.Format("20060102"), gs.Date.Format("1504"),
+			gs.ControlNumber, gs.Agency, gs.Version,
+		}})
+	}
+	start := len(segments)
+	segments = append(segments, X12Segment{ID: "ST", Elements: []string{"820", rem.ControlNumber}})
+
+	bpr := make([]string, 16)
+	bpr[0] = rem.Payment.TransactionHandlingCode
+	bpr[1] = formatX12Amount(rem.Payment.Amount)
+	bpr[2] = rem.Payment.CreditDebitFlag
+	bpr[3] = rem.Payment.PaymentMethod
+	bpr[4] = rem.Payment.PaymentFormat
+	if !rem.Payment.EffectiveDate.IsZero() {
+		bpr[15] = rem.Payment.EffectiveDate.Format("20060102")
+	}
+	segments = append(segments, X12Segment{ID: "BPR", Elements: bpr})
+	if rem.Trace != (X12Trace{}) {
+		segments = append(segments, X12Segment{ID: "TRN", Elements: []string{rem.Trace.TraceType, rem.Trace.ReferenceID, rem.Trace.OriginatingCompanyID}})
+	}
+	segments = appendX12References(segments, rem.References, rem.Dates)
+	for _, d := range rem.Details {
+		rmr := []string{d.Qualifier, d.ReferenceID, d.PaymentActionCode, formatX12Amount(d.AmountPaid), "", ""}
+		if d.AmountInvoiced != 0 {
+			rmr[4] = formatX12Amount(d.AmountInvoiced)
+		}
+		if d.DiscountAmount != 0 {
+			rmr[5] = formatX12Amount(d.DiscountAmount)
+		}
+		segments = append(segments, X12Segment{ID: "RMR", Elements: rmr})
+		segments = appendX12References(segments, d.References, d.Dates)
+	}
+	count := len(segments) - start + 1
+	segments = append(segments, X12Segment{ID: "SE", Elements: []string{strconv.Itoa(count), rem.ControlNumber}})
+
+	if rem.Group != nil {
+		segments = append(segments, X12Segment{ID: "GE", Elements: []string{"1", rem.Group.ControlNumber}})
+	}
+	if rem.Interchange != nil {
+		segments = append(segments, X12Segment{ID: "IEA", Elements: []string{"1", fmt.Sprintf("%09s", rem.Interchange.ControlNumber)}})
+	}
+	return segments
+}
+
+// String writes the remittance as an X12 payload with X12ElementSeparator and
+// X12SegmentTerminator
+func (rem *Remittance) String() string {
+	var buf strings.Builder
+	for _, s := range rem.Segments() {
+		buf.WriteString(s.String())
+		buf.WriteString(X12SegmentTerminator)
+	}
+	return buf.String()
+}
+
+// Addenda05 splits the remittance into the Addenda05 records of a CTX entry. Records are
+// numbered from 1. A record only ends early, at the end of a segment, when the next record
+// would begin with a space, which Addenda05.Parse does not keep.
+func (rem *Remittance) Addenda05() []*Addenda05 {
+	payload := rem.String()
+	var records []*Addenda05
+	for len(payload) > 0 {
+		n := 80
+		if n >= len(payload) {
+			n = len(payload)
+		} else if payload[n] == ' ' {
+			if i := strings.LastIndex(payload[:n], X12SegmentTerminator); i >= 0 {
+				n = i + 1
+			}
+		}
+		addenda05 := NewAddenda05()
+		addenda05.PaymentRelatedInformation = payload[:n]
+		addenda05.SequenceNumber = len(records) + 1
+		records = append(records, addenda05)
+		payload = payload[n:]
+	}
+	return records
+}
+
+// SetRemittance replaces the addenda of a CTX entry with the Addenda05 records of rem and sets
+// the AddendaRecordIndicator and the CTX number of addenda records. The receiving company of
+// the entry is kept.
+func SetRemittance(entry *EntryDetail, rem *Remittance) error {
+	records := rem.Addenda05()
+	if len(records) > 9999 {
+		return &FieldError{FieldName: "Addendum", Value: strconv.Itoa(len(records)), Msg: msgBatchCTXAddenda, Code: ErrBatchAddendaCount.Code}
+	}
+	company := ""
+	if len(entry.IndividualName) >= 20 {
+		company = entry.CTXReceivingCompanyField()
+	}
+	entry.Addendum = nil
+	for _, addenda05 := range records {
+		addenda05.EntryDetailSequenceNumber = entry.parseNumField(entry.TraceNumberField()[8:])
+		entry.AddAddenda(addenda05)
+	}
+	entry.AddendaRecordIndicator = 1
+	entry.SetCTXAddendaRecords(len(records))
+	entry.SetCTXReceivingCompany(company)
+	return nil
+}
+
+// appendX12References appends REF and DTM segments to segments
+func appendX12References(segments []X12Segment, refs []X12Reference, dates []X12Date) []X12Segment {
+	for _, ref := range refs {
+		segments = append(segments, X12Segment{ID: "REF", Elements: []string{ref.Qualifier, ref.ID, ref.Description}})
+	}
+	for _, date := range dates {
+		segments = append(segments, X12Segment{ID: "DTM", Elements: []string{date.Qualifier, date.Date.Format("20060102")}})
+	}
+	return segments
+}
+
+func parseX12Interchange(s X12Segment) (*X12Interchange, error) {
+	if len(s.Elements) != 16 {
+		return nil, x12Error("ISA", s.String(), fmt.Sprintf(msgX12Segment, "ISA"))
+	}
+	date, err := time.Parse("0601021504", s.Element(9)+s.Element(10))
+	if err != nil {
+		return nil, x12Error("ISA09", s.Element(9)+s.Element(10), fmt.Sprintf(msgX12Date, "YYMMDD HHMM"))
+	}
+	return &X12Interchange{
+		AuthorizationQualifier:  s.Element(1),
+		Authorization:           s.Element(2),
+		SecurityQualifier:       s.Element(3),
+		Security:                s.Element(4),
+		SenderQualifier:         s.Element(5),
+		SenderID:                s.Element(6),
+		ReceiverQualifier:       s.Element(7),
+		ReceiverID:              s.Element(8),
+		Date:                    date,
+		StandardsID:             s.Element(11),
+		Version:                 s.Element(12),
+		ControlNumber:           s.Element(13),
+		AcknowledgmentRequested: s.Element(14),
+		UsageIndicator:          s.Element(15),
+		ComponentSeparator:      s.Element(16),
+	}, nil
+}
+
+func parseX12FunctionalGroup(s X12Segment) (*X12FunctionalGroup, error) {
+	date, err := time.Parse("200601021504", s.Element(4)+s.Element(5))
+	if err != nil {
+		return nil, x12Error("GS04", s.Element(4)+s.Element(5), fmt.Sprintf(msgX12Date, "CCYYMMDD HHMM"))
+	}
+	return &X12FunctionalGroup{
+		FunctionalID:  s.Element(1),
+		SenderCode:    s.Element(2),
+		ReceiverCode:  s.Element(3),
+		Date:          date,
+		ControlNumber: s.Element(6),
+		Agency:        s.Element(7),
+		Version:       s.Element(8),
+	}, nil
+}
+
+func parseX12Payment(s X12Segment) (X12Payment, error) {
+	amount, err := parseX12Amount("BPR02", s.Element(2))
+	if err != nil {
+		return X12Payment{}, err
+	}
+	payment := X12Payment{
+		TransactionHandlingCode: s.Element(1),
+		Amount:                  amount,
+		CreditDebitFlag:         s.Element(3),
+		PaymentMethod:           s.Element(4),
+		PaymentFormat:           s.Element(5),
+	}
+	if v := s.Element(16); v != "" {
+		if payment.EffectiveDate, err = time.Parse("20060102", v); err != nil {
+			return X12Payment{}, x12Error("BPR16", v, fmt.Sprintf(msgX12Date, "CCYYMMDD"))
+		}
+	}
+	return payment, nil
+}
+
+func parseX12Date(s X12Segment) (X12Date, error) {
+	date, err := time.Parse("20060102", s.Element(2))
+	if err != nil {
+		return X12Date{}, x12Error("DTM02", s.Element(2), fmt.Sprintf(msgX12Date, "CCYYMMDD"))
+	}
+	return X12Date{Qualifier: s.Element(1), Date: date}, nil
+}
+
+func parseX12RemittanceDetail(s X12Segment) (X12RemittanceDetail, error) {
+	d := X12RemittanceDetail{Qualifier: s.Element(1), ReferenceID: s.Element(2), PaymentActionCode: s.Element(3)}
+	var err error
+	if d.AmountPaid, err = parseX12Amount("RMR04", s.Element(4)); err != nil {
+		return d, err
+	}
+	if d.AmountInvoiced, err = parseX12Amount("RMR05", s.Element(5)); err != nil {
+		return d, err
+	}
+	if d.DiscountAmount, err = parseX12Amount("RMR06", s.Element(6)); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// parseX12Amount parses a decimal X12 amount such as 1250, 1250.5 or -3.25 into cents
+func parseX12Amount(element, s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || strings.ContainsAny(s, "eE") {
+		return 0, x12Error(element, s, msgX12Amount)
+	}
+	if f < 0 {
+		return int(f*100 - 0.5), nil
+	}
+	return int(f*100 + 0.5), nil
+}
+
+// formatX12Amount formats cents as a decimal X12 amount with two decimal places
+func formatX12Amount(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// padX12 right pads s with spaces to the fixed width of an ISA element
+func padX12(s string, width int) string {
+	if len(s) >= width {
+		return s[:width]
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// x12Error returns a FieldError of an X12 element such as BPR02
+func x12Error(element, value, msg string) error {
+	return &FieldError{FieldName: element, Value: value, Msg: msg, Code: ErrInvalidX12.Code}
+}
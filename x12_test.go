@@ -0,0 +1,187 @@
+// Copyright 2018 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ach
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockRemittance creates an 820 remittance of two invoices
+func mockRemittance() *Remittance {
+	return &Remittance{
+		Interchange: &X12Interchange{
+			AuthorizationQualifier:  "00",
+			SecurityQualifier:       "00",
+			SenderQualifier:         "ZZ",
+			SenderID:                "PAYER",
+			ReceiverQualifier:       "ZZ",
+			ReceiverID:              "PAYEE",
+			Date:                    time.Date(2018, time.October, 22, 9, 30, 0, 0, time.UTC),
+			StandardsID:             "U",
+			Version:                 "00401",
+			ControlNumber:           "000000001",
+			AcknowledgmentRequested: "0",
+			UsageIndicator:          "P",
+			ComponentSeparator:      ">",
+		},
+		Group: &X12FunctionalGroup{
+			FunctionalID:  "RA",
+			SenderCode:    "PAYER",
+			ReceiverCode:  "PAYEE",
+			Date:          time.Date(2018, time.October, 22, 9, 30, 0, 0, time.UTC),
+			ControlNumber: "1",
+			Agency:        "X",
+			Version:       "004010",
+		},
+		ControlNumber: "0001",
+		Payment: X12Payment{
+			TransactionHandlingCode: "C",
+			Amount:                  25000,
+			CreditDebitFlag:         "C",
+			PaymentMethod:           "ACH",
+			PaymentFormat:           "CTX",
+			EffectiveDate:           time.Date(2018, time.October, 23, 0, 0, 0, 0, time.UTC),
+		},
+		Trace:      X12Trace{TraceType: "1", ReferenceID: "0012345", OriginatingCompanyID: "1121042882"},
+		References: []X12Reference{{Qualifier: "VR", ID: "V12345"}},
+		Details: []X12RemittanceDetail{
+			{
+				Qualifier:      "IV",
+				ReferenceID:    "INV-1001",
+				AmountPaid:     15000,
+				AmountInvoiced: 15500,
+				DiscountAmount: 500,
+				References:     []X12Reference{{Qualifier: "PO", ID: "PO 778", Description: "Office supplies"}},
+				Dates:          []X12Date{{Qualifier: "003", Date: time.Date(2018, time.October, 1, 0, 0, 0, 0, time.UTC)}},
+			},
+			{Qualifier: "IV", ReferenceID: "INV-1002", AmountPaid: 10000},
+		},
+	}
+}
+
+// testRemittanceAddenda05 validates splitting a remittance into CTX addenda and parsing it back
+func testRemittanceAddenda05(t testing.TB) {
+	rem := mockRemittance()
+	entry := mockCTXEntryDetail()
+	if err := SetRemittance(entry, rem); err != nil {
+		t.Fatal(err)
+	}
+	if entry.CTXAddendaRecordsField() != "0005" || len(entry.Addendum) != 5 {
+		t.Errorf("CTXAddendaRecords %s", entry.CTXAddendaRecordsField())
+	}
+	if entry.CTXReceivingCompanyField() != "Receiver Company" {
+		t.Errorf("CTXReceivingCompany %q", entry.CTXReceivingCompanyField())
+	}
+	for i, addenda := range entry.Addendum {
+		addenda05 := addenda.(*Addenda05)
+		if addenda05.SequenceNumber != i+1 || len(addenda05.PaymentRelatedInformation) > 80 {
+			t.Errorf("addenda %d: %#v", i, addenda05)
+		}
+		if strings.HasPrefix(addenda05.PaymentRelatedInformation, " ") {
+			t.Errorf("addenda %d begins with a space", i)
+		}
+	}
+	batch := NewBatchCTX(mockBatchCTXHeader())
+	batch.AddEntry(entry)
+	if err := batch.Create(); err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+
+	// read the entry back from its records
+	read := new(EntryDetail)
+	read.Parse(entry.String())
+	for _, addenda := range entry.Addendum {
+		addenda05 := NewAddenda05()
+		addenda05.Parse(addenda.String())
+		read.AddAddenda(addenda05)
+	}
+	parsed, err := ParseRemittance(read)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if parsed.String() != rem.String() {
+		t.Errorf("round trip\n%s\nwant\n%s", parsed.String(), rem.String())
+	}
+	if parsed.Details[0].References[0].ID != "PO 778" || parsed.Details[0].DiscountAmount != 500 {
+		t.Errorf("unexpected detail %#v", parsed.Details[0])
+	}
+	if parsed.Interchange.ReceiverID != "PAYEE" || parsed.Payment.Amount != 25000 {
+		t.Errorf("unexpected remittance %#v", parsed)
+	}
+}
+
+// TestRemittanceAddenda05 tests splitting a remittance into CTX addenda and parsing it back
+func TestRemittanceAddenda05(t *testing.T) {
+	testRemittanceAddenda05(t)
+}
+
+// BenchmarkRemittanceAddenda05 benchmarks splitting a remittance into CTX addenda and parsing it back
+func BenchmarkRemittanceAddenda05(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testRemittanceAddenda05(b)
+	}
+}
+
+// testParseX12 validates parsing X12 payloads and their errors
+func testParseX12(t testing.TB) {
+	// delimiters of the ISA segment
+	payload := mockRemittance().String()
+	payload = strings.Replace(strings.Replace(payload, "*", "|", -1), "\\", "~\n", -1)
+	segments, err := ParseX12(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rem, err := ParseRemittanceSegments(segments)
+	if err != nil {
+		t.Fatalf("%T: %s", err, err)
+	}
+	if rem.Trace.ReferenceID != "0012345" || len(rem.Details) != 2 {
+		t.Errorf("unexpected remittance %#v", rem)
+	}
+
+	tests := map[string]string{
+		"ST*820*0001\\BPR*C*250.00*C*ACH*CTX\\SE*4*0001\\":                "SE01",
+		"ST*820*0001\\BPR*C*250.00*C*ACH*CTX\\SE*3*0002\\":                "SE02",
+		"ST*810*0001\\BPR*C*250.00*C*ACH*CTX\\SE*3*0001\\":                "ST01",
+		"ST*820*0001\\BPR*C*25O.00*C*ACH*CTX\\SE*3*0001\\":                "BPR02",
+		"ST*820*0001\\TRN*1*12345\\SE*3*0001\\":                           "BPR",
+		"ST*820*0001\\BPR*C*250*C*ACH*CTX\\DTM*003*20181301\\SE*4*0001\\": "DTM02",
+	}
+	for payload, field := range tests {
+		segments, err := ParseX12(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseRemittanceSegments(segments)
+		if e, ok := err.(*FieldError); !ok || e.FieldName != field {
+			t.Errorf("%s: %T %v want %s", payload, err, err, field)
+		}
+	}
+	if _, err := ParseRemittance(mockCTXEntryDetail()); err == nil {
+		t.Error("expected an error for an entry without addenda")
+	}
+	if got, _ := parseX12Amount("BPR02", "1250.5"); got != 125050 {
+		t.Errorf("amount %d", got)
+	}
+	if formatX12Amount(-325) != "-3.25" {
+		t.Errorf("formatX12Amount %s", formatX12Amount(-325))
+	}
+}
+
+// TestParseX12 tests parsing X12 payloads and their errors
+func TestParseX12(t *testing.T) {
+	testParseX12(t)
+}
+
+// BenchmarkParseX12 benchmarks parsing X12 payloads and their errors
+func BenchmarkParseX12(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		testParseX12(b)
+	}
+}